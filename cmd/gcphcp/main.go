@@ -4,10 +4,11 @@ import (
 	"os"
 
 	gcphcpcli "github.com/ckandag/gcp-hcp-cli/pkg/cli"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops"
 )
 
 func main() {
 	if err := gcphcpcli.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(ops.ExitCode(err))
 	}
 }
@@ -0,0 +1,178 @@
+// Package cache provides a small on-disk cache for workflow results, so
+// repeated idempotent calls (get, describe) can be served locally for a short
+// time instead of re-running the workflow.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache stores entries as files in Dir. Entries older than TTL are treated
+// as missing.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// entry is the on-disk form of a cached value.
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// New returns a cache rooted at dir whose entries expire after ttl.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl, now: time.Now}
+}
+
+// DefaultDir returns the cache directory under the given config directory.
+func DefaultDir(configDir string) string {
+	return filepath.Join(configDir, "cache")
+}
+
+// Key derives a cache key from its parts (e.g. project, region, workflow and
+// the JSON-encoded arguments).
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the data stored under key if it exists and is fresh.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, false
+	}
+	if c.now().Sub(e.StoredAt) > c.TTL {
+		return nil, false
+	}
+	return e.Data, true
+}
+
+// Age returns how long ago key was stored, or false if it is not cached.
+func (c *Cache) Age(key string) (time.Duration, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return 0, false
+	}
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return 0, false
+	}
+	return c.now().Sub(e.StoredAt), true
+}
+
+// Put stores data (which must be valid JSON) under key. The file is written
+// to a temporary name and renamed, so readers never see a partial entry.
+func (c *Cache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	raw, err := json.Marshal(entry{StoredAt: c.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	return nil
+}
+
+// DefaultMaxAge is how long an entry is kept on disk before Prune removes
+// it, whatever the TTL it was read with.
+const DefaultMaxAge = 24 * time.Hour
+
+// Clear removes every entry from the cache and returns how many were
+// removed. A missing cache directory is empty.
+func (c *Cache) Clear() (int, error) {
+	return c.remove(func(string) bool { return true })
+}
+
+// Prune removes entries stored more than maxAge ago, along with unreadable
+// entries and temporary files left by interrupted writes, and returns how
+// many were removed.
+func (c *Cache) Prune(maxAge time.Duration) (int, error) {
+	return c.remove(func(path string) bool {
+		if strings.HasSuffix(path, ".tmp") {
+			info, err := os.Stat(path)
+			return err == nil && c.now().Sub(info.ModTime()) > maxAge
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		var e entry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return true
+		}
+		return c.now().Sub(e.StoredAt) > maxAge
+	})
+}
+
+// remove deletes the entry and temporary files in Dir for which stale
+// returns true, and returns how many entries (not temporary files) it
+// deleted.
+func (c *Cache) remove(stale func(path string) bool) (int, error) {
+	files, err := os.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	removed := 0
+	for _, f := range files {
+		name := f.Name()
+		isEntry := strings.HasSuffix(name, ".json")
+		if f.IsDir() || !isEntry && !strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+		path := filepath.Join(c.Dir, name)
+		if !stale(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("removing cache entry: %w", err)
+		}
+		if isEntry {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T, ttl time.Duration) (*Cache, *time.Time) {
+	t.Helper()
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New(filepath.Join(t.TempDir(), "cache"), ttl)
+	c.now = func() time.Time { return clock }
+	return c, &clock
+}
+
+func TestCache_HitMissExpiry(t *testing.T) {
+	c, clock := newTestCache(t, 30*time.Second)
+	key := Key("proj", "us-central1", "get", `{"resource_type":"nodes"}`)
+
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected miss before Put")
+	}
+
+	if err := c.Put(key, []byte(`{"items":[]}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	*clock = clock.Add(10 * time.Second)
+	got, ok := c.Get(key)
+	if !ok {
+		t.Fatal("expected hit within TTL")
+	}
+	if string(got) != `{"items":[]}` {
+		t.Errorf("got %s", got)
+	}
+	if age, ok := c.Age(key); !ok || age != 10*time.Second {
+		t.Errorf("Age() = %v, %v; want 10s, true", age, ok)
+	}
+
+	*clock = clock.Add(21 * time.Second)
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected miss after TTL")
+	}
+}
+
+func TestCache_PutReplacesAtomically(t *testing.T) {
+	c, _ := newTestCache(t, time.Minute)
+	key := Key("a")
+
+	if err := c.Put(key, []byte(`1`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put(key, []byte(`2`)); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := c.Get(key)
+	if !ok || string(got) != "2" {
+		t.Errorf("Get() = %s, %v; want 2, true", got, ok)
+	}
+
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the entry file, found %d files", len(entries))
+	}
+}
+
+func TestCache_CorruptEntryIsMiss(t *testing.T) {
+	c, _ := newTestCache(t, time.Minute)
+	key := Key("a")
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(c.path(key), []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(key); ok {
+		t.Error("expected miss for corrupt entry")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if Key("a", "b") == Key("ab") {
+		t.Error("keys with different part boundaries should differ")
+	}
+	if Key("p", "r", "get", "{}") != Key("p", "r", "get", "{}") {
+		t.Error("keys should be deterministic")
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	c, clock := newTestCache(t, time.Minute)
+	stale, fresh := Key("stale"), Key("fresh")
+
+	if err := c.Put(stale, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(2 * time.Hour)
+	if err := c.Put(fresh, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(c.Dir, Key("corrupt")+".json"), []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(10 * time.Minute)
+
+	removed, err := c.Prune(time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed %d entries, want 2 (stale and corrupt)", removed)
+	}
+	if _, ok := c.Age(stale); ok {
+		t.Error("expected the stale entry to be removed")
+	}
+	if age, ok := c.Age(fresh); !ok || age != 10*time.Minute {
+		t.Errorf("expected the fresh entry to be kept, got age %s, %v", age, ok)
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	c, _ := newTestCache(t, time.Minute)
+
+	if removed, err := c.Clear(); err != nil || removed != 0 {
+		t.Fatalf("Clear() on a missing directory = %d, %v; want 0, nil", removed, err)
+	}
+	for _, k := range []string{"a", "b"} {
+		if err := c.Put(Key(k), []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := c.Clear()
+	if err != nil || removed != 2 {
+		t.Fatalf("Clear() = %d, %v; want 2, nil", removed, err)
+	}
+	if _, ok := c.Get(Key("a")); ok {
+		t.Error("expected no entries after Clear")
+	}
+}
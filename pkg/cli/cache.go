@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/cache"
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local workflow result cache",
+	}
+
+	var maxAge time.Duration
+	clear := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove cached workflow results",
+		Long: `Remove the workflow results cached by --cache-ttl and the workflow names
+cached for shell completion.
+
+With --max-age, only entries stored longer ago than that are removed; the
+same pruning runs with a 24h max age whenever a cached command stores a
+result.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("max-age") && maxAge <= 0 {
+				return fmt.Errorf("--max-age must be positive")
+			}
+			dir := config.ResolveDir(globals.ConfigPath)
+			if dir == "" {
+				return fmt.Errorf("cannot locate the config directory for the cache")
+			}
+			c := cache.New(cache.DefaultDir(dir), 0)
+
+			var removed int
+			var err error
+			if maxAge > 0 {
+				removed, err = c.Prune(maxAge)
+			} else {
+				removed, err = c.Clear()
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached %s from %s\n", removed, entryNoun(removed), c.Dir)
+			return nil
+		},
+	}
+	clear.Flags().DurationVar(&maxAge, "max-age", 0, "Only remove entries stored longer ago than this (e.g. 1h)")
+	cmd.AddCommand(clear)
+
+	return cmd
+}
+
+func entryNoun(n int) string {
+	if n == 1 {
+		return "entry"
+	}
+	return "entries"
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the gcphcp config file",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check the config file for unknown keys and invalid values",
+		Long: `Load the config file and check it for mistakes that would otherwise be
+silently ignored: unknown top-level keys (warnings), an unsupported output
+format, a region that does not look like a GCP region, or a current-context
+that is not defined (errors).
+
+The path defaults to --config, then $GCPHCP_CONFIG, then
+$XDG_CONFIG_HOME/gcphcp/config.yaml. Exits non-zero if any error is found.`,
+		Args: cobra.MaximumNArgs(1),
+		// The config being validated may be the one that fails to load.
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := globals.ConfigPath
+			if len(args) == 1 {
+				path = args[0]
+			}
+			path = config.ResolvePath(path)
+			if path == "" {
+				return fmt.Errorf("no config file path: pass one or set --config")
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("config file %s: %w", path, err)
+			}
+
+			cfg, err := config.Load(path)
+			if err != nil {
+				return err
+			}
+			unknown, err := config.UnknownKeys(path)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, key := range unknown {
+				fmt.Fprintf(out, "warning: unknown key %q (ignored)\n", key)
+			}
+			errs := cfg.Validate()
+			for _, err := range errs {
+				fmt.Fprintf(out, "error: %v\n", err)
+			}
+			if len(errs) > 0 {
+				return fmt.Errorf("%s: %d error(s) found", path, len(errs))
+			}
+			fmt.Fprintf(out, "%s is valid\n", path)
+			return nil
+		},
+	})
+
+	return cmd
+}
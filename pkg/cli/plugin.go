@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the executable name prefix for plugins: "gcphcp foo" runs
+// "gcphcp-foo" from $PATH when foo is not a built-in command.
+const pluginPrefix = "gcphcp-"
+
+// plugin is an executable on $PATH that extends gcphcp.
+type plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// discoverPlugins returns the plugins found in the directories of pathList
+// (a $PATH-style list), sorted by name. When several directories provide the
+// same plugin, the first one on the path wins, as it would for the shell.
+func discoverPlugins(pathList string) []plugin {
+	seen := map[string]bool{}
+	var plugins []plugin
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), pluginPrefix)
+			if !ok || name == "" || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// findPlugin returns the path of the gcphcp-<name> plugin on pathList.
+func findPlugin(name, pathList string) (string, bool) {
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, pluginPrefix+name)
+		if isExecutable(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+// pluginInvocation splits command-line args into the persistent flags before
+// the first positional argument, the plugin name, and the plugin's own args.
+// It returns ok=false when the first positional argument is a built-in
+// command, or there is none.
+func pluginInvocation(root *cobra.Command, args []string) (flags []string, name string, rest []string, ok bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return nil, "", nil, false
+		}
+		if strings.HasPrefix(arg, "-") {
+			if flagTakesNextArg(root, arg) {
+				i++
+			}
+			continue
+		}
+		if cmd, _, err := root.Find([]string{arg}); err == nil && cmd != root {
+			return nil, "", nil, false
+		}
+		return args[:i], arg, args[i+1:], true
+	}
+	return nil, "", nil, false
+}
+
+// flagTakesNextArg reports whether arg is a persistent flag whose value is
+// the following argument, e.g. "--project p" or "-o json".
+func flagTakesNextArg(root *cobra.Command, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	flags := root.PersistentFlags()
+	if name, ok := strings.CutPrefix(arg, "--"); ok {
+		f := flags.Lookup(name)
+		return f != nil && f.NoOptDefVal == ""
+	}
+	if len(arg) == 2 {
+		f := flags.ShorthandLookup(arg[1:])
+		return f != nil && f.NoOptDefVal == ""
+	}
+	return false
+}
+
+// runPlugin replaces the process with the gcphcp-<name> plugin when args
+// name one that is not a built-in command. Resolved project, region, and
+// config path are passed to the plugin through its environment. It returns
+// handled=false when args do not invoke a plugin.
+func runPlugin(root *cobra.Command, args []string) (handled bool, err error) {
+	flags, name, rest, ok := pluginInvocation(root, args)
+	if !ok {
+		return false, nil
+	}
+	path, found := findPlugin(name, os.Getenv("PATH"))
+	if !found {
+		return false, nil
+	}
+
+	if err := root.PersistentFlags().Parse(flags); err != nil {
+		return true, err
+	}
+	if _, err := globals.ResolveConfig(); err != nil {
+		return true, err
+	}
+
+	env := os.Environ()
+	env = append(env, "GCPHCP_PROJECT="+globals.Project, "GCPHCP_REGION="+globals.Region)
+	cfgPath := globals.ConfigPath
+	if cfgPath == "" {
+		cfgPath = config.DefaultConfigPath()
+	}
+	if cfgPath != "" {
+		env = append(env, config.EnvConfigPath+"="+cfgPath)
+	}
+
+	return true, syscall.Exec(path, append([]string{path}, rest...), env)
+}
+
+func newPluginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage gcphcp plugins",
+		Long: `Plugins are executables named gcphcp-<name> on $PATH. Running
+"gcphcp <name> [args...]" executes the plugin when <name> is not a built-in
+command, with GCPHCP_PROJECT, GCPHCP_REGION, and GCPHCP_CONFIG set from the
+resolved flags, environment, and config file.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List plugins found on $PATH",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			plugins := discoverPlugins(os.Getenv("PATH"))
+			out := cmd.OutOrStdout()
+
+			if output.ParseFormat(getOutputFormat()) == output.FormatJSON {
+				if plugins == nil {
+					plugins = []plugin{}
+				}
+				return output.PrintJSON(out, plugins)
+			}
+
+			if len(plugins) == 0 {
+				fmt.Fprintln(out, "No plugins found on $PATH.")
+				return nil
+			}
+			t := output.NewTable(out, "NAME", "PATH")
+			for _, p := range plugins {
+				path := p.Path
+				if c, _, err := rootCmd.Find([]string{p.Name}); err == nil && c != rootCmd {
+					path += " (shadowed by built-in command)"
+				}
+				t.AddRow(p.Name, path)
+			}
+			return t.Flush()
+		},
+	})
+
+	return cmd
+}
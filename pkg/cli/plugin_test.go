@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writePlugin creates an executable fake plugin script in dir.
+func writePlugin(t *testing.T, dir, file string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho plugin\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+
+	hello := writePlugin(t, first, "gcphcp-hello", 0o755)
+	writePlugin(t, first, "gcphcp-noexec", 0o644)
+	writePlugin(t, first, "kubectl-hello", 0o755)
+	writePlugin(t, second, "gcphcp-hello", 0o755)
+	audit := writePlugin(t, second, "gcphcp-audit", 0o755)
+
+	pathList := strings.Join([]string{first, "", filepath.Join(first, "missing"), second}, string(os.PathListSeparator))
+	got := discoverPlugins(pathList)
+
+	want := []plugin{
+		{Name: "audit", Path: audit},
+		{Name: "hello", Path: hello},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	dir := t.TempDir()
+	hello := writePlugin(t, dir, "gcphcp-hello", 0o755)
+	writePlugin(t, dir, "gcphcp-noexec", 0o644)
+
+	if path, ok := findPlugin("hello", dir); !ok || path != hello {
+		t.Errorf("findPlugin(hello) = %q, %v", path, ok)
+	}
+	if _, ok := findPlugin("noexec", dir); ok {
+		t.Error("a non-executable file should not be a plugin")
+	}
+	if _, ok := findPlugin("missing", dir); ok {
+		t.Error("expected missing plugin not to be found")
+	}
+}
+
+func TestPluginInvocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantOK    bool
+		wantFlags []string
+		wantName  string
+		wantRest  []string
+	}{
+		{
+			name:      "plugin name follows persistent flags",
+			args:      []string{"--project", "p", "-o", "json", "hello", "world", "--flag"},
+			wantOK:    true,
+			wantFlags: []string{"--project", "p", "-o", "json"},
+			wantName:  "hello",
+			wantRest:  []string{"world", "--flag"},
+		},
+		{
+			name:      "flags use = or are booleans",
+			args:      []string{"--region=us-central1", "-v", "hello"},
+			wantOK:    true,
+			wantFlags: []string{"--region=us-central1", "-v"},
+			wantName:  "hello",
+			wantRest:  []string{},
+		},
+		{
+			name:   "first argument is a built-in command",
+			args:   []string{"ops", "get", "pods"},
+			wantOK: false,
+		},
+		{
+			name:   "there is no positional argument",
+			args:   []string{"--project", "p"},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, name, rest, ok := pluginInvocation(rootCmd, tt.args)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) || name != tt.wantName || !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("got flags=%v name=%q rest=%v", flags, name, rest)
+			}
+		})
+	}
+}
+
+func TestPluginListCmd(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "gcphcp-hello", 0o755)
+	writePlugin(t, dir, "gcphcp-ops", 0o755)
+	t.Setenv("PATH", dir)
+
+	cmd := newPluginCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"list"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "hello") || !strings.Contains(out, filepath.Join(dir, "gcphcp-hello")) {
+		t.Errorf("expected hello plugin in output, got:\n%s", out)
+	}
+	if !strings.Contains(out, "gcphcp-ops (shadowed by built-in command)") {
+		t.Errorf("expected ops plugin to be marked as shadowed, got:\n%s", out)
+	}
+}
+
+func TestLoadConfig_RegionOnlyCheckedForOps(t *testing.T) {
+	origRegion, origConfig := globals.Region, globals.ConfigPath
+	t.Cleanup(func() { globals.Region, globals.ConfigPath = origRegion, origConfig })
+	globals.ConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	pluginList, _, err := rootCmd.Find([]string{"plugin", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals.Region = "us-east-1"
+	if err := loadConfig(pluginList); err != nil {
+		t.Errorf("plugin list should ignore a malformed region, got %v", err)
+	}
+
+	opsGet, _, err := rootCmd.Find([]string{"ops", "get"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals.Region = "us-east-1"
+	if err := loadConfig(opsGet); err == nil || !strings.Contains(err.Error(), "did you mean us-east1?") {
+		t.Errorf("ops get should reject a malformed region, got %v", err)
+	}
+}
@@ -4,18 +4,18 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/ckandag/gcp-hcp-cli/pkg/config"
 	"github.com/ckandag/gcp-hcp-cli/pkg/ops"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 
 	"github.com/spf13/cobra"
 )
 
-var (
-	project      string
-	region       string
-	outputFormat string
-	configPath   string
-)
+// globals holds the persistent flags shared with the standalone gcphcp-ops
+// binary.
+var globals ops.GlobalFlags
+
+// opsCmd is the ops subtree; see usesRegion.
+var opsCmd = ops.NewOpsCmd()
 
 var rootCmd = &cobra.Command{
 	Use:   "gcphcp",
@@ -25,7 +25,7 @@ var rootCmd = &cobra.Command{
 It provides commands for cluster lifecycle, infrastructure management,
 and operational debugging of hosted control plane clusters on GCP.
 
-Configuration priority: CLI flags > environment variables > config file (~/.gcphcp/config.yaml).`,
+Configuration priority: CLI flags > environment variables > config file ($XDG_CONFIG_HOME/gcphcp/config.yaml).`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -34,43 +34,47 @@ Configuration priority: CLI flags > environment variables > config file (~/.gcph
 }
 
 func loadConfig(cmd *cobra.Command) error {
-	cfg, err := config.Load(configPath)
-	if err != nil {
-		return err
-	}
+	return globals.SetupPersistent(cmd, usesRegion(cmd))
+}
 
-	if project == "" && cfg.Project != "" {
-		project = cfg.Project
-	}
-	if region == "" && cfg.Region != "" {
-		region = cfg.Region
+// usesRegion reports whether cmd is in the ops subtree, the built-in
+// commands that call regional APIs. Only those reject a malformed region, so
+// a bad GCPHCP_REGION does not break commands such as plugin list.
+func usesRegion(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == opsCmd {
+			return true
+		}
 	}
-	if !cmd.Flags().Changed("output") && cfg.Output != "" {
-		outputFormat = cfg.Output
-	}
-
-	return nil
+	return false
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&project, "project", os.Getenv("GCPHCP_PROJECT"), "GCP project ID (env: GCPHCP_PROJECT)")
-	rootCmd.PersistentFlags().StringVar(&region, "region", os.Getenv("GCPHCP_REGION"), "GCP region (env: GCPHCP_REGION)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, yaml")
-	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file path (default: ~/.gcphcp/config.yaml)")
+	globals.AddFlags(rootCmd)
 
 	// Register the ops subtree. Self-contained so it can be extracted as a plugin.
-	rootCmd.AddCommand(ops.NewOpsCmd())
+	rootCmd.AddCommand(opsCmd)
+	rootCmd.AddCommand(newPluginCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newCacheCmd())
 }
 
-// Execute runs the root command.
+// Execute runs the root command, or a gcphcp-<name> plugin from $PATH when
+// the first argument is not a built-in command.
 func Execute() error {
+	if handled, err := runPlugin(rootCmd, os.Args[1:]); handled {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return err
+	}
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		progress.PrintError(os.Stderr, globals.LogFormat, err)
 		return err
 	}
 	return nil
 }
 
-func getProject() string      { return project }
-func getRegion() string       { return region }
-func getOutputFormat() string { return outputFormat }
+func getProject() string      { return globals.Project }
+func getRegion() string       { return globals.Region }
+func getOutputFormat() string { return globals.Output }
@@ -1,30 +1,110 @@
 // Package config provides configuration file support for the gcphcp CLI.
-// Configuration is loaded from ~/.gcphcp/config.yaml and can be overridden
-// by environment variables and CLI flags.
+// Configuration is loaded from $XDG_CONFIG_HOME/gcphcp/config.yaml (or the
+// legacy ~/.gcphcp/config.yaml) and can be overridden by environment
+// variables and CLI flags.
 package config
 
 import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+const configFileName = "config.yaml"
+
 // Config holds the CLI configuration loaded from config file.
 type Config struct {
 	Project string `yaml:"project"`
 	Region  string `yaml:"region"`
 	Output  string `yaml:"output"`
+	// WorkflowPrefix is prepended to the workflow names used by the ops
+	// convenience commands, for deployments that rename them.
+	WorkflowPrefix string `yaml:"workflow-prefix"`
+
+	// CurrentContext names the context applied when --context is not given.
+	CurrentContext string `yaml:"current-context"`
+	// Contexts holds named project/region/output sets, e.g. one per environment.
+	Contexts map[string]Context `yaml:"contexts"`
+}
+
+// Context is a named set of settings that overrides the top-level values.
+type Context struct {
+	Project        string `yaml:"project"`
+	Region         string `yaml:"region"`
+	Output         string `yaml:"output"`
+	WorkflowPrefix string `yaml:"workflow-prefix"`
+}
+
+// ForContext returns the effective settings for the named context, falling
+// back to CurrentContext when name is empty. Fields left empty in the context
+// inherit the top-level values. With no context selected, the top-level
+// settings are returned unchanged.
+func (c *Config) ForContext(name string) (*Config, error) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		return c, nil
+	}
+
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		names := make([]string, 0, len(c.Contexts))
+		for n := range c.Contexts {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("context %q not found: no contexts defined in config", name)
+		}
+		return nil, fmt.Errorf("context %q not found; available contexts: %s", name, strings.Join(names, ", "))
+	}
+
+	resolved := *c
+	resolved.CurrentContext = name
+	if ctx.Project != "" {
+		resolved.Project = ctx.Project
+	}
+	if ctx.Region != "" {
+		resolved.Region = ctx.Region
+	}
+	if ctx.Output != "" {
+		resolved.Output = ctx.Output
+	}
+	if ctx.WorkflowPrefix != "" {
+		resolved.WorkflowPrefix = ctx.WorkflowPrefix
+	}
+	return &resolved, nil
 }
 
-// DefaultConfigDir returns the default config directory path.
+// DefaultConfigDir returns the default config directory path:
+// $XDG_CONFIG_HOME/gcphcp, or ~/.config/gcphcp when XDG_CONFIG_HOME is unset.
+// If no config file exists there but the legacy ~/.gcphcp/config.yaml does,
+// the legacy directory is returned so existing setups keep working.
 func DefaultConfigDir() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ""
 	}
-	return filepath.Join(home, ".gcphcp")
+
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		base = filepath.Join(home, ".config")
+	}
+	dir := filepath.Join(base, "gcphcp")
+
+	if fileExists(filepath.Join(dir, configFileName)) {
+		return dir
+	}
+	legacy := filepath.Join(home, ".gcphcp")
+	if fileExists(filepath.Join(legacy, configFileName)) {
+		return legacy
+	}
+	return dir
 }
 
 // DefaultConfigPath returns the default config file path.
@@ -33,16 +113,47 @@ func DefaultConfigPath() string {
 	if dir == "" {
 		return ""
 	}
-	return filepath.Join(dir, "config.yaml")
+	return filepath.Join(dir, configFileName)
 }
 
-// Load reads configuration from the given path. If the file does not exist,
-// it returns an empty Config without error. Returns an error only if the file
-// exists but cannot be parsed.
-func Load(path string) (*Config, error) {
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// EnvConfigPath names the environment variable that overrides the default
+// config file path.
+const EnvConfigPath = "GCPHCP_CONFIG"
+
+// ResolvePath returns the config file Load reads for path: path itself when
+// set, otherwise $GCPHCP_CONFIG, otherwise DefaultConfigPath.
+func ResolvePath(path string) string {
+	if path == "" {
+		path = os.Getenv(EnvConfigPath)
+	}
 	if path == "" {
 		path = DefaultConfigPath()
 	}
+	return path
+}
+
+// ResolveDir returns the directory of the config file Load reads for path
+// (see ResolvePath), or "" when there is none. Local state that belongs with
+// the config, such as the result cache, lives there.
+func ResolveDir(path string) string {
+	path = ResolvePath(path)
+	if path == "" {
+		return ""
+	}
+	return filepath.Dir(path)
+}
+
+// Load reads configuration from the given path. An empty path falls back to
+// $GCPHCP_CONFIG and then DefaultConfigPath. If the file does not exist,
+// it returns an empty Config without error. Returns an error only if the file
+// exists but cannot be parsed.
+func Load(path string) (*Config, error) {
+	path = ResolvePath(path)
 	if path == "" {
 		return &Config{}, nil
 	}
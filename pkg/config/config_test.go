@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -119,12 +120,71 @@ func TestLoad_ExtraFieldsIgnored(t *testing.T) {
 }
 
 func TestDefaultConfigDir(t *testing.T) {
-	dir := DefaultConfigDir()
-	if dir == "" {
-		t.Skip("could not determine home directory")
+	writeConfig := func(t *testing.T, dir string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("project: p\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		xdg    bool
+		legacy bool
+		xdgCfg bool
+		want   func(home, xdg string) string
+	}{
+		{
+			name: "XDG_CONFIG_HOME is set",
+			xdg:  true,
+			want: func(_, xdg string) string { return filepath.Join(xdg, "gcphcp") },
+		},
+		{
+			name: "XDG_CONFIG_HOME is unset",
+			want: func(home, _ string) string { return filepath.Join(home, ".config", "gcphcp") },
+		},
+		{
+			name:   "only the legacy file exists",
+			xdg:    true,
+			legacy: true,
+			want:   func(home, _ string) string { return filepath.Join(home, ".gcphcp") },
+		},
+		{
+			name:   "both files exist",
+			xdg:    true,
+			legacy: true,
+			xdgCfg: true,
+			want:   func(_, xdg string) string { return filepath.Join(xdg, "gcphcp") },
+		},
 	}
-	if filepath.Base(dir) != ".gcphcp" {
-		t.Errorf("expected dir to end with '.gcphcp', got %q", dir)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			xdg := filepath.Join(t.TempDir(), "xdg")
+			t.Setenv("HOME", home)
+			if tt.xdg {
+				t.Setenv("XDG_CONFIG_HOME", xdg)
+			} else {
+				t.Setenv("XDG_CONFIG_HOME", "")
+			}
+			if tt.legacy {
+				writeConfig(t, filepath.Join(home, ".gcphcp"))
+			}
+			if tt.xdgCfg {
+				writeConfig(t, filepath.Join(xdg, "gcphcp"))
+			}
+
+			if got, want := DefaultConfigDir(), tt.want(home, xdg); got != want {
+				t.Errorf("DefaultConfigDir() = %q, want %q", got, want)
+			}
+			if got, want := DefaultConfigPath(), filepath.Join(tt.want(home, xdg), "config.yaml"); got != want {
+				t.Errorf("DefaultConfigPath() = %q, want %q", got, want)
+			}
+		})
 	}
 }
 
@@ -137,3 +197,155 @@ func TestDefaultConfigPath(t *testing.T) {
 		t.Errorf("expected path to end with 'config.yaml', got %q", path)
 	}
 }
+
+func TestResolveDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GCPHCP_CONFIG", filepath.Join(dir, "env", "config.yaml"))
+
+	if got, want := ResolveDir(filepath.Join(dir, "flag", "config.yaml")), filepath.Join(dir, "flag"); got != want {
+		t.Errorf("ResolveDir(path) = %q, want %q", got, want)
+	}
+	if got, want := ResolveDir(""), filepath.Join(dir, "env"); got != want {
+		t.Errorf("ResolveDir(\"\") = %q, want %q", got, want)
+	}
+}
+
+func TestLoad_EnvConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "env-config.yaml")
+	if err := os.WriteFile(path, []byte("project: env-project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GCPHCP_CONFIG", path)
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Project != "env-project" {
+		t.Errorf("expected project 'env-project' from GCPHCP_CONFIG, got %q", cfg.Project)
+	}
+}
+
+func TestLoad_ExplicitPathOverridesEnv(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "env.yaml")
+	flagPath := filepath.Join(dir, "flag.yaml")
+	if err := os.WriteFile(envPath, []byte("project: env-project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(flagPath, []byte("project: flag-project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GCPHCP_CONFIG", envPath)
+
+	cfg, err := Load(flagPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Project != "flag-project" {
+		t.Errorf("expected project 'flag-project', got %q", cfg.Project)
+	}
+}
+
+func TestLoad_ForContext(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := `project: default-project
+region: us-central1
+current-context: staging
+contexts:
+  staging:
+    project: staging-project
+  prod:
+    project: prod-project
+    region: us-east1
+    output: json
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		context     string
+		wantProject string
+		wantRegion  string
+		wantOutput  string
+		wantErr     string
+	}{
+		{
+			name:        "no context is given",
+			wantProject: "staging-project",
+			wantRegion:  "us-central1",
+		},
+		{
+			name:        "context is given",
+			context:     "prod",
+			wantProject: "prod-project",
+			wantRegion:  "us-east1",
+			wantOutput:  "json",
+		},
+		{
+			name:    "context is unknown",
+			context: "dev",
+			wantErr: "available contexts: prod, staging",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ForContext(tt.context)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Project != tt.wantProject || got.Region != tt.wantRegion || got.Output != tt.wantOutput {
+				t.Errorf("got project=%q region=%q output=%q, want %q %q %q",
+					got.Project, got.Region, got.Output, tt.wantProject, tt.wantRegion, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestConfig_ForContext_NoContexts(t *testing.T) {
+	cfg := &Config{Project: "p"}
+
+	got, err := cfg.ForContext("")
+	if err != nil || got.Project != "p" {
+		t.Fatalf("expected top-level config, got %+v, %v", got, err)
+	}
+	if _, err := cfg.ForContext("prod"); err == nil {
+		t.Fatal("expected error for unknown context")
+	}
+}
+
+func TestConfig_ForContext_WorkflowPrefix(t *testing.T) {
+	cfg := &Config{
+		WorkflowPrefix: "gcphcp-",
+		Contexts: map[string]Context{
+			"staging": {Project: "s"},
+			"v2":      {WorkflowPrefix: "gcphcp-v2-"},
+		},
+	}
+
+	for name, want := range map[string]string{"staging": "gcphcp-", "v2": "gcphcp-v2-"} {
+		got, err := cfg.ForContext(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.WorkflowPrefix != want {
+			t.Errorf("context %s: WorkflowPrefix = %q, want %q", name, got.WorkflowPrefix, want)
+		}
+	}
+}
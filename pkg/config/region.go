@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// awsRegionRe matches AWS-style region names such as us-east-1 or
+	// ap-southeast-1, where GCP has no dash before the number.
+	awsRegionRe = regexp.MustCompile(`^([a-z]+)-([a-z]+)-([0-9]+)$`)
+	// zoneRe matches a GCP zone such as us-central1-a.
+	zoneRe = regexp.MustCompile(`^([a-z]+-[a-z]+[0-9]+)-[a-z]$`)
+)
+
+// awsGeoPrefixes maps AWS region prefixes to their GCP equivalents.
+var awsGeoPrefixes = map[string]string{
+	"eu": "europe",
+	"ap": "asia",
+	"sa": "southamerica",
+}
+
+// NormalizeRegion lowercases and trims a region name and checks that it looks
+// like a GCP region such as us-central1. For common mistakes (an AWS-style
+// name like us-east-1, a zone like us-east1-b, underscores) the error
+// suggests the region that was probably meant.
+func NormalizeRegion(s string) (string, error) {
+	region := strings.ToLower(strings.TrimSpace(s))
+	if region == "" {
+		return "", fmt.Errorf("region is empty (e.g. us-central1)")
+	}
+	if regionRe.MatchString(region) {
+		return region, nil
+	}
+	if suggestion := suggestRegion(region); suggestion != "" {
+		return "", fmt.Errorf("%q does not look like a GCP region; did you mean %s?", s, suggestion)
+	}
+	return "", fmt.Errorf("%q does not look like a GCP region (e.g. us-central1)", s)
+}
+
+// suggestRegion returns the GCP region a malformed name most likely refers
+// to, or "" if there is no confident guess.
+func suggestRegion(region string) string {
+	region = strings.ReplaceAll(region, "_", "-")
+	if regionRe.MatchString(region) {
+		return region
+	}
+	if m := zoneRe.FindStringSubmatch(region); m != nil {
+		return m[1]
+	}
+	if m := awsRegionRe.FindStringSubmatch(region); m != nil {
+		geo := m[1]
+		if gcp, ok := awsGeoPrefixes[geo]; ok {
+			geo = gcp
+		}
+		return geo + "-" + m[2] + m[3]
+	}
+	return ""
+}
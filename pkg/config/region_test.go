@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "region is valid",
+			input: "us-central1",
+			want:  "us-central1",
+		},
+		{
+			name:  "region has a long geography",
+			input: "northamerica-northeast2",
+			want:  "northamerica-northeast2",
+		},
+		{
+			name:  "region has uppercase or spaces",
+			input: " Europe-West4 ",
+			want:  "europe-west4",
+		},
+		{
+			name:    "region is AWS-style",
+			input:   "us-east-1",
+			wantErr: "did you mean us-east1?",
+		},
+		{
+			name:    "AWS geography differs",
+			input:   "eu-west-1",
+			wantErr: "did you mean europe-west1?",
+		},
+		{
+			name:    "zone is given",
+			input:   "us-central1-a",
+			wantErr: "did you mean us-central1?",
+		},
+		{
+			name:    "underscores are used",
+			input:   "asia_east1",
+			wantErr: "did you mean asia-east1?",
+		},
+		{
+			name:    "region is unrecognizable",
+			input:   "mars",
+			wantErr: `"mars" does not look like a GCP region (e.g. us-central1)`,
+		},
+		{
+			name:    "region is empty",
+			input:   "",
+			wantErr: "region is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeRegion(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRegion(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormats are the values accepted by the output setting.
+var outputFormats = []string{"text", "json", "jsonl", "yaml", "name"}
+
+// outputFormatPrefixes introduce output formats that carry an argument.
+var outputFormatPrefixes = []string{"go-template=", "jsonpath=", "custom-columns=", "columns="}
+
+// regionRe matches GCP region names such as us-central1 or
+// northamerica-northeast2.
+var regionRe = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+
+// knownKeys are the top-level keys Config reads.
+var knownKeys = map[string]bool{
+	"project":         true,
+	"region":          true,
+	"output":          true,
+	"workflow-prefix": true,
+	"current-context": true,
+	"contexts":        true,
+}
+
+// Validate reports settings that would be silently ignored or rejected later:
+// an output format the CLI does not support, a region that does not look like
+// a GCP region, and a current-context that names no defined context. Values
+// in each context are checked the same way as the top-level ones.
+func (c *Config) Validate() []error {
+	errs := validateValues("", c.Region, c.Output)
+
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ctx := c.Contexts[name]
+		errs = append(errs, validateValues(fmt.Sprintf("contexts.%s.", name), ctx.Region, ctx.Output)...)
+	}
+
+	if c.CurrentContext != "" {
+		if _, ok := c.Contexts[c.CurrentContext]; !ok {
+			errs = append(errs, fmt.Errorf("current-context: context %q is not defined", c.CurrentContext))
+		}
+	}
+	return errs
+}
+
+// validateValues checks a region/output pair; keyPrefix locates them in the
+// file for error messages.
+func validateValues(keyPrefix, region, output string) []error {
+	var errs []error
+	if output != "" && !validOutput(output) {
+		errs = append(errs, fmt.Errorf("%soutput: unsupported format %q (supported: %s)",
+			keyPrefix, output, strings.Join(outputFormats, ", ")))
+	}
+	if region != "" {
+		if _, err := NormalizeRegion(region); err != nil {
+			errs = append(errs, fmt.Errorf("%sregion: %w", keyPrefix, err))
+		}
+	}
+	return errs
+}
+
+func validOutput(output string) bool {
+	for _, f := range outputFormats {
+		if output == f {
+			return true
+		}
+	}
+	for _, p := range outputFormatPrefixes {
+		if strings.HasPrefix(output, p) && len(output) > len(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnknownKeys returns the top-level keys in the config file at path that
+// Config does not read, sorted. Such keys are ignored by Load, so they are
+// usually typos.
+func UnknownKeys(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	var unknown []string
+	for key := range raw {
+		if !knownKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string
+	}{
+		{
+			name: "every value is valid",
+			content: `project: my-project
+region: us-central1
+output: json
+current-context: prod
+contexts:
+  prod:
+    region: northamerica-northeast2
+    output: go-template={{.metadata.name}}
+`,
+		},
+		{
+			name:    "output is not a supported format",
+			content: "output: jsonn\n",
+			wantErr: []string{`output: unsupported format "jsonn"`},
+		},
+		{
+			name:    "region does not look like a GCP region",
+			content: "region: us_central\n",
+			wantErr: []string{`region: "us_central" does not look like a GCP region`},
+		},
+		{
+			name: "context has bad values",
+			content: `current-context: missing
+contexts:
+  prod:
+    region: mars
+`,
+			wantErr: []string{`contexts.prod.region: "mars"`, `current-context: context "missing" is not defined`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			errs := cfg.Validate()
+			if len(errs) != len(tt.wantErr) {
+				t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(tt.wantErr))
+			}
+			for i, want := range tt.wantErr {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	path := writeConfig(t, "project: p\nregoin: us-east1\noutputs: json\ncontexts: {}\n")
+
+	got, err := UnknownKeys(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"outputs", "regoin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -7,12 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-
-	"golang.org/x/oauth2/google"
 )
 
-const callbacksAPIBase = "https://workflowexecutions.googleapis.com/v1"
-
 // CallbackInfo holds metadata about a pending callback.
 type CallbackInfo struct {
 	Name   string `json:"name"`
@@ -28,14 +24,20 @@ type callbacksResponse struct {
 }
 
 // ListCallbacks returns pending callbacks for an execution using the REST API.
-// executionName must be the full resource name with project number.
+// The callbacks endpoint requires the project number, so an executionName
+// built from the project ID is rewritten before the request (see ProjectNumber).
 func (c *Client) ListCallbacks(ctx context.Context, executionName string) ([]CallbackInfo, error) {
-	httpClient, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	url, err := c.callbacksURL(ctx, executionName)
+	if err != nil {
+		return nil, fmt.Errorf("resolving callbacks URL: %w", err)
+	}
+
+	httpClient, err := c.httpClient(ctx)
 	if err != nil {
 		return nil, wrapAuthError("creating HTTP client for callbacks", err)
 	}
 
-	url := fmt.Sprintf("%s/%s/callbacks", callbacksAPIBase, executionName)
+	c.Logger.Logf(1, "GET %s", url)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating callbacks request: %w", err)
@@ -51,6 +53,7 @@ func (c *Client) ListCallbacks(ctx context.Context, executionName string) ([]Cal
 	if err != nil {
 		return nil, fmt.Errorf("reading callbacks response: %w", err)
 	}
+	c.Logger.Logf(2, "callbacks response: HTTP %d: %s", resp.StatusCode, body)
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("listing callbacks: HTTP %d: %s", resp.StatusCode, string(body))
@@ -66,16 +69,26 @@ func (c *Client) ListCallbacks(ctx context.Context, executionName string) ([]Cal
 		result = append(result, CallbackInfo{
 			Name:   cb.Name,
 			Method: cb.Method,
-			URL:    fmt.Sprintf("%s/%s", callbacksAPIBase, cb.Name),
+			URL:    fmt.Sprintf("%s/%s", c.callbacksAPI(), cb.Name),
 		})
 	}
 
 	return result, nil
 }
 
+// callbacksURL returns the REST URL listing callbacks for executionName,
+// with the project ID replaced by its number.
+func (c *Client) callbacksURL(ctx context.Context, executionName string) (string, error) {
+	name, err := c.withProjectNumber(ctx, executionName)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/callbacks", c.callbacksAPI(), name), nil
+}
+
 // TriggerCallback sends an HTTP request to a callback URL to resume a paused workflow.
 func (c *Client) TriggerCallback(ctx context.Context, callbackURL, method string, data map[string]interface{}) error {
-	httpClient, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	httpClient, err := c.httpClient(ctx)
 	if err != nil {
 		return wrapAuthError("creating HTTP client for callback trigger", err)
 	}
@@ -93,6 +106,7 @@ func (c *Client) TriggerCallback(ctx context.Context, callbackURL, method string
 		method = http.MethodPost
 	}
 
+	c.Logger.Logf(1, "%s %s", method, callbackURL)
 	req, err := http.NewRequestWithContext(ctx, method, callbackURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating callback request: %w", err)
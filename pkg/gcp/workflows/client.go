@@ -4,10 +4,12 @@ package workflows
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 	executionspb "cloud.google.com/go/workflows/executions/apiv1/executionspb"
 	wfapi "cloud.google.com/go/workflows/apiv1"
 	workflowspb "cloud.google.com/go/workflows/apiv1/workflowspb"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
 )
@@ -30,6 +33,10 @@ func wrapAuthError(action string, err error) error {
 	case strings.Contains(msg, "token expired") || strings.Contains(msg, "oauth2: token expired"):
 		return fmt.Errorf("%s: GCP credentials have expired\n\n"+
 			"  Run: gcloud auth application-default login", action)
+	case strings.Contains(msg, "iam.serviceAccounts.getAccessToken") || strings.Contains(msg, "impersonate:"):
+		return fmt.Errorf("%s: service account impersonation denied\n\n"+
+			"  Ensure your account has roles/iam.serviceAccountTokenCreator on the\n"+
+			"  --impersonate-service-account service account, and that the account exists", action)
 	case strings.Contains(msg, "PermissionDenied") || strings.Contains(msg, "permission denied") || strings.Contains(msg, "403"):
 		return fmt.Errorf("%s: permission denied\n\n"+
 			"  Ensure your account has the required roles:\n"+
@@ -49,34 +56,131 @@ func wrapAuthError(action string, err error) error {
 	}
 }
 
+// CheckCredentials verifies that Application Default Credentials are present
+// and can mint an access token. If ctx carries a service account (see
+// ContextWithImpersonation), it also checks that the account can be impersonated.
+// Errors carry the same remediation hints as the client's own auth failures.
+func CheckCredentials(ctx context.Context) error {
+	creds, err := google.FindDefaultCredentials(ctx, cloudPlatformScope)
+	if err != nil {
+		return wrapAuthError("finding credentials", err)
+	}
+	if _, err := creds.TokenSource.Token(); err != nil {
+		return wrapAuthError("refreshing credentials", err)
+	}
+	if email := ImpersonationFromContext(ctx); email != "" {
+		ts, err := ImpersonatedTokenSource(ctx, email)
+		if err != nil {
+			return err
+		}
+		if _, err := ts.Token(); err != nil {
+			return wrapAuthError("impersonating "+email, err)
+		}
+	}
+	return nil
+}
+
 // Client wraps the Google Cloud Workflows API.
 type Client struct {
 	Project string
 	Region  string
 
+	// Logger receives request/response debug output. It defaults to the
+	// Logger carried by the context passed to NewClient, if any.
+	Logger *Logger
+
 	execClient     *executions.Client
 	workflowClient *wfapi.Client
+
+	// tokenSource authenticates all API calls instead of Application Default
+	// Credentials when set (see WithTokenSource and ContextWithImpersonation).
+	tokenSource oauth2.TokenSource
+	// quotaProject bills API calls to a project other than the credentials'
+	// default quota project (see WithQuotaProject).
+	quotaProject string
+	// apiEndpoint overrides the Workflows and Executions API endpoint (see
+	// WithAPIEndpoint).
+	apiEndpoint string
+	// callbacksBase overrides callbacksAPIBase (see WithCallbacksBase).
+	callbacksBase string
+
+	// resolveProjectNumber looks up a project number from its ID. It defaults
+	// to the Resource Manager API and is replaced in tests.
+	resolveProjectNumber func(ctx context.Context, projectID string) (string, error)
+	projectNumberMu      sync.Mutex
+	projectNumber        string
+	// projectNumberFlag is a caller-supplied project number (--project-number)
+	// that takes precedence over any resolved value.
+	projectNumberFlag string
+	// warn receives user-facing warnings; it defaults to stderr.
+	warn io.Writer
+	// pollExecution fetches an execution while waiting for it to finish. It
+	// defaults to the Executions API and is replaced in tests.
+	pollExecution func(ctx context.Context, executionName string) (*executionspb.Execution, error)
+}
+
+// newExecutionsClient and newWorkflowsClient build the underlying API
+// clients. They are replaced in tests to inspect the options NewClient
+// passes.
+var (
+	newExecutionsClient = executions.NewClient
+	newWorkflowsClient  = wfapi.NewClient
+)
+
+// ClientOption configures optional Client behavior in NewClient.
+type ClientOption func(*Client)
+
+// WithProjectNumber supplies the numeric project number so callback URLs can
+// be built without a Resource Manager lookup. An empty number is ignored.
+func WithProjectNumber(number string) ClientOption {
+	return func(c *Client) {
+		c.projectNumberFlag = number
+	}
 }
 
 // NewClient creates a new Workflows client using Application Default Credentials.
-func NewClient(ctx context.Context, project, region string) (*Client, error) {
-	execClient, err := executions.NewClient(ctx)
+// If ctx carries a Logger (see ContextWithLogger), the client uses it for debug output.
+// If ctx carries a service account (see ContextWithImpersonation) and no
+// WithTokenSource option is given, the client acts as that account. A quota
+// project carried by ctx (see ContextWithQuotaProject) applies unless
+// WithQuotaProject overrides it; likewise for an API endpoint
+// (ContextWithAPIEndpoint, WithAPIEndpoint) and a callbacks base
+// (ContextWithCallbacksBase, WithCallbacksBase).
+func NewClient(ctx context.Context, project, region string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		Project:       project,
+		Region:        region,
+		Logger:        LoggerFromContext(ctx),
+		quotaProject:  QuotaProjectFromContext(ctx),
+		apiEndpoint:   APIEndpointFromContext(ctx),
+		callbacksBase: strings.TrimSuffix(CallbacksBaseFromContext(ctx), "/"),
+		warn:          os.Stderr,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tokenSource == nil {
+		ts, err := contextTokenSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.tokenSource = ts
+	}
+
+	execClient, err := newExecutionsClient(ctx, c.workflowsAPIOptions()...)
 	if err != nil {
 		return nil, wrapAuthError("creating workflows client", err)
 	}
 
-	wfClient, err := wfapi.NewClient(ctx)
+	wfClient, err := newWorkflowsClient(ctx, c.workflowsAPIOptions()...)
 	if err != nil {
 		execClient.Close()
 		return nil, wrapAuthError("creating workflows client", err)
 	}
 
-	return &Client{
-		Project:        project,
-		Region:         region,
-		execClient:     execClient,
-		workflowClient: wfClient,
-	}, nil
+	c.execClient = execClient
+	c.workflowClient = wfClient
+	return c, nil
 }
 
 // Close releases resources held by the client.
@@ -104,6 +208,16 @@ type ExecutionResult struct {
 	StartTime time.Time              `json:"start_time"`
 	EndTime   time.Time              `json:"end_time,omitempty"`
 	Callbacks []CallbackInfo         `json:"callbacks,omitempty"`
+	// PollCount and PollDuration record how many status polls
+	// WaitForCompletion made and how long it waited, to tell a slow
+	// workflow from slow polling. Both are zero for results not obtained
+	// by waiting. The wf commands report PollDuration in -o json as a
+	// duration string.
+	PollCount    int           `json:"poll_count,omitempty"`
+	PollDuration time.Duration `json:"-"`
+	// RawResult is the result string exactly as the API returned it, before
+	// JSON parsing.
+	RawResult string `json:"-"`
 }
 
 // WorkflowInfo holds metadata about a workflow.
@@ -218,22 +332,55 @@ func ParseParams(source string) []WorkflowParam {
 
 // Execute starts a workflow and returns the execution name.
 func (c *Client) Execute(ctx context.Context, workflowName string, args map[string]interface{}) (string, error) {
-	argJSON, err := json.Marshal(args)
+	return c.ExecuteWithLabels(ctx, workflowName, args, nil)
+}
+
+// ExecuteWithLabels starts a workflow with labels attached to the execution,
+// e.g. a request ID for correlating the run with Cloud Logging, and returns
+// the execution name. A missing workflow yields a *WorkflowNotFoundError.
+func (c *Client) ExecuteWithLabels(ctx context.Context, workflowName string, args map[string]interface{}, labels map[string]string) (string, error) {
+	req, err := c.executionRequest(workflowName, args, labels)
+	if err != nil {
+		return "", err
+	}
+
+	exec, err := c.execClient.CreateExecution(ctx, req)
+	if err != nil {
+		return "", executeError(workflowName, c.Project, c.Region, err)
+	}
+
+	c.Logger.Logf(1, "created execution %s", exec.Name)
+	notifyExecution(ctx, exec.Name)
+	return exec.Name, nil
+}
+
+// executionRequest builds the CreateExecution request for a workflow run.
+func (c *Client) executionRequest(workflowName string, args map[string]interface{}, labels map[string]string) (*executionspb.CreateExecutionRequest, error) {
+	argJSON, err := c.marshalArgs(workflowName, args)
 	if err != nil {
-		return "", fmt.Errorf("marshaling arguments: %w", err)
+		return nil, err
+	}
+	if len(labels) > 0 {
+		c.Logger.Logf(1, "execution labels: %v", labels)
 	}
 
-	exec, err := c.execClient.CreateExecution(ctx, &executionspb.CreateExecutionRequest{
+	return &executionspb.CreateExecutionRequest{
 		Parent: c.workflowName(workflowName),
 		Execution: &executionspb.Execution{
 			Argument: string(argJSON),
+			Labels:   labels,
 		},
-	})
+	}, nil
+}
+
+// marshalArgs encodes workflow arguments and logs them at verbosity level 1.
+func (c *Client) marshalArgs(workflowName string, args map[string]interface{}) ([]byte, error) {
+	argJSON, err := json.Marshal(args)
 	if err != nil {
-		return "", wrapAuthError("executing workflow '"+workflowName+"'", err)
+		return nil, fmt.Errorf("marshaling arguments: %w", err)
 	}
-
-	return exec.Name, nil
+	c.Logger.Logf(1, "executing workflow %s with args: %s", c.workflowName(workflowName), argJSON)
+	return argJSON, nil
 }
 
 // Run executes a workflow and waits for it to complete.
@@ -256,80 +403,102 @@ func (c *Client) GetExecution(ctx context.Context, executionName string) (*Execu
 		return nil, wrapAuthError("getting execution status", err)
 	}
 
-	result := &ExecutionResult{
-		Name:      exec.Name,
-		State:     exec.State.String(),
-		StartTime: exec.StartTime.AsTime(),
-	}
+	return c.executionResult(exec), nil
+}
 
-	if exec.EndTime != nil {
-		result.EndTime = exec.EndTime.AsTime()
-		result.Duration = result.EndTime.Sub(result.StartTime)
+// CancelExecution cancels a running execution and returns its final status.
+func (c *Client) CancelExecution(ctx context.Context, executionName string) (*ExecutionResult, error) {
+	exec, err := c.execClient.CancelExecution(ctx, &executionspb.CancelExecutionRequest{
+		Name: executionName,
+	})
+	if err != nil {
+		return nil, wrapAuthError("cancelling execution", err)
 	}
 
-	switch result.State {
-	case "SUCCEEDED":
-		var parsed map[string]interface{}
-		if err := json.Unmarshal([]byte(exec.Result), &parsed); err != nil {
-			result.Result = map[string]interface{}{"raw": exec.Result}
-		} else {
-			result.Result = parsed
-		}
-	case "FAILED":
-		if exec.Error != nil {
-			result.Error = exec.Error.Context
+	return c.executionResult(exec), nil
+}
+
+// WaitTimeoutError is returned by WaitForCompletion when the context deadline
+// passes before the execution finishes. The execution itself keeps running.
+type WaitTimeoutError struct {
+	// ExecutionName is the full name of the execution being waited on.
+	ExecutionName string
+	// State is the last state observed before the deadline.
+	State string
+}
+
+func (e *WaitTimeoutError) Error() string {
+	_, execID := ParseExecutionName(e.ExecutionName)
+	return fmt.Sprintf("timed out waiting for execution %s (last state: %s)", execID, e.State)
+}
+
+// Unwrap lets errors.Is(err, context.DeadlineExceeded) match.
+func (e *WaitTimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// ParseExecutionName extracts the workflow and execution ID from a full
+// execution name of the form
+// projects/P/locations/L/workflows/W/executions/ID. A bare ID is returned
+// with an empty workflow.
+func ParseExecutionName(execName string) (workflow, execID string) {
+	parts := strings.Split(execName, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "workflows":
+			workflow = parts[i+1]
+		case "executions":
+			execID = parts[i+1]
 		}
 	}
-
-	return result, nil
+	if execID == "" {
+		execID = execName
+	}
+	return workflow, execID
 }
 
-// WaitForCompletion polls until the execution finishes.
+// WaitForCompletion polls until the execution finishes. If ctx's deadline
+// passes first, it returns an *WaitTimeoutError carrying the last known state.
+// A poll observer in ctx (see ContextWithPollObserver) is told about every poll.
 func (c *Client) WaitForCompletion(ctx context.Context, executionName string) (*ExecutionResult, error) {
+	poll := c.pollExecution
+	if poll == nil {
+		poll = func(ctx context.Context, name string) (*executionspb.Execution, error) {
+			return c.execClient.GetExecution(ctx, &executionspb.GetExecutionRequest{Name: name})
+		}
+	}
+
 	pollInterval := 500 * time.Millisecond
 	maxPoll := 2 * time.Second
+	lastState := "UNKNOWN"
+	start := time.Now()
+	polls := 0
 
 	for {
-		exec, err := c.execClient.GetExecution(ctx, &executionspb.GetExecutionRequest{
-			Name: executionName,
-		})
+		exec, err := poll(ctx, executionName)
+		polls++
 		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &WaitTimeoutError{ExecutionName: executionName, State: lastState}
+			}
 			return nil, wrapAuthError("checking execution status", err)
 		}
 
 		state := exec.State.String()
+		notifyPoll(ctx, state, time.Since(start))
 
 		if state != "ACTIVE" && state != "QUEUED" {
-			result := &ExecutionResult{
-				Name:      exec.Name,
-				State:     state,
-				StartTime: exec.StartTime.AsTime(),
-			}
-
-			if exec.EndTime != nil {
-				result.EndTime = exec.EndTime.AsTime()
-				result.Duration = result.EndTime.Sub(result.StartTime)
-			}
-
-			switch state {
-			case "SUCCEEDED":
-				var parsed map[string]interface{}
-				if err := json.Unmarshal([]byte(exec.Result), &parsed); err != nil {
-					result.Result = map[string]interface{}{"raw": exec.Result}
-				} else {
-					result.Result = parsed
-				}
-			case "FAILED":
-				if exec.Error != nil {
-					result.Error = exec.Error.Context
-				}
-			}
-
+			result := c.executionResult(exec)
+			result.PollCount = polls
+			result.PollDuration = time.Since(start)
+			c.Logger.Logf(1, "waited %s for %s over %d polls", result.PollDuration.Round(time.Millisecond), exec.Name, polls)
 			return result, nil
 		}
+		lastState = state
 
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, &WaitTimeoutError{ExecutionName: executionName, State: lastState}
+			}
 			return nil, ctx.Err()
 		case <-time.After(pollInterval):
 		}
@@ -343,6 +512,42 @@ func (c *Client) WaitForCompletion(ctx context.Context, executionName string) (*
 	}
 }
 
+// executionResult converts an API execution into an ExecutionResult, parsing
+// the JSON result of a successful execution.
+func (c *Client) executionResult(exec *executionspb.Execution) *ExecutionResult {
+	result := &ExecutionResult{
+		Name:      exec.Name,
+		State:     exec.State.String(),
+		StartTime: exec.StartTime.AsTime(),
+	}
+
+	if exec.EndTime != nil {
+		result.EndTime = exec.EndTime.AsTime()
+		result.Duration = result.EndTime.Sub(result.StartTime)
+	}
+
+	c.Logger.Logf(1, "execution %s is %s", exec.Name, result.State)
+
+	switch result.State {
+	case "SUCCEEDED":
+		c.Logger.Logf(2, "raw result: %s", exec.Result)
+		result.RawResult = exec.Result
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(exec.Result), &parsed); err != nil {
+			result.Result = map[string]interface{}{"raw": exec.Result}
+		} else {
+			result.Result = parsed
+		}
+	case "FAILED":
+		if exec.Error != nil {
+			c.Logger.Logf(2, "raw error: payload=%s context=%s", exec.Error.Payload, exec.Error.Context)
+			result.Error = exec.Error.Context
+		}
+	}
+
+	return result
+}
+
 // ListExecutions returns recent executions for a specific workflow.
 func (c *Client) ListExecutions(ctx context.Context, workflow string, limit int) ([]ExecutionInfo, error) {
 	var result []ExecutionInfo
@@ -387,6 +592,54 @@ func (c *Client) ListExecutions(ctx context.Context, workflow string, limit int)
 	return result, nil
 }
 
+// resolveExecutionLimit bounds how many recent executions ResolveExecution
+// scans when matching a partial execution ID.
+const resolveExecutionLimit = 100
+
+// fullExecutionIDLength is the length of a complete execution ID (a UUID).
+const fullExecutionIDLength = 36
+
+// ResolveExecution resolves a full or partial execution ID to a complete one by
+// matching it as a prefix against the workflow's recent executions. Complete IDs
+// are returned as-is without an API call.
+func (c *Client) ResolveExecution(ctx context.Context, workflow, idPrefix string) (string, error) {
+	if idPrefix == "" {
+		return "", fmt.Errorf("execution ID is required")
+	}
+	if len(idPrefix) >= fullExecutionIDLength {
+		return idPrefix, nil
+	}
+
+	execs, err := c.ListExecutions(ctx, workflow, resolveExecutionLimit)
+	if err != nil {
+		return "", err
+	}
+	return matchExecutionID(execs, workflow, idPrefix)
+}
+
+// matchExecutionID returns the single execution whose ID starts with idPrefix.
+// An exact match always wins; otherwise zero or multiple matches are an error.
+func matchExecutionID(execs []ExecutionInfo, workflow, idPrefix string) (string, error) {
+	var matches []string
+	for _, e := range execs {
+		if e.ID == idPrefix {
+			return e.ID, nil
+		}
+		if strings.HasPrefix(e.ID, idPrefix) {
+			matches = append(matches, e.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no execution of '%s' matches %q (searched the last %d executions)", workflow, idPrefix, len(execs))
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("execution ID %q is ambiguous; candidates:\n  %s", idPrefix, strings.Join(matches, "\n  "))
+	}
+}
+
 // List returns all workflows in the project/region, including PAM-gated status
 // detected via GCP Resource Tags.
 func (c *Client) List(ctx context.Context) ([]WorkflowInfo, error) {
@@ -443,7 +696,7 @@ func (c *Client) fetchPamGatedFlags(ctx context.Context, fullNames []string) []b
 		return flags
 	}
 
-	httpClient, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	httpClient, err := c.httpClient(ctx)
 	if err != nil {
 		return flags // best-effort
 	}
@@ -470,11 +723,18 @@ type tagBindingsResponse struct {
 
 // CheckPamGatedTag checks if a workflow has the pam-gated=true resource tag.
 // It uses the workflow short name and constructs the full resource path internally.
+// If ctx carries a service account (see ContextWithImpersonation), it acts as that
+// account, and a quota project (see ContextWithQuotaProject) is billed.
 func CheckPamGatedTag(ctx context.Context, project, region, workflowName string) bool {
-	httpClient, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	ts, err := contextTokenSource(ctx)
+	if err != nil {
+		return false
+	}
+	httpClient, err := authHTTPClient(ctx, ts)
 	if err != nil {
 		return false
 	}
+	httpClient = withQuotaProject(httpClient, QuotaProjectFromContext(ctx))
 	fullName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s", project, region, workflowName)
 	return checkPamGatedTag(ctx, httpClient, region, fullName)
 }
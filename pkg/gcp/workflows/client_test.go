@@ -0,0 +1,221 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/workflows/executions/apiv1/executionspb"
+)
+
+func TestMatchExecutionID(t *testing.T) {
+	execs := []ExecutionInfo{
+		{ID: "3f2a1b9c-0000-4000-8000-000000000001"},
+		{ID: "3f2a7d10-0000-4000-8000-000000000002"},
+		{ID: "a91c55e2-0000-4000-8000-000000000003"},
+	}
+
+	t.Run("prefix is unique", func(t *testing.T) {
+		got, err := matchExecutionID(execs, "get", "a91c")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "a91c55e2-0000-4000-8000-000000000003" {
+			t.Errorf("got %q", got)
+		}
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		_, err := matchExecutionID(execs, "get", "ffff")
+		if err == nil {
+			t.Fatal("expected error for no match")
+		}
+		if !strings.Contains(err.Error(), "no execution") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("prefix is ambiguous", func(t *testing.T) {
+		_, err := matchExecutionID(execs, "get", "3f2a")
+		if err == nil {
+			t.Fatal("expected error for ambiguous match")
+		}
+		msg := err.Error()
+		if !strings.Contains(msg, "ambiguous") {
+			t.Errorf("expected ambiguity error, got: %v", err)
+		}
+		for _, id := range []string{execs[0].ID, execs[1].ID} {
+			if !strings.Contains(msg, id) {
+				t.Errorf("expected candidate %q in error, got: %v", id, err)
+			}
+		}
+	})
+}
+
+func TestExecutionRequest(t *testing.T) {
+	c := &Client{Project: "my-proj", Region: "us-central1"}
+
+	req, err := c.executionRequest("restart", map[string]interface{}{"name": "etcd"}, map[string]string{
+		"request-id": "inc-1234",
+		"team":       "sre",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "projects/my-proj/locations/us-central1/workflows/restart"; req.Parent != want {
+		t.Errorf("Parent = %q, want %q", req.Parent, want)
+	}
+	if req.Execution.Argument != `{"name":"etcd"}` {
+		t.Errorf("Argument = %q", req.Execution.Argument)
+	}
+	if req.Execution.Labels["request-id"] != "inc-1234" || req.Execution.Labels["team"] != "sre" {
+		t.Errorf("Labels = %v", req.Execution.Labels)
+	}
+}
+
+func TestExecutionRequest_NoLabels(t *testing.T) {
+	c := &Client{Project: "p", Region: "r"}
+
+	req, err := c.executionRequest("get", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.Execution.Labels) != 0 {
+		t.Errorf("expected no labels, got %v", req.Execution.Labels)
+	}
+}
+
+func TestExecutionResult_NonJSON(t *testing.T) {
+	c := &Client{}
+	result := c.executionResult(&executionspb.Execution{
+		Name:   "executions/abc",
+		State:  executionspb.Execution_SUCCEEDED,
+		Result: "plain text result",
+	})
+
+	if result.Result["raw"] != "plain text result" {
+		t.Errorf("Result = %v, want raw wrapper", result.Result)
+	}
+	if result.RawResult != "plain text result" {
+		t.Errorf("RawResult = %q", result.RawResult)
+	}
+}
+
+func TestWaitForCompletion_DeadlineReturnsWaitTimeoutError(t *testing.T) {
+	const execName = "projects/p/locations/r/workflows/get/executions/abc-123"
+	c := &Client{
+		pollExecution: func(context.Context, string) (*executionspb.Execution, error) {
+			return &executionspb.Execution{Name: execName, State: executionspb.Execution_ACTIVE}, nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.WaitForCompletion(ctx, execName)
+
+	var timeout *WaitTimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("expected *WaitTimeoutError, got %T: %v", err, err)
+	}
+	if timeout.ExecutionName != execName || timeout.State != "ACTIVE" {
+		t.Errorf("got %+v, want execution %s in state ACTIVE", timeout, execName)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected the error to match context.DeadlineExceeded")
+	}
+	if !strings.Contains(err.Error(), "abc-123") {
+		t.Errorf("expected execution ID in message, got %q", err)
+	}
+}
+
+func TestWaitForCompletion_NotifiesPollObserver(t *testing.T) {
+	states := []executionspb.Execution_State{executionspb.Execution_ACTIVE, executionspb.Execution_SUCCEEDED}
+	polls := 0
+	c := &Client{
+		pollExecution: func(context.Context, string) (*executionspb.Execution, error) {
+			state := states[polls]
+			polls++
+			return &executionspb.Execution{State: state, Result: "{}"}, nil
+		},
+	}
+
+	var seen []string
+	var elapsed []time.Duration
+	ctx := ContextWithPollObserver(context.Background(), func(state string, d time.Duration) {
+		seen = append(seen, state)
+		elapsed = append(elapsed, d)
+	})
+	if _, err := c.WaitForCompletion(ctx, "executions/abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"ACTIVE", "SUCCEEDED"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("observed states %v, want %v", seen, want)
+	}
+	for i := 1; i < len(elapsed); i++ {
+		if elapsed[i] < elapsed[i-1] {
+			t.Errorf("elapsed went backwards: %v", elapsed)
+		}
+	}
+}
+
+func TestWaitForCompletion_RecordsPollMetrics(t *testing.T) {
+	states := []executionspb.Execution_State{executionspb.Execution_QUEUED, executionspb.Execution_ACTIVE, executionspb.Execution_SUCCEEDED}
+	polls := 0
+	c := &Client{
+		pollExecution: func(context.Context, string) (*executionspb.Execution, error) {
+			state := states[polls]
+			polls++
+			return &executionspb.Execution{State: state, Result: "{}"}, nil
+		},
+	}
+
+	result, err := c.WaitForCompletion(context.Background(), "executions/abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.PollCount != 3 {
+		t.Errorf("PollCount = %d, want 3", result.PollCount)
+	}
+	// Two sleeps of at least 500ms and 1s separate the three polls.
+	if result.PollDuration < 1500*time.Millisecond {
+		t.Errorf("PollDuration = %s, want at least 1.5s", result.PollDuration)
+	}
+}
+
+func TestWaitForCompletion_CancelIsNotTimeout(t *testing.T) {
+	c := &Client{
+		pollExecution: func(context.Context, string) (*executionspb.Execution, error) {
+			return &executionspb.Execution{State: executionspb.Execution_ACTIVE}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.WaitForCompletion(ctx, "executions/abc")
+
+	var timeout *WaitTimeoutError
+	if errors.As(err, &timeout) {
+		t.Fatalf("a cancelled context should not report a wait timeout")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParseExecutionName(t *testing.T) {
+	wf, id := ParseExecutionName("projects/p/locations/r/workflows/get/executions/abc")
+	if wf != "get" || id != "abc" {
+		t.Errorf("got %q, %q", wf, id)
+	}
+	wf, id = ParseExecutionName("abc")
+	if wf != "" || id != "abc" {
+		t.Errorf("bare ID: got %q, %q", wf, id)
+	}
+}
@@ -0,0 +1,90 @@
+package workflows
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/option"
+)
+
+// callbacksAPIBase is the default REST base for execution callbacks.
+const callbacksAPIBase = "https://workflowexecutions.googleapis.com/v1"
+
+type apiEndpointKey struct{}
+
+// ContextWithAPIEndpoint returns a context whose clients (see NewClient) send
+// Workflows and Executions API calls to endpoint (host:port) instead of the
+// Google endpoints, e.g. a fake server in integration tests or a VPC-SC
+// proxy. An empty endpoint leaves ctx unchanged.
+func ContextWithAPIEndpoint(ctx context.Context, endpoint string) context.Context {
+	if endpoint == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, apiEndpointKey{}, endpoint)
+}
+
+// APIEndpointFromContext returns the endpoint set by ContextWithAPIEndpoint, or "".
+func APIEndpointFromContext(ctx context.Context) string {
+	endpoint, _ := ctx.Value(apiEndpointKey{}).(string)
+	return endpoint
+}
+
+type callbacksBaseKey struct{}
+
+// ContextWithCallbacksBase returns a context whose clients (see NewClient) list
+// callbacks and build callback URLs under base, e.g.
+// "http://127.0.0.1:8080/v1", instead of the Executions REST API. Callback
+// calls use REST, so --api-endpoint (a gRPC host:port) does not cover them.
+// An empty base leaves ctx unchanged.
+func ContextWithCallbacksBase(ctx context.Context, base string) context.Context {
+	if base == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, callbacksBaseKey{}, base)
+}
+
+// CallbacksBaseFromContext returns the base set by ContextWithCallbacksBase, or "".
+func CallbacksBaseFromContext(ctx context.Context) string {
+	base, _ := ctx.Value(callbacksBaseKey{}).(string)
+	return base
+}
+
+// WithAPIEndpoint sends the client's Workflows and Executions API calls to
+// endpoint. Other APIs (Cloud Logging, Resource Manager) are unaffected. An
+// empty endpoint is ignored.
+func WithAPIEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		if endpoint != "" {
+			c.apiEndpoint = endpoint
+		}
+	}
+}
+
+// WithCallbacksBase replaces the REST base URL used to list callbacks and to
+// build callback URLs, e.g. "http://127.0.0.1:8080/v1". An empty base is
+// ignored.
+func WithCallbacksBase(base string) ClientOption {
+	return func(c *Client) {
+		if base != "" {
+			c.callbacksBase = strings.TrimSuffix(base, "/")
+		}
+	}
+}
+
+// workflowsAPIOptions returns the options for the Workflows and Executions
+// API clients: apiOptions plus the endpoint override, if any.
+func (c *Client) workflowsAPIOptions() []option.ClientOption {
+	opts := c.apiOptions()
+	if c.apiEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(c.apiEndpoint))
+	}
+	return opts
+}
+
+// callbacksAPI returns the REST base for callback calls.
+func (c *Client) callbacksAPI() string {
+	if c.callbacksBase != "" {
+		return c.callbacksBase
+	}
+	return callbacksAPIBase
+}
@@ -0,0 +1,97 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+
+	wfapi "cloud.google.com/go/workflows/apiv1"
+	executions "cloud.google.com/go/workflows/executions/apiv1"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+func TestNewClient_APIEndpoint(t *testing.T) {
+	var execOpts, wfOpts []option.ClientOption
+	origExec, origWF := newExecutionsClient, newWorkflowsClient
+	t.Cleanup(func() { newExecutionsClient, newWorkflowsClient = origExec, origWF })
+	newExecutionsClient = func(ctx context.Context, opts ...option.ClientOption) (*executions.Client, error) {
+		execOpts = opts
+		return executions.NewClient(ctx, opts...)
+	}
+	newWorkflowsClient = func(ctx context.Context, opts ...option.ClientOption) (*wfapi.Client, error) {
+		wfOpts = opts
+		return wfapi.NewClient(ctx, opts...)
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	want := option.WithEndpoint("127.0.0.1:8443")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		opts     []ClientOption
+		expected bool
+	}{
+		{"no endpoint is set", context.Background(), []ClientOption{WithTokenSource(ts)}, false},
+		{"option is given", context.Background(), []ClientOption{WithTokenSource(ts), WithAPIEndpoint("127.0.0.1:8443")}, true},
+		{"context carries it", ContextWithAPIEndpoint(context.Background(), "127.0.0.1:8443"), []ClientOption{WithTokenSource(ts)}, true},
+		{"option and context differ", ContextWithAPIEndpoint(context.Background(), "proxy:443"), []ClientOption{WithTokenSource(ts), WithAPIEndpoint("127.0.0.1:8443")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.ctx, "my-proj", "us-central1", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer c.Close()
+
+			for name, opts := range map[string][]option.ClientOption{"executions": execOpts, "workflows": wfOpts} {
+				if got := containsOption(opts, want); got != tt.expected {
+					t.Errorf("%s client options %v: endpoint present = %v, want %v", name, opts, got, tt.expected)
+				}
+			}
+			if tt.expected && containsOption(c.apiOptions(), want) {
+				t.Error("expected the endpoint to stay off the options shared with other APIs")
+			}
+		})
+	}
+}
+
+func TestCallbacksBase(t *testing.T) {
+	c := &Client{Project: "123456789", Region: "us-central1"}
+	if got := c.callbacksAPI(); got != callbacksAPIBase {
+		t.Errorf("default callbacks base = %q, want %q", got, callbacksAPIBase)
+	}
+
+	WithCallbacksBase("http://127.0.0.1:8080/v1/")(c)
+	url, err := c.callbacksURL(context.Background(), "projects/123456789/locations/us-central1/workflows/w/executions/e")
+	if err != nil {
+		t.Fatalf("callbacksURL: %v", err)
+	}
+	if want := "http://127.0.0.1:8080/v1/projects/123456789/locations/us-central1/workflows/w/executions/e/callbacks"; url != want {
+		t.Errorf("url = %q, want %q", url, want)
+	}
+}
+
+func TestNewClient_ContextWithCallbacksBase(t *testing.T) {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	ctx := ContextWithCallbacksBase(context.Background(), "http://127.0.0.1:8080/v1/")
+
+	c, err := NewClient(ctx, "my-proj", "us-central1", WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+	if got, want := c.callbacksAPI(), "http://127.0.0.1:8080/v1"; got != want {
+		t.Errorf("callbacks base = %q, want %q", got, want)
+	}
+
+	c, err = NewClient(ctx, "my-proj", "us-central1", WithTokenSource(ts), WithCallbacksBase("http://proxy/v1"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+	if got, want := c.callbacksAPI(), "http://proxy/v1"; got != want {
+		t.Errorf("option should win over the context: callbacks base = %q, want %q", got, want)
+	}
+}
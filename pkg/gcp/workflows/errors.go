@@ -0,0 +1,143 @@
+package workflows
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrWorkflowNotFound matches, via errors.Is, the error Execute returns when
+// the workflow is not deployed in the client's project and region.
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// WorkflowNotFoundError is returned by Execute when the workflow does not
+// exist. It matches ErrWorkflowNotFound.
+type WorkflowNotFoundError struct {
+	Workflow string
+	Project  string
+	Region   string
+	// Err is the underlying API error.
+	Err error
+}
+
+func (e *WorkflowNotFoundError) Error() string {
+	return fmt.Sprintf("workflow %q not found in %s/%s\n\n"+
+		"  Verify the workflow exists: gcphcp ops wf list --project %s --region %s", e.Workflow, e.Project, e.Region, e.Project, e.Region)
+}
+
+// Is lets errors.Is(err, ErrWorkflowNotFound) match.
+func (e *WorkflowNotFoundError) Is(target error) bool { return target == ErrWorkflowNotFound }
+
+// Unwrap returns the underlying API error.
+func (e *WorkflowNotFoundError) Unwrap() error { return e.Err }
+
+// ExecutionFailedError describes an execution that finished FAILED, parsed
+// from its error context with ParseWorkflowError. It marshals as the
+// {"message", "raw", "step"} detail commands print in JSON mode.
+type ExecutionFailedError struct {
+	Message string `json:"message"`
+	Raw     string `json:"raw"`
+	Step    string `json:"step,omitempty"`
+}
+
+// NewExecutionFailedError parses raw, the error context of a FAILED
+// execution.
+func NewExecutionFailedError(raw string) *ExecutionFailedError {
+	step, msg, _ := ParseWorkflowError(raw)
+	return &ExecutionFailedError{Message: msg, Raw: raw, Step: step}
+}
+
+func (e *ExecutionFailedError) Error() string {
+	if e.Step != "" {
+		return fmt.Sprintf("workflow failed in step %q: %s", e.Step, e.Message)
+	}
+	return fmt.Sprintf("workflow failed: %s", e.Message)
+}
+
+// isNotFound reports whether an API error is a NotFound (gRPC) or 404
+// (REST) status.
+func isNotFound(err error) bool {
+	if status.Code(err) == codes.NotFound {
+		return true
+	}
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// executeError classifies a CreateExecution failure: NotFound means the
+// workflow itself is missing; anything else gets the usual auth hints.
+func executeError(workflowName, project, region string, err error) error {
+	if isNotFound(err) {
+		return &WorkflowNotFoundError{Workflow: workflowName, Project: project, Region: region, Err: err}
+	}
+	return wrapAuthError("executing workflow '"+workflowName+"'", err)
+}
+
+// stepTrailerRe matches the trailer Cloud Workflows appends to an error
+// context, e.g. `in step "validate_inputs", routine "main", line: 23`.
+var stepTrailerRe = regexp.MustCompile(`(?m)^\s*in step "([^"]+)"(?:, routine "[^"]*")?(?:, line: \d+)?\s*$`)
+
+// errorTagRe matches the leading error tag of a context, e.g. "RuntimeError: ".
+var errorTagRe = regexp.MustCompile(`^[A-Za-z]*Error: `)
+
+// ParseWorkflowError extracts the failing step and a human-readable message
+// from a Cloud Workflows execution error context. The context usually looks
+// like:
+//
+//	RuntimeError: {"code":403,"message":"..."}
+//	in step "check_resource_type_deny_list", routine "main", line: 48
+//
+// but may also be a bare JSON payload or a quoted string. ok is false when
+// raw does not look like a Cloud Workflows error, in which case message is
+// raw trimmed.
+func ParseWorkflowError(raw string) (step, message string, ok bool) {
+	body := strings.TrimSpace(raw)
+
+	if m := stepTrailerRe.FindStringSubmatchIndex(body); m != nil {
+		step = body[m[2]:m[3]]
+		body = strings.TrimSpace(body[:m[0]])
+		ok = true
+	}
+
+	if loc := errorTagRe.FindStringIndex(body); loc != nil {
+		body = strings.TrimSpace(body[loc[1]:])
+		ok = true
+	}
+
+	if msg, parsed := errorPayloadMessage(body); parsed {
+		return step, msg, true
+	}
+
+	var unquoted string
+	if err := json.Unmarshal([]byte(body), &unquoted); err == nil {
+		return step, strings.TrimSpace(unquoted), true
+	}
+
+	return step, body, ok
+}
+
+// errorPayloadMessage returns the message from a JSON error payload such as
+// {"message": "...", "tags": ["RuntimeError"]}. HTTP errors from gke.request
+// nest the API server's message under body.message, which is preferred.
+func errorPayloadMessage(s string) (string, bool) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &payload); err != nil {
+		return "", false
+	}
+	if body, ok := payload["body"].(map[string]interface{}); ok {
+		if msg, ok := body["message"].(string); ok && msg != "" {
+			return msg, true
+		}
+	}
+	if msg, ok := payload["message"].(string); ok && msg != "" {
+		return msg, true
+	}
+	return s, true
+}
@@ -0,0 +1,119 @@
+package workflows
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseWorkflowError(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		wantStep    string
+		wantMessage string
+		wantOK      bool
+	}{
+		{
+			name: "context has a JSON payload and step trailer",
+			raw: `RuntimeError: {"code":403,"message":"Resource type secrets is blocked for security."}
+in step "check_resource_type_deny_list", routine "main", line: 48`,
+			wantStep:    "check_resource_type_deny_list",
+			wantMessage: "Resource type secrets is blocked for security.",
+			wantOK:      true,
+		},
+		{
+			name: "context is a quoted string",
+			raw: `RuntimeError: "Missing required parameter: resource_type"
+in step "validate_inputs", routine "main", line: 23`,
+			wantStep:    "validate_inputs",
+			wantMessage: "Missing required parameter: resource_type",
+			wantOK:      true,
+		},
+		{
+			name: "HTTP error nests the API message",
+			raw: `HttpError: {"body":{"kind":"Status","message":"pods \"etcd-9\" not found","reason":"NotFound"},"code":404,"message":"HTTP server responded with error code 404","tags":["HttpError"]}
+in step "get_resource", routine "main", line: 190`,
+			wantStep:    "get_resource",
+			wantMessage: `pods "etcd-9" not found`,
+			wantOK:      true,
+		},
+		{
+			name:        "error is a bare payload",
+			raw:         `{"message":"Unsupported resource type: widgets","tags":["RuntimeError"]}`,
+			wantMessage: "Unsupported resource type: widgets",
+			wantOK:      true,
+		},
+		{
+			name:        "error is free text",
+			raw:         "  something went wrong  ",
+			wantMessage: "something went wrong",
+			wantOK:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, msg, ok := ParseWorkflowError(tt.raw)
+			if step != tt.wantStep || msg != tt.wantMessage || ok != tt.wantOK {
+				t.Errorf("ParseWorkflowError() = (%q, %q, %v), want (%q, %q, %v)",
+					step, msg, ok, tt.wantStep, tt.wantMessage, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestExecuteError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+		wantMsg      string
+	}{
+		{
+			name:         "gRPC status is NotFound",
+			err:          status.Error(codes.NotFound, "Resource 'projects/p/locations/r/workflows/get' was not found"),
+			wantNotFound: true,
+			wantMsg:      `workflow "get" not found in p/r`,
+		},
+		{
+			name:         "REST status is 404",
+			err:          &googleapi.Error{Code: http.StatusNotFound, Message: "Requested entity was not found."},
+			wantNotFound: true,
+			wantMsg:      `workflow "get" not found in p/r`,
+		},
+		{
+			name:    "permission is denied",
+			err:     status.Error(codes.PermissionDenied, "Permission 'workflows.executions.create' denied"),
+			wantMsg: "roles/workflows.invoker",
+		},
+		{
+			name:    "error is unrelated",
+			err:     errors.New("connection reset"),
+			wantMsg: "executing workflow 'get': connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executeError("get", "p", "r", tt.err)
+			if got := errors.Is(err, ErrWorkflowNotFound); got != tt.wantNotFound {
+				t.Errorf("errors.Is(err, ErrWorkflowNotFound) = %v, want %v", got, tt.wantNotFound)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %q missing %q", err, tt.wantMsg)
+			}
+			var notFound *WorkflowNotFoundError
+			if tt.wantNotFound && (!errors.As(err, &notFound) || notFound.Workflow != "get" || notFound.Project != "p" || notFound.Region != "r") {
+				t.Errorf("expected a *WorkflowNotFoundError for get in p/r, got %#v", err)
+			}
+			if tt.wantNotFound && !errors.Is(err, tt.err) {
+				t.Errorf("expected the not-found error to wrap %v", tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package workflows
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+type impersonationKey struct{}
+
+// ContextWithImpersonation returns a context whose clients (see NewClient) act as
+// the service account email instead of the Application Default Credentials
+// identity. An empty email leaves ctx unchanged.
+func ContextWithImpersonation(ctx context.Context, email string) context.Context {
+	if email == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, impersonationKey{}, email)
+}
+
+// ImpersonationFromContext returns the service account set by
+// ContextWithImpersonation, or "".
+func ImpersonationFromContext(ctx context.Context) string {
+	email, _ := ctx.Value(impersonationKey{}).(string)
+	return email
+}
+
+// WithTokenSource makes the client authenticate with ts instead of
+// Application Default Credentials, for both the gRPC clients and the REST
+// calls (callbacks, project lookup, tags, logs).
+func WithTokenSource(ts oauth2.TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// ImpersonatedTokenSource returns a token source for the service account
+// email, minted with the caller's Application Default Credentials. The
+// caller needs roles/iam.serviceAccountTokenCreator on the account.
+func ImpersonatedTokenSource(ctx context.Context, email string) (oauth2.TokenSource, error) {
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: email,
+		Scopes:          []string{cloudPlatformScope},
+	})
+	if err != nil {
+		return nil, wrapAuthError("impersonating "+email, err)
+	}
+	return ts, nil
+}
+
+// contextTokenSource returns the impersonated token source requested by
+// ctx, or nil to use Application Default Credentials.
+func contextTokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	email := ImpersonationFromContext(ctx)
+	if email == "" {
+		return nil, nil
+	}
+	return ImpersonatedTokenSource(ctx, email)
+}
+
+// apiOptions returns the options for Google API clients built by c.
+func (c *Client) apiOptions() []option.ClientOption {
+	var opts []option.ClientOption
+	if c.tokenSource != nil {
+		opts = append(opts, option.WithTokenSource(c.tokenSource))
+	}
+	if c.quotaProject != "" {
+		opts = append(opts, option.WithQuotaProject(c.quotaProject))
+	}
+	return opts
+}
+
+// httpClient returns an authenticated HTTP client for c's REST calls.
+func (c *Client) httpClient(ctx context.Context) (*http.Client, error) {
+	httpClient, err := authHTTPClient(ctx, c.tokenSource)
+	if err != nil {
+		return nil, err
+	}
+	return withQuotaProject(httpClient, c.quotaProject), nil
+}
+
+// authHTTPClient returns an HTTP client authenticated with ts, or with
+// Application Default Credentials when ts is nil.
+func authHTTPClient(ctx context.Context, ts oauth2.TokenSource) (*http.Client, error) {
+	if ts != nil {
+		return oauth2.NewClient(ctx, ts), nil
+	}
+	return google.DefaultClient(ctx, cloudPlatformScope)
+}
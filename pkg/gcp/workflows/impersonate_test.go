@@ -0,0 +1,66 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewClient_WithTokenSource(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sa-token"})
+	c, err := NewClient(context.Background(), "my-proj", "us-central1", WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	if c.tokenSource != ts {
+		t.Fatalf("expected the client to keep the injected token source")
+	}
+	if len(c.apiOptions()) != 1 {
+		t.Errorf("expected one API option for the token source, got %d", len(c.apiOptions()))
+	}
+
+	httpClient, err := c.httpClient(context.Background())
+	if err != nil {
+		t.Fatalf("httpClient: %v", err)
+	}
+	resp, err := httpClient.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if gotAuth != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer sa-token")
+	}
+}
+
+func TestImpersonationFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := ImpersonationFromContext(ContextWithImpersonation(ctx, "")); got != "" {
+		t.Errorf("expected no impersonation for an empty email, got %q", got)
+	}
+	email := "ops@my-proj.iam.gserviceaccount.com"
+	if got := ImpersonationFromContext(ContextWithImpersonation(ctx, email)); got != email {
+		t.Errorf("got %q, want %q", got, email)
+	}
+}
+
+func TestWrapAuthError_ImpersonationDenied(t *testing.T) {
+	err := wrapAuthError("impersonating ops@my-proj.iam.gserviceaccount.com",
+		errors.New(`impersonate: status code 403: {"error":{"message":"Permission 'iam.serviceAccounts.getAccessToken' denied on resource"}}`))
+	if !strings.Contains(err.Error(), "roles/iam.serviceAccountTokenCreator") {
+		t.Errorf("expected a token creator hint, got %v", err)
+	}
+}
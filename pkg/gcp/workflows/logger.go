@@ -0,0 +1,49 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Logger writes leveled debug output about workflow requests and responses.
+// Level 1 logs workflow names and marshaled arguments; level 2 also logs raw
+// execution results and errors before they are parsed. Each message is one
+// write to the underlying writer, which may prefix or structure it. A nil
+// *Logger discards everything.
+type Logger struct {
+	w     io.Writer
+	level int
+}
+
+// NewLogger creates a Logger that writes messages up to level to w.
+func NewLogger(w io.Writer, level int) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Enabled reports whether messages at level would be written.
+func (l *Logger) Enabled(level int) bool {
+	return l != nil && l.w != nil && level > 0 && level <= l.level
+}
+
+// Logf writes a debug message if level is enabled.
+func (l *Logger) Logf(level int, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	fmt.Fprintf(l.w, format+"\n", args...)
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a context carrying l. Clients created from the context
+// with NewClient use it as their Logger.
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// LoggerFromContext returns the Logger stored in ctx, or nil.
+func LoggerFromContext(ctx context.Context) *Logger {
+	l, _ := ctx.Value(loggerKey{}).(*Logger)
+	return l
+}
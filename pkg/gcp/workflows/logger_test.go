@@ -0,0 +1,56 @@
+package workflows
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMarshalArgs_LogsAtVerbose(t *testing.T) {
+	var buf bytes.Buffer
+	c := &Client{Project: "my-proj", Region: "us-central1", Logger: NewLogger(&buf, 1)}
+
+	if _, err := c.marshalArgs("get", map[string]interface{}{"resource_type": "pods"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "projects/my-proj/locations/us-central1/workflows/get") {
+		t.Errorf("expected workflow name in log, got %q", out)
+	}
+	if !strings.Contains(out, `{"resource_type":"pods"}`) {
+		t.Errorf("expected marshaled args in log, got %q", out)
+	}
+}
+
+func TestMarshalArgs_SilentWithoutLogger(t *testing.T) {
+	c := &Client{Project: "p", Region: "r"}
+	if _, err := c.marshalArgs("get", map[string]interface{}{"a": 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLogger_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, 1)
+	l.Logf(1, "level one")
+	l.Logf(2, "level two")
+
+	if !strings.Contains(buf.String(), "level one") {
+		t.Errorf("expected level 1 message, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "level two") {
+		t.Errorf("level 2 message should be suppressed at -v, got %q", buf.String())
+	}
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	if LoggerFromContext(context.Background()) != nil {
+		t.Error("expected nil logger for bare context")
+	}
+	l := NewLogger(&bytes.Buffer{}, 2)
+	if got := LoggerFromContext(ContextWithLogger(context.Background(), l)); got != l {
+		t.Error("expected logger from context")
+	}
+}
@@ -0,0 +1,163 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	logging "google.golang.org/api/logging/v2"
+)
+
+// ExecutionLogEntry is one Cloud Logging entry written by a workflow
+// execution, such as a sys.log call or a step error.
+type ExecutionLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Severity  string    `json:"severity,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// String formats the entry as a single line: timestamp, severity, message.
+func (e ExecutionLogEntry) String() string {
+	severity := e.Severity
+	if severity == "" {
+		severity = "DEFAULT"
+	}
+	return fmt.Sprintf("%s  %-8s %s", e.Timestamp.UTC().Format("2006-01-02 15:04:05"), severity, e.Message)
+}
+
+// ExecutionLogOptions limits the entries returned by StreamExecutionLogs.
+type ExecutionLogOptions struct {
+	// Since only returns entries newer than this; zero means no bound.
+	Since time.Duration
+	// Limit caps the number of entries; zero means no limit.
+	Limit int
+}
+
+// errLogLimit stops paging once ExecutionLogOptions.Limit entries were seen.
+var errLogLimit = errors.New("log limit reached")
+
+// StreamExecutionLogs fetches the Cloud Logging entries of an execution,
+// oldest first, and calls fn for each one. executionName has the form
+// projects/P/locations/L/workflows/W/executions/ID.
+//
+// Without a limit entries are passed to fn as each page arrives. With one,
+// the newest Limit entries are fetched newest first and passed to fn oldest
+// first once all of them were read.
+func (c *Client) StreamExecutionLogs(ctx context.Context, executionName string, opts ExecutionLogOptions, fn func(ExecutionLogEntry) error) error {
+	workflow, execID := ParseExecutionName(executionName)
+	if workflow == "" {
+		return fmt.Errorf("invalid execution name %q", executionName)
+	}
+
+	svc, err := logging.NewService(ctx, c.apiOptions()...)
+	if err != nil {
+		return wrapLoggingError("creating logging client", err)
+	}
+
+	var since time.Time
+	if opts.Since > 0 {
+		since = time.Now().Add(-opts.Since)
+	}
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + c.Project},
+		Filter:        executionLogFilter(c.Region, workflow, execID, since),
+		OrderBy:       "timestamp asc",
+	}
+	if opts.Limit > 0 {
+		req.OrderBy = "timestamp desc"
+		req.PageSize = int64(opts.Limit)
+	}
+	c.Logger.Logf(1, "logging entries:list filter=%q", req.Filter)
+
+	var newest []ExecutionLogEntry
+	var fnErr error
+	err = svc.Entries.List(req).Pages(ctx, func(resp *logging.ListLogEntriesResponse) error {
+		for _, entry := range resp.Entries {
+			e := parseExecutionLogEntry(entry)
+			if opts.Limit == 0 {
+				if fnErr = fn(e); fnErr != nil {
+					return fnErr
+				}
+				continue
+			}
+			newest = append(newest, e)
+			if len(newest) >= opts.Limit {
+				return errLogLimit
+			}
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil && !errors.Is(err, errLogLimit) {
+		return wrapLoggingError("listing execution logs", err)
+	}
+
+	for i := len(newest) - 1; i >= 0; i-- {
+		if err := fn(newest[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executionLogFilter builds the Cloud Logging filter for one execution's
+// entries. A zero since leaves the time range unbounded.
+func executionLogFilter(region, workflow, execID string, since time.Time) string {
+	filter := fmt.Sprintf(`resource.type="workflows.googleapis.com/Workflow"
+resource.labels.workflow_id=%q
+resource.labels.location=%q
+labels."workflows.googleapis.com/execution_id"=%q`, workflow, region, execID)
+	if !since.IsZero() {
+		filter += fmt.Sprintf("\ntimestamp >= %q", since.UTC().Format(time.RFC3339))
+	}
+	return filter
+}
+
+// parseExecutionLogEntry extracts the timestamp, severity and message of a
+// log entry. Text payloads are used as-is; JSON payloads use their "message"
+// field when present and are otherwise printed as compact JSON.
+func parseExecutionLogEntry(entry *logging.LogEntry) ExecutionLogEntry {
+	e := ExecutionLogEntry{Severity: entry.Severity}
+	if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+		e.Timestamp = t
+	}
+
+	switch {
+	case entry.TextPayload != "":
+		e.Message = entry.TextPayload
+	case len(entry.JsonPayload) > 0:
+		var payload map[string]interface{}
+		if err := json.Unmarshal(entry.JsonPayload, &payload); err == nil {
+			if msg, ok := payload["message"].(string); ok {
+				e.Message = msg
+				break
+			}
+		}
+		e.Message = string(entry.JsonPayload)
+	}
+	e.Message = strings.TrimRight(e.Message, "\n")
+	return e
+}
+
+// wrapLoggingError explains the Cloud Logging failures users can fix
+// themselves (API disabled, missing role) and defers to wrapAuthError for
+// credential problems.
+func wrapLoggingError(action string, err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "SERVICE_DISABLED") || strings.Contains(msg, "accessNotConfigured") || strings.Contains(msg, "has not been used in project"):
+		return fmt.Errorf("%s: the Cloud Logging API is not enabled\n\n"+
+			"  Run: gcloud services enable logging.googleapis.com --project <project>", action)
+	case strings.Contains(msg, "PermissionDenied") || strings.Contains(msg, "permission denied") || strings.Contains(msg, "403"):
+		return fmt.Errorf("%s: permission denied\n\n"+
+			"  Ensure your account has the required role:\n"+
+			"    - roles/logging.viewer", action)
+	default:
+		return wrapAuthError(action, err)
+	}
+}
@@ -0,0 +1,82 @@
+package workflows
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logging "google.golang.org/api/logging/v2"
+)
+
+func TestParseExecutionLogEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry *logging.LogEntry
+		want  string
+	}{
+		{
+			name: "entry has a text payload",
+			entry: &logging.LogEntry{
+				Timestamp:   "2026-01-02T03:04:05.123456Z",
+				Severity:    "INFO",
+				TextPayload: "fetching pods in clusters-abc\n",
+			},
+			want: "2026-01-02 03:04:05  INFO     fetching pods in clusters-abc",
+		},
+		{
+			name: "JSON payload has a message",
+			entry: &logging.LogEntry{
+				Timestamp:   "2026-01-02T03:04:05Z",
+				Severity:    "ERROR",
+				JsonPayload: []byte(`{"message":"step get_pods failed: HTTP 403","step":"get_pods"}`),
+			},
+			want: "2026-01-02 03:04:05  ERROR    step get_pods failed: HTTP 403",
+		},
+		{
+			name: "JSON payload has no message",
+			entry: &logging.LogEntry{
+				Timestamp:   "2026-01-02T03:04:05Z",
+				JsonPayload: []byte(`{"count":3}`),
+			},
+			want: `2026-01-02 03:04:05  DEFAULT  {"count":3}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseExecutionLogEntry(tt.entry).String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionLogFilter(t *testing.T) {
+	since := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	got := executionLogFilter("us-central1", "get", "abc-123", since)
+	for _, want := range []string{
+		`resource.labels.workflow_id="get"`,
+		`resource.labels.location="us-central1"`,
+		`labels."workflows.googleapis.com/execution_id"="abc-123"`,
+		`timestamp >= "2026-01-02T03:00:00Z"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("filter %q missing %q", got, want)
+		}
+	}
+	if strings.Contains(executionLogFilter("r", "get", "id", time.Time{}), "timestamp") {
+		t.Error("expected no timestamp clause for a zero since")
+	}
+}
+
+func TestWrapLoggingError(t *testing.T) {
+	err := wrapLoggingError("listing execution logs", errors.New("googleapi: Error 403: Cloud Logging API has not been used in project 123 before or it is disabled., accessNotConfigured"))
+	if !strings.Contains(err.Error(), "gcloud services enable logging.googleapis.com") {
+		t.Errorf("expected an enable hint, got %v", err)
+	}
+	err = wrapLoggingError("listing execution logs", errors.New("googleapi: Error 403: Permission denied, forbidden"))
+	if !strings.Contains(err.Error(), "roles/logging.viewer") {
+		t.Errorf("expected a role hint, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package workflows
+
+import (
+	"context"
+	"time"
+)
+
+type executionObserverKey struct{}
+
+// ContextWithExecutionObserver returns a context carrying fn. Executions created with
+// the context call fn with the full execution name as soon as the API
+// accepts them, before waiting for completion.
+func ContextWithExecutionObserver(ctx context.Context, fn func(execName string)) context.Context {
+	return context.WithValue(ctx, executionObserverKey{}, fn)
+}
+
+// notifyExecution calls the observer stored in ctx, if any.
+func notifyExecution(ctx context.Context, execName string) {
+	if fn, _ := ctx.Value(executionObserverKey{}).(func(string)); fn != nil {
+		fn(execName)
+	}
+}
+
+type pollObserverKey struct{}
+
+// ContextWithPollObserver returns a context carrying fn. WaitForCompletion calls fn
+// after every status poll, including the final one, with the execution's
+// state and the time spent waiting so far, so commands can render progress.
+func ContextWithPollObserver(ctx context.Context, fn func(state string, elapsed time.Duration)) context.Context {
+	return context.WithValue(ctx, pollObserverKey{}, fn)
+}
+
+// notifyPoll calls the poll observer stored in ctx, if any.
+func notifyPoll(ctx context.Context, state string, elapsed time.Duration) {
+	if fn, _ := ctx.Value(pollObserverKey{}).(func(string, time.Duration)); fn != nil {
+		fn(state, elapsed)
+	}
+}
@@ -0,0 +1,126 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const resourceManagerAPIBase = "https://cloudresourcemanager.googleapis.com/v1"
+
+// ProjectNumber returns the numeric project number for the client's project.
+// A number supplied with WithProjectNumber always wins (with a warning if it
+// disagrees with a known number). Otherwise the lookup happens once per
+// client; later calls return the cached value.
+func (c *Client) ProjectNumber(ctx context.Context) (string, error) {
+	c.projectNumberMu.Lock()
+	defer c.projectNumberMu.Unlock()
+
+	if c.projectNumberFlag != "" {
+		known := c.projectNumber
+		if known == "" && isProjectNumber(c.Project) {
+			known = c.Project
+		}
+		if known != "" && known != c.projectNumberFlag && c.warn != nil {
+			fmt.Fprintf(c.warn, "Warning: --project-number %s differs from resolved project number %s; using %s\n",
+				c.projectNumberFlag, known, c.projectNumberFlag)
+		}
+		c.projectNumber = c.projectNumberFlag
+		c.projectNumberFlag = ""
+		return c.projectNumber, nil
+	}
+
+	if c.projectNumber != "" {
+		return c.projectNumber, nil
+	}
+	if isProjectNumber(c.Project) {
+		c.projectNumber = c.Project
+		return c.projectNumber, nil
+	}
+
+	resolve := c.resolveProjectNumber
+	if resolve == nil {
+		resolve = c.lookupProjectNumber
+	}
+	number, err := resolve(ctx, c.Project)
+	if err != nil {
+		return "", err
+	}
+	c.projectNumber = number
+	return number, nil
+}
+
+// lookupProjectNumber resolves a project ID to its number via the Resource
+// Manager REST API.
+func (c *Client) lookupProjectNumber(ctx context.Context, projectID string) (string, error) {
+	httpClient, err := c.httpClient(ctx)
+	if err != nil {
+		return "", wrapAuthError("creating HTTP client for project lookup", err)
+	}
+
+	url := fmt.Sprintf("%s/projects/%s", resourceManagerAPIBase, projectID)
+	c.Logger.Logf(1, "GET %s", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating project lookup request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", wrapAuthError("looking up project number", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading project lookup response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("looking up project number: HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		ProjectNumber string `json:"projectNumber"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("parsing project lookup response: %w", err)
+	}
+	if parsed.ProjectNumber == "" {
+		return "", fmt.Errorf("looking up project number: no projectNumber for %q", projectID)
+	}
+	return parsed.ProjectNumber, nil
+}
+
+// withProjectNumber rewrites a resource name of the form projects/<id>/...
+// to use the project number when <id> is the client's project ID.
+func (c *Client) withProjectNumber(ctx context.Context, name string) (string, error) {
+	rest, ok := strings.CutPrefix(name, "projects/")
+	if !ok {
+		return name, nil
+	}
+	project, tail, _ := strings.Cut(rest, "/")
+	if project != c.Project {
+		return name, nil
+	}
+
+	number, err := c.ProjectNumber(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "projects/" + number + "/" + tail, nil
+}
+
+func isProjectNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
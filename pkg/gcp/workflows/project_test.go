@@ -0,0 +1,129 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCallbacksURL_UsesProjectNumber(t *testing.T) {
+	calls := 0
+	c := &Client{
+		Project: "my-proj",
+		Region:  "us-central1",
+		resolveProjectNumber: func(_ context.Context, projectID string) (string, error) {
+			calls++
+			if projectID != "my-proj" {
+				t.Errorf("resolver got project %q, want my-proj", projectID)
+			}
+			return "123456789", nil
+		},
+	}
+
+	execName := "projects/my-proj/locations/us-central1/workflows/approval/executions/abc"
+	for i := 0; i < 2; i++ {
+		url, err := c.callbacksURL(context.Background(), execName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := callbacksAPIBase + "/projects/123456789/locations/us-central1/workflows/approval/executions/abc/callbacks"
+		if url != want {
+			t.Errorf("url = %q, want %q", url, want)
+		}
+		if strings.Contains(url, "my-proj") {
+			t.Errorf("url should not contain the project ID: %q", url)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected resolver to be called once (cached), got %d", calls)
+	}
+}
+
+func TestWithProjectNumber_PassThrough(t *testing.T) {
+	c := &Client{
+		Project: "my-proj",
+		resolveProjectNumber: func(context.Context, string) (string, error) {
+			return "", fmt.Errorf("resolver should not be called")
+		},
+	}
+
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"name already has a number", "projects/42/locations/r/workflows/w/executions/e"},
+		{"name is for another project", "projects/other/locations/r/workflows/w/executions/e"},
+		{"name is not project-scoped", "locations/r/workflows/w"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.withProjectNumber(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.in {
+				t.Errorf("got %q, want %q", got, tt.in)
+			}
+		})
+	}
+}
+
+func TestProjectNumber_ResolverError(t *testing.T) {
+	c := &Client{
+		Project: "my-proj",
+		resolveProjectNumber: func(context.Context, string) (string, error) {
+			return "", fmt.Errorf("permission denied")
+		},
+	}
+	if _, err := c.ProjectNumber(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if c.projectNumber != "" {
+		t.Errorf("failed lookup should not be cached, got %q", c.projectNumber)
+	}
+}
+
+func TestCallbacksURL_ProjectNumberFlag(t *testing.T) {
+	c := &Client{
+		Project: "my-proj",
+		resolveProjectNumber: func(context.Context, string) (string, error) {
+			return "", fmt.Errorf("resolver should not be called when --project-number is set")
+		},
+	}
+	WithProjectNumber("987654321")(c)
+
+	url, err := c.callbacksURL(context.Background(), "projects/my-proj/locations/r/workflows/w/executions/e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := callbacksAPIBase + "/projects/987654321/locations/r/workflows/w/executions/e/callbacks"; url != want {
+		t.Errorf("url = %q, want %q", url, want)
+	}
+}
+
+func TestProjectNumber_FlagDisagreesWithKnownNumber(t *testing.T) {
+	var warn strings.Builder
+	c := &Client{Project: "111", warn: &warn}
+	WithProjectNumber("222")(c)
+
+	got, err := c.ProjectNumber(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "222" {
+		t.Errorf("ProjectNumber() = %q, want the flag value 222", got)
+	}
+	if !strings.Contains(warn.String(), "differs from resolved project number 111") {
+		t.Errorf("expected a warning, got %q", warn.String())
+	}
+
+	url, err := c.callbacksURL(context.Background(), "projects/111/locations/r/workflows/w/executions/e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(url, "/projects/222/") {
+		t.Errorf("url should use the flag number, got %q", url)
+	}
+}
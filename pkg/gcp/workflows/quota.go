@@ -0,0 +1,66 @@
+package workflows
+
+import (
+	"context"
+	"net/http"
+)
+
+// quotaProjectHeader names the project that REST calls are billed and
+// quota-checked against.
+const quotaProjectHeader = "X-Goog-User-Project"
+
+type quotaProjectKey struct{}
+
+// ContextWithQuotaProject returns a context whose clients (see NewClient) bill
+// API usage to project instead of the credentials' default quota project.
+// An empty project leaves ctx unchanged.
+func ContextWithQuotaProject(ctx context.Context, project string) context.Context {
+	if project == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, quotaProjectKey{}, project)
+}
+
+// QuotaProjectFromContext returns the project set by ContextWithQuotaProject, or "".
+func QuotaProjectFromContext(ctx context.Context) string {
+	project, _ := ctx.Value(quotaProjectKey{}).(string)
+	return project
+}
+
+// WithQuotaProject bills the client's API calls, gRPC and REST alike, to
+// project. It only affects billing and quota; Client.Project still selects
+// the workflows. An empty project is ignored.
+func WithQuotaProject(project string) ClientOption {
+	return func(c *Client) {
+		if project != "" {
+			c.quotaProject = project
+		}
+	}
+}
+
+// quotaProjectTransport sets the quota project header on every request.
+type quotaProjectTransport struct {
+	project string
+	base    http.RoundTripper
+}
+
+func (t *quotaProjectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(quotaProjectHeader, t.project)
+	return t.base.RoundTrip(req)
+}
+
+// withQuotaProject returns a copy of httpClient that sends the quota project
+// header, or httpClient itself when project is empty.
+func withQuotaProject(httpClient *http.Client, project string) *http.Client {
+	if project == "" {
+		return httpClient
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	clone := *httpClient
+	clone.Transport = &quotaProjectTransport{project: project, base: base}
+	return &clone
+}
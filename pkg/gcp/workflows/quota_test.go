@@ -0,0 +1,90 @@
+package workflows
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	wfapi "cloud.google.com/go/workflows/apiv1"
+	executions "cloud.google.com/go/workflows/executions/apiv1"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+func TestNewClient_QuotaProject(t *testing.T) {
+	var execOpts, wfOpts []option.ClientOption
+	origExec, origWF := newExecutionsClient, newWorkflowsClient
+	t.Cleanup(func() { newExecutionsClient, newWorkflowsClient = origExec, origWF })
+	newExecutionsClient = func(ctx context.Context, opts ...option.ClientOption) (*executions.Client, error) {
+		execOpts = opts
+		return executions.NewClient(ctx, opts...)
+	}
+	newWorkflowsClient = func(ctx context.Context, opts ...option.ClientOption) (*wfapi.Client, error) {
+		wfOpts = opts
+		return wfapi.NewClient(ctx, opts...)
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	want := option.WithQuotaProject("billing-proj")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opts []ClientOption
+	}{
+		{"option is given", context.Background(), []ClientOption{WithTokenSource(ts), WithQuotaProject("billing-proj")}},
+		{"context carries it", ContextWithQuotaProject(context.Background(), "billing-proj"), []ClientOption{WithTokenSource(ts)}},
+		{"option and context differ", ContextWithQuotaProject(context.Background(), "other-proj"), []ClientOption{WithTokenSource(ts), WithQuotaProject("billing-proj")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.ctx, "my-proj", "us-central1", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer c.Close()
+
+			for name, opts := range map[string][]option.ClientOption{"executions": execOpts, "workflows": wfOpts} {
+				if !containsOption(opts, want) {
+					t.Errorf("%s client options %v do not include the quota project", name, opts)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClient_QuotaProjectHeader(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(quotaProjectHeader)
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		tokenSource:  oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		quotaProject: "billing-proj",
+	}
+	httpClient, err := c.httpClient(context.Background())
+	if err != nil {
+		t.Fatalf("httpClient: %v", err)
+	}
+	resp, err := httpClient.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if got != "billing-proj" {
+		t.Errorf("%s = %q, want %q", quotaProjectHeader, got, "billing-proj")
+	}
+}
+
+func containsOption(opts []option.ClientOption, want option.ClientOption) bool {
+	for _, o := range opts {
+		if reflect.DeepEqual(o, want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Package journal keeps a local record of workflow executions started by
+// this machine, so an execution can be found again after detaching from
+// `wf run` without copying its ID from the terminal.
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxEntries caps the journal; Append drops the oldest entries beyond it.
+const MaxEntries = 50
+
+const (
+	// lockTimeout bounds how long Append waits for another process to
+	// finish updating the journal.
+	lockTimeout = 5 * time.Second
+	// staleLockAge is how old a lock file must be before it is taken to be
+	// left behind by a process that died mid-update.
+	staleLockAge = 30 * time.Second
+)
+
+// Entry is one started execution.
+type Entry struct {
+	Workflow    string    `json:"workflow"`
+	ExecutionID string    `json:"execution_id"`
+	Project     string    `json:"project,omitempty"`
+	Region      string    `json:"region,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	ArgsHash    string    `json:"args_hash,omitempty"`
+}
+
+// DefaultPath returns the journal file under the given config directory.
+func DefaultPath(configDir string) string {
+	return filepath.Join(configDir, "executions.json")
+}
+
+// HashArgs returns a short, stable digest of workflow arguments, so runs
+// with the same arguments can be recognised without storing them.
+func HashArgs(args map[string]interface{}) string {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// List returns the journal entries, newest first. A missing journal is
+// empty.
+func List(path string) ([]Entry, error) {
+	entries, err := read(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// Append adds e to the journal, keeping at most MaxEntries. The update
+// holds a lock file beside the journal, so concurrent runs do not drop each
+// other's entries, and the file is written to a temporary name and renamed,
+// so readers never see a partial journal.
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating journal directory: %w", err)
+	}
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := read(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+	return write(path, entries)
+}
+
+// lock takes the journal's lock file, waiting up to lockTimeout for another
+// holder and breaking locks older than staleLockAge. The returned func
+// releases it.
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("locking execution journal: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("locking execution journal: %s is held by another process (remove it if stale)", lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// read returns the stored entries, oldest first.
+func read(path string) ([]Entry, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading execution journal: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("parsing execution journal %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func write(path string, entries []Entry) error {
+	dir := filepath.Dir(path)
+	raw, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding execution journal: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing execution journal: %w", err)
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing execution journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing execution journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing execution journal: %w", err)
+	}
+	return nil
+}
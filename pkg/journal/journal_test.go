@@ -0,0 +1,172 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendList(t *testing.T) {
+	path := DefaultPath(filepath.Join(t.TempDir(), "gcphcp"))
+
+	got, err := List(path)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("List() on a missing journal = %v, %v; want empty", got, err)
+	}
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"aaa", "bbb"} {
+		e := Entry{Workflow: "get", ExecutionID: id, Project: "p", Region: "r", StartTime: start.Add(time.Duration(i) * time.Minute), ArgsHash: "h"}
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	got, err = List(path)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(got) != 2 || got[0].ExecutionID != "bbb" || got[1].ExecutionID != "aaa" {
+		t.Fatalf("expected newest first, got %+v", got)
+	}
+	if !got[1].StartTime.Equal(start) || got[1].Project != "p" || got[1].ArgsHash != "h" {
+		t.Errorf("entry did not round-trip: %+v", got[1])
+	}
+
+	leftovers, _ := filepath.Glob(path + ".*.tmp")
+	if len(leftovers) != 0 {
+		t.Errorf("temporary files left behind: %v", leftovers)
+	}
+}
+
+func TestAppend_Truncates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "executions.json")
+	for i := 0; i < MaxEntries+5; i++ {
+		if err := Append(path, Entry{Workflow: "get", ExecutionID: fmt.Sprintf("exec-%d", i)}); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	got, err := List(path)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(got) != MaxEntries {
+		t.Fatalf("got %d entries, want %d", len(got), MaxEntries)
+	}
+	if want := fmt.Sprintf("exec-%d", MaxEntries+4); got[0].ExecutionID != want {
+		t.Errorf("newest entry = %s, want %s", got[0].ExecutionID, want)
+	}
+	if got[len(got)-1].ExecutionID != "exec-5" {
+		t.Errorf("oldest kept entry = %s, want exec-5", got[len(got)-1].ExecutionID)
+	}
+}
+
+func TestList_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "executions.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := List(path); err == nil || !strings.Contains(err.Error(), "parsing execution journal") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestHashArgs(t *testing.T) {
+	a := HashArgs(map[string]interface{}{"resource_type": "pods", "namespace": "hypershift"})
+	b := HashArgs(map[string]interface{}{"namespace": "hypershift", "resource_type": "pods"})
+	c := HashArgs(map[string]interface{}{"resource_type": "nodes"})
+	if a != b {
+		t.Errorf("expected key order not to matter: %s != %s", a, b)
+	}
+	if a == c || len(a) != 12 {
+		t.Errorf("unexpected hashes %q, %q", a, c)
+	}
+}
+
+func TestAppend_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "executions.json")
+
+	const writers, perWriter = 10, MaxEntries / 10
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, writers*perWriter)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			for j := 0; j < perWriter; j++ {
+				errs <- Append(path, Entry{Workflow: "get", ExecutionID: fmt.Sprintf("exec-%d-%d", i, j)})
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	got, err := List(path)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(got) != writers*perWriter {
+		t.Fatalf("got %d entries, want %d: concurrent appends were lost", len(got), writers*perWriter)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestAppend_StaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "executions.json")
+	if err := os.WriteFile(path+".lock", nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Append(path, Entry{Workflow: "get", ExecutionID: "abc"}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if got, _ := List(path); len(got) != 1 {
+		t.Errorf("got %+v, want one entry", got)
+	}
+}
+
+func TestAppend_WaitsForLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "executions.json")
+	if err := os.WriteFile(path+".lock", nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Append(path, Entry{Workflow: "get", ExecutionID: "abc"})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("journal written while another process held the lock: %v", err)
+	}
+
+	if err := os.Remove(path + ".lock"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if got, _ := List(path); len(got) != 1 {
+		t.Errorf("got %+v, want one entry", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package analysis keeps a local history of AI pod analyses so recurring
+// issues can be tracked across runs.
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// Record is one line of an analysis history file.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Pod       string    `json:"pod"`
+	Namespace string    `json:"namespace"`
+	Severity  string    `json:"severity,omitempty"`
+	Summary   string    `json:"summary,omitempty"`
+}
+
+// RecordFromResult builds a Record from an analyze workflow result. Severity
+// and summary come from the structured AI response; when the response is
+// unstructured, the summary is the raw text, and when the analysis failed it
+// is the analysis error.
+func RecordFromResult(result map[string]interface{}, namespace, pod string, now time.Time) Record {
+	rec := Record{Timestamp: now.UTC(), Pod: pod, Namespace: namespace}
+
+	analysis := output.AsMap(result["analysis"])
+	if errMsg := output.GetString(analysis, "error"); errMsg != "" {
+		rec.Summary = errMsg
+		return rec
+	}
+	raw := output.GetString(analysis, "ai_analysis")
+	if parsed, ok := output.StructuredAnalysis(raw); ok {
+		rec.Severity, _ = parsed["severity"].(string)
+		rec.Summary, _ = parsed["summary"].(string)
+		return rec
+	}
+	rec.Summary = raw
+	return rec
+}
+
+// AppendRecord appends rec to the JSONL file at path, creating the file and
+// its parent directories if needed. Each record is written with a single
+// O_APPEND write, so concurrent writers never interleave within a line.
+func AppendRecord(path string, rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshaling analysis record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating analysis history directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening analysis history: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("writing analysis history: %w", err)
+	}
+	return f.Close()
+}
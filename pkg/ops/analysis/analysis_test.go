@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordFromResult(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		result       map[string]interface{}
+		wantSeverity string
+		wantSummary  string
+	}{
+		{
+			name: "analysis is structured",
+			result: map[string]interface{}{"analysis": map[string]interface{}{
+				"ai_analysis": `{"severity":"high","summary":"etcd is out of disk"}`,
+			}},
+			wantSeverity: "high",
+			wantSummary:  "etcd is out of disk",
+		},
+		{
+			name: "analysis is plain text",
+			result: map[string]interface{}{"analysis": map[string]interface{}{
+				"ai_analysis": "Pod looks healthy.",
+			}},
+			wantSummary: "Pod looks healthy.",
+		},
+		{
+			name: "analysis failed",
+			result: map[string]interface{}{"analysis": map[string]interface{}{
+				"error": "model unavailable",
+			}},
+			wantSummary: "model unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := RecordFromResult(tt.result, "ns", "etcd-0", now)
+			if rec.Pod != "etcd-0" || rec.Namespace != "ns" || !rec.Timestamp.Equal(now) {
+				t.Errorf("unexpected identity fields: %+v", rec)
+			}
+			if rec.Severity != tt.wantSeverity || rec.Summary != tt.wantSummary {
+				t.Errorf("got severity=%q summary=%q, want %q %q", rec.Severity, rec.Summary, tt.wantSeverity, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAppendRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "analyses.jsonl")
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, pod := range []string{"etcd-0", "etcd-1"} {
+		if err := AppendRecord(path, Record{Timestamp: now, Pod: pod, Namespace: "ns", Summary: "ok"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var pods []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not a JSON record: %v", scanner.Text(), err)
+		}
+		pods = append(pods, rec.Pod)
+	}
+	if len(pods) != 2 || pods[0] != "etcd-0" || pods[1] != "etcd-1" {
+		t.Errorf("got pods %v, want [etcd-0 etcd-1]", pods)
+	}
+}
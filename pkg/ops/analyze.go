@@ -0,0 +1,135 @@
+package ops
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newAnalyzeCmd() *cobra.Command {
+	var (
+		namespace string
+		container string
+		tailLines int
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "analyze <pod-name>",
+		Short: "Run AI analysis on a pod via Cloud Workflows",
+		Long: `Diagnose a pod with the analyze workflow, which sends the pod's status,
+events, and recent logs to Vertex AI and returns a summary, the errors it
+found, a likely root cause, and recommended actions.
+
+Examples:
+  # Analyze a crashing pod
+  gcphcp ops analyze kube-apiserver-abc123 -n clusters-test
+
+  # Analyze more log lines from a specific container
+  gcphcp ops analyze etcd-0 -n clusters-test -c etcd --tail 500
+
+  # Raw analysis result
+  gcphcp ops analyze etcd-0 -n clusters-test -o json
+
+  # Keep a history of analyses to spot recurring issues
+  gcphcp ops analyze etcd-0 -n clusters-test --save-analysis ~/.gcphcp/analyses.jsonl`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			podName := args[0]
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			workflowName := workflowPrefix(cmd) + analyzeWorkflow
+			progress.With(cmd, "workflow", workflowName)
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+			if namespace == "" {
+				return fmt.Errorf("--namespace is required for analyze")
+			}
+
+			data := map[string]interface{}{
+				"namespace":  namespace,
+				"pod":        podName,
+				"tail_lines": tailLines,
+			}
+			if container != "" {
+				data["container"] = container
+			}
+
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				return printDryRun(cmd.OutOrStdout(), workflowName, data)
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
+				return err
+			}
+
+			progress.Printf(cmd, "Analyzing %s in %s (this may take a moment)...\n", podName, namespace)
+
+			result, err := runAnalysis(ctx, client, workflowName, data)
+			if err != nil {
+				return err
+			}
+			if output.GetString(result, "status") != "container_required" {
+				if err := saveAnalysis(cmd, result, namespace, podName); err != nil {
+					return err
+				}
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			usage := fmt.Sprintf("gcphcp ops analyze %s -n %s -c <container>", podName, namespace)
+			return printAnalyzeResult(out, progress.Stderr(cmd), output.ParseFormat(outputFormat), result, namespace, podName, usage)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Container whose logs are analyzed")
+	cmd.Flags().IntVar(&tailLines, "tail", 100, "Number of log lines to analyze")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	addSaveAnalysisFlag(cmd)
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+// printAnalyzeResult writes an analyze result: the raw result with -o json,
+// otherwise the text report. A "container_required" result is reported on
+// stderr with usage, as in logs.
+func printAnalyzeResult(w, stderr io.Writer, format output.Format, result map[string]interface{}, namespace, pod, usage string) error {
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, result)
+	}
+	if err := containerRequired(stderr, result, pod, usage); err != nil {
+		return err
+	}
+	return printAnalysis(w, result, namespace, pod)
+}
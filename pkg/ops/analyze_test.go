@@ -0,0 +1,104 @@
+package ops
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestNewAnalyzeCmd(t *testing.T) {
+	cmd := newAnalyzeCmd()
+
+	if cmd.Use != "analyze <pod-name>" {
+		t.Errorf("Use = %q", cmd.Use)
+	}
+	for name, shorthand := range map[string]string{"namespace": "n", "container": "c", "tail": "", "timeout": "", "dry-run": ""} {
+		f := cmd.Flag(name)
+		if f == nil {
+			t.Errorf("expected --%s flag", name)
+			continue
+		}
+		if f.Shorthand != shorthand {
+			t.Errorf("--%s shorthand = %q, want %q", name, f.Shorthand, shorthand)
+		}
+	}
+	if tail := cmd.Flag("tail"); tail != nil && tail.DefValue != "100" {
+		t.Errorf("--tail default = %q, want 100", tail.DefValue)
+	}
+}
+
+func TestAnalyzeCmd_DryRun(t *testing.T) {
+	cmd := newAnalyzeCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.Flags().String("project", "", "")
+	cmd.Flags().String("region", "", "")
+	cmd.Flags().String("output", "", "")
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"etcd-0", "-n", "ns", "-c", "etcd", "--tail", "500", "--project", "p", "--region", "r", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `gcphcp ops wf run analyze --data '{"container":"etcd","namespace":"ns","pod":"etcd-0","tail_lines":500}'` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPrintAnalyzeResult(t *testing.T) {
+	result := map[string]interface{}{
+		"name": "etcd-0",
+		"analysis": map[string]interface{}{
+			"pod_phase":   "Running",
+			"ai_analysis": `{"summary":"Healthy.","severity":"LOW"}`,
+		},
+	}
+
+	t.Run("output is JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printAnalyzeResult(&buf, &bytes.Buffer{}, output.FormatJSON, result, "ns", "etcd-0", "usage"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+		}
+		if !reflect.DeepEqual(got, result) {
+			t.Errorf("got %v, want %v", got, result)
+		}
+	})
+
+	t.Run("output is text", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printAnalyzeResult(&buf, &bytes.Buffer{}, output.FormatText, result, "ns", "etcd-0", "usage"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"POD ANALYSIS", "Pod:       etcd-0", "Severity:  LOW", "Healthy."} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("output missing %q:\n%s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("container is required", func(t *testing.T) {
+		var stderr bytes.Buffer
+		required := map[string]interface{}{
+			"status":               "container_required",
+			"available_containers": []interface{}{"etcd", "etcd-metrics"},
+		}
+		err := printAnalyzeResult(&bytes.Buffer{}, &stderr, output.FormatText, required, "ns", "etcd-0", "gcphcp ops analyze etcd-0 -n ns -c <container>")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if !strings.Contains(stderr.String(), "etcd-metrics") || !strings.Contains(stderr.String(), "gcphcp ops analyze etcd-0") {
+			t.Errorf("unexpected stderr:\n%s", stderr.String())
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/cache"
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/spf13/cobra"
+)
+
+// addCacheFlags registers the opt-in result cache flags on an idempotent
+// read command.
+func addCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("cache-ttl", 0, "Serve results cached within this long (e.g. 30s); 0 disables the cache")
+	cmd.Flags().Bool("no-cache", false, "Bypass the result cache even if --cache-ttl is set")
+}
+
+// resultCacheFromFlags returns the cache selected by --cache-ttl/--no-cache,
+// or nil when caching is off.
+func resultCacheFromFlags(cmd *cobra.Command) *cache.Cache {
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if ttl <= 0 || noCache {
+		return nil
+	}
+	configPath, _ := cmd.Flags().GetString("config")
+	dir := config.ResolveDir(configPath)
+	if dir == "" {
+		return nil
+	}
+	return cache.New(cache.DefaultDir(dir), ttl)
+}
+
+// runCached runs a workflow, serving a fresh cached result when c is non-nil
+// and storing successful results for later calls. Entries are keyed by
+// project, region, the API endpoint and impersonated service account carried
+// by ctx, workflow name and arguments. Cache hits are noted on
+// stderr and failures to store a result on warn.
+func runCached(ctx context.Context, runner workflowRunner, c *cache.Cache, project, region, workflowName string, data map[string]interface{}, stderr, warn io.Writer) (*workflows.ExecutionResult, error) {
+	if c == nil {
+		_, result, err := runner.Run(ctx, workflowName, data)
+		return result, err
+	}
+
+	args, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling arguments: %w", err)
+	}
+	key := cache.Key(project, region, workflows.APIEndpointFromContext(ctx), workflows.ImpersonationFromContext(ctx), workflowName, string(args))
+
+	if raw, ok := c.Get(key); ok {
+		var result map[string]interface{}
+		if err := json.Unmarshal(raw, &result); err == nil {
+			age, _ := c.Age(key)
+			fmt.Fprintf(stderr, "Using cached result (%s old)\n", age.Round(time.Second))
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: result}, nil
+		}
+	}
+
+	_, result, err := runner.Run(ctx, workflowName, data)
+	if err != nil {
+		return nil, err
+	}
+	if result.State == "SUCCEEDED" {
+		if raw, err := json.Marshal(result.Result); err == nil {
+			if err := c.Put(key, raw); err != nil {
+				fmt.Fprintf(warn, "Warning: could not cache result: %v\n", err)
+			}
+		}
+	}
+	// Keep the cache directory bounded; a failed prune only leaves files
+	// for the next run or "gcphcp cache clear".
+	_, _ = c.Prune(cache.DefaultMaxAge)
+	return result, nil
+}
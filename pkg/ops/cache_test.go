@@ -0,0 +1,123 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/cache"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/spf13/cobra"
+)
+
+func TestRunCached(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(args map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"resource_type": args["resource_type"]}}
+		},
+	}}
+	c := cache.New(filepath.Join(t.TempDir(), "cache"), time.Minute)
+	ctx := context.Background()
+	var stderr bytes.Buffer
+
+	for i := 0; i < 2; i++ {
+		result, err := runCached(ctx, runner, c, "p", "r", "get", map[string]interface{}{"resource_type": "nodes"}, &stderr, &stderr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Result["resource_type"] != "nodes" {
+			t.Errorf("unexpected result: %v", result.Result)
+		}
+	}
+	if len(runner.calls) != 1 {
+		t.Errorf("expected 1 workflow run with a warm cache, got %d", len(runner.calls))
+	}
+	if !strings.Contains(stderr.String(), "Using cached result") {
+		t.Errorf("expected cache notice on stderr, got %q", stderr.String())
+	}
+
+	if _, err := runCached(ctx, runner, c, "p", "r", "get", map[string]interface{}{"resource_type": "pods"}, &stderr, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if len(runner.calls) != 2 {
+		t.Errorf("different args should miss the cache, got %d runs", len(runner.calls))
+	}
+}
+
+func TestRunCached_DoesNotCacheFailures(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "FAILED", Error: "boom"}
+		},
+	}}
+	c := cache.New(filepath.Join(t.TempDir(), "cache"), time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := runCached(context.Background(), runner, c, "p", "r", "get", nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(runner.calls) != 2 {
+		t.Errorf("failed results must not be cached, got %d runs", len(runner.calls))
+	}
+}
+
+func TestRunCached_NilCache(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "SUCCEEDED"}
+		},
+	}}
+	for i := 0; i < 2; i++ {
+		if _, err := runCached(context.Background(), runner, nil, "p", "r", "get", nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(runner.calls) != 2 {
+		t.Errorf("nil cache should always run, got %d runs", len(runner.calls))
+	}
+}
+
+func TestRunCached_KeyIncludesEndpointAndImpersonation(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{}}
+		},
+	}}
+	c := cache.New(filepath.Join(t.TempDir(), "cache"), time.Minute)
+	ctxs := []context.Context{
+		context.Background(),
+		workflows.ContextWithAPIEndpoint(context.Background(), "127.0.0.1:8443"),
+		workflows.ContextWithImpersonation(context.Background(), "sa@p.iam.gserviceaccount.com"),
+	}
+
+	for _, ctx := range ctxs {
+		if _, err := runCached(ctx, runner, c, "p", "r", "get", nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(runner.calls) != len(ctxs) {
+		t.Errorf("each endpoint and identity should miss the cache, got %d runs", len(runner.calls))
+	}
+}
+
+func TestResultCacheFromFlags_ConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	cmd := &cobra.Command{Use: "get"}
+	addCacheFlags(cmd)
+	cmd.Flags().String("config", "", "")
+	if err := cmd.ParseFlags([]string{"--cache-ttl", "30s", "--config", filepath.Join(dir, "config.yaml")}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := resultCacheFromFlags(cmd)
+	if c == nil {
+		t.Fatal("expected a cache with --cache-ttl set")
+	}
+	if want := cache.DefaultDir(dir); c.Dir != want {
+		t.Errorf("cache dir = %q, want %q", c.Dir, want)
+	}
+}
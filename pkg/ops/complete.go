@@ -0,0 +1,54 @@
+package ops
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ResourceTypeCompletions returns every resource type name get and describe
+// accept: the canonical plural names and all of their aliases, sorted.
+func ResourceTypeCompletions() []string {
+	seen := map[string]bool{}
+	for alias, canonical := range resourceTypeExpand {
+		seen[alias] = true
+		seen[canonical] = true
+	}
+	for rt := range clusterScopedTypes {
+		seen[rt] = true
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// completeResourceType is a ValidArgsFunction for commands whose first
+// argument is a resource type. With allowList, the argument may be a
+// comma-separated list (get pods,svc,...) and the last entry is completed.
+func completeResourceType(allowList bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var listed string
+		if allowList {
+			if i := strings.LastIndex(toComplete, ","); i >= 0 {
+				listed, toComplete = toComplete[:i+1], toComplete[i+1:]
+			}
+		}
+
+		var matches []string
+		for _, name := range ResourceTypeCompletions() {
+			if strings.HasPrefix(name, toComplete) {
+				matches = append(matches, listed+name)
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,67 @@
+package ops
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResourceTypeCompletions(t *testing.T) {
+	got := ResourceTypeCompletions()
+	for _, want := range []string{"po", "pods", "deploy", "deployments", "hc", "hostedclusters", "pvc", "nodes", "storageclasses"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("completions missing %q", want)
+		}
+	}
+	if !slices.IsSorted(got) {
+		t.Errorf("expected sorted completions, got %v", got)
+	}
+	if len(slices.Compact(slices.Clone(got))) != len(got) {
+		t.Errorf("expected no duplicates, got %v", got)
+	}
+}
+
+func TestCompleteResourceType(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowList  bool
+		args       []string
+		toComplete string
+		want       []string
+	}{
+		{
+			name:       "prefix is typed",
+			toComplete: "po",
+			want:       []string{"po", "pod", "pods"},
+		},
+		{
+			name:       "list is allowed",
+			allowList:  true,
+			toComplete: "pods,dep",
+			want:       []string{"pods,deploy", "pods,deployment", "pods,deployments"},
+		},
+		{
+			name:       "list is not allowed",
+			toComplete: "pods,dep",
+		},
+		{
+			name:       "type is already given",
+			args:       []string{"pods"},
+			toComplete: "e",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, directive := completeResourceType(tt.allowList)(&cobra.Command{}, tt.args, tt.toComplete)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("directive = %v", directive)
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package ops
 
 import (
-	"context"
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +19,9 @@ func newDeleteCmd() *cobra.Command {
 	var (
 		namespace    string
 		gracePeriod  int
+		force        bool
+		yes          bool
+		reallyMeanIt bool
 		timeout      time.Duration
 	)
 
@@ -24,6 +31,10 @@ func newDeleteCmd() *cobra.Command {
 		Long: `Delete a Kubernetes resource by type and name.
 Supported resource types: pods, jobs, deployments.
 
+On a terminal, delete asks "Are you sure? (y/N)" first; --yes skips the
+question and is required when stdin is not a terminal. Deleting cluster-level
+or storage resources such as namespaces also needs --i-really-mean-it.
+
 Examples:
   # Delete a pod
   gcphcp ops delete pods my-pod -n clusters-abc123
@@ -31,8 +42,11 @@ Examples:
   # Delete a deployment
   gcphcp ops delete deployments my-deploy -n clusters-abc123
 
-  # Force delete a pod (grace period 0)
-  gcphcp ops delete pods my-pod -n clusters-abc123 --grace-period 0
+  # Force delete a stuck pod (grace period 0)
+  gcphcp ops delete pods my-pod -n clusters-abc123 --force
+
+  # Skip the confirmation prompt, e.g. in scripts
+  gcphcp ops delete pods my-pod -n clusters-abc123 --yes
 
   # Short aliases work too
   gcphcp ops delete po my-pod -n clusters-abc123`,
@@ -44,10 +58,14 @@ Examples:
 				resourceType = expanded
 			}
 			resourceName := args[1]
+			if err := checkDeleteTarget(resourceType, reallyMeanIt); err != nil {
+				return err
+			}
 
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -64,11 +82,24 @@ Examples:
 				"namespace":     namespace,
 				"name":          resourceName,
 			}
-			if cmd.Flags().Changed("grace-period") {
+			if force {
+				if cmd.Flags().Changed("grace-period") && gracePeriod != 0 {
+					return fmt.Errorf("--force deletes immediately and cannot be combined with --grace-period %d", gracePeriod)
+				}
+				data["grace_period_seconds"] = 0
+				data["force"] = true
+			} else if cmd.Flags().Changed("grace-period") {
 				data["grace_period_seconds"] = gracePeriod
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			if !yes {
+				prompt := fmt.Sprintf("Delete %s %s (ns: %s)", resourceType, resourceName, namespace)
+				if err := confirmDelete(cmd.InOrStdin(), cmd.ErrOrStderr(), progress.IsTerminal(os.Stdin), prompt); err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -77,20 +108,26 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "delete", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, "delete", cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Deleting %s %s (ns: %s)\n", resourceType, resourceName, namespace)
+			progress.Printf(cmd, "Deleting %s %s (ns: %s)\n", resourceType, resourceName, namespace)
 
 			_, result, err := client.Run(ctx, "delete", data)
 			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+				return workflowRunError(err)
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
 			}
+			defer out.Close()
 
 			format := output.ParseFormat(outputFormat)
 			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+				return output.PrintJSON(out, result.Result)
 			}
 
 			status := output.GetString(result.Result, "status")
@@ -99,7 +136,7 @@ Examples:
 				return fmt.Errorf("failed to delete %s/%s: %s", resourceType, resourceName, errMsg)
 			}
 
-			fmt.Fprintf(os.Stdout, "%s \"%s\" deleted\n", resourceType, resourceName)
+			fmt.Fprintf(out, "%s \"%s\" deleted\n", resourceType, resourceName)
 			return nil
 		},
 	}
@@ -107,7 +144,48 @@ Examples:
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
 	_ = cmd.MarkFlagRequired("namespace")
 	cmd.Flags().IntVar(&gracePeriod, "grace-period", 30, "Grace period in seconds before force kill (max 300)")
+	cmd.Flags().BoolVar(&force, "force", false, "Delete immediately (grace period 0), e.g. for a pod stuck terminating")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+	cmd.Flags().BoolVar(&reallyMeanIt, "i-really-mean-it", false, "Allow deleting dangerous resource types such as namespaces")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 
 	return cmd
 }
+
+// dangerousDeleteTypes are resource types whose deletion takes down much more
+// than one workload; deleting them needs --i-really-mean-it.
+var dangerousDeleteTypes = map[string]bool{
+	"namespaces":             true,
+	"nodes":                  true,
+	"persistentvolumes":      true,
+	"persistentvolumeclaims": true,
+	"hostedclusters":         true,
+	"hostedcontrolplanes":    true,
+	"nodepools":              true,
+}
+
+// checkDeleteTarget refuses dangerous resource types unless reallyMeanIt.
+func checkDeleteTarget(resourceType string, reallyMeanIt bool) error {
+	if dangerousDeleteTypes[resourceType] && !reallyMeanIt {
+		return fmt.Errorf("refusing to delete %s without --i-really-mean-it", resourceType)
+	}
+	return nil
+}
+
+// confirmDelete asks "<prompt>? Are you sure? (y/N)" on out and reads the
+// answer from in; only "y" or "yes" proceeds. When stdin is not a terminal
+// it fails instead of waiting for an answer that will not come.
+func confirmDelete(in io.Reader, out io.Writer, interactive bool, prompt string) error {
+	if !interactive {
+		return fmt.Errorf("stdin is not a terminal; pass --yes to delete without confirmation")
+	}
+	fmt.Fprintf(out, "%s? Are you sure? (y/N) ", prompt)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("delete cancelled")
+	}
+}
@@ -0,0 +1,73 @@
+package ops
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirmDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		wantErr     string
+	}{
+		{"answer is y", "y\n", true, ""},
+		{"answer is YES", " YES \n", true, ""},
+		{"answer is empty", "\n", true, "delete cancelled"},
+		{"answer is n", "n\n", true, "delete cancelled"},
+		{"input ends without an answer", "", true, "delete cancelled"},
+		{"stdin is not a terminal", "y\n", false, "--yes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirmDelete(strings.NewReader(tt.input), &out, tt.interactive, "Delete pods my-pod (ns: default)")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if tt.interactive && out.String() != "Delete pods my-pod (ns: default)? Are you sure? (y/N) " {
+				t.Errorf("unexpected prompt %q", out.String())
+			}
+		})
+	}
+}
+
+func TestCheckDeleteTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		reallyMeanIt bool
+		wantErr      bool
+	}{
+		{"deleting a pod", "pods", false, false},
+		{"deleting a namespace", "namespaces", false, true},
+		{"deleting a namespace with --i-really-mean-it", "namespaces", true, false},
+		{"deleting a hosted cluster", "hostedclusters", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDeleteTarget(tt.resourceType, tt.reallyMeanIt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDeleteTarget(%q, %v) = %v, wantErr %v", tt.resourceType, tt.reallyMeanIt, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteCmd_RefusesDangerousTypeBeforePrompting(t *testing.T) {
+	cmd := newDeleteCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"ns", "clusters-abc123", "-n", "default"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--i-really-mean-it") {
+		t.Errorf("expected the dangerous-target guard, got %v", err)
+	}
+}
@@ -1,21 +1,27 @@
 package ops
 
 import (
-	"context"
 	"fmt"
-	"os"
+	"io"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 func newDescribeCmd() *cobra.Command {
 	var (
-		namespace string
-		timeout   time.Duration
+		namespace       string
+		nsPrefix        string
+		timeout         time.Duration
+		showAnnotations bool
+		explain         bool
 	)
 
 	cmd := &cobra.Command{
@@ -31,13 +37,23 @@ Examples:
   # Describe a deployment
   gcphcp ops describe deployment my-deploy -n kube-system
 
+  # Find the namespace by prefix instead of typing it out
+  gcphcp ops describe pods kube-apiserver-0 --namespace-prefix clusters-test-pd
+
   # Describe a hosted cluster
   gcphcp ops describe hc my-hc -n clusters
 
+  # Include annotation values
+  gcphcp ops describe deployment my-deploy -n kube-system --show-annotations
+
   # Describe a node (cluster-scoped, no namespace needed)
-  gcphcp ops describe nodes gke-node-abc123`,
+  gcphcp ops describe nodes gke-node-abc123
+
+  # Follow up with an AI analysis of the pod
+  gcphcp ops describe pods my-pod -n hypershift --explain`,
 
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeResourceType(false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			resourceType := args[0]
 			resourceName := args[1]
@@ -48,7 +64,10 @@ Examples:
 
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
+			workflowName := workflowPrefix(cmd) + "describe"
+			progress.With(cmd, "workflow", workflowName)
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -57,6 +76,13 @@ Examples:
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
 
+			if err := validateExplain(explain, resourceType, outputFormat); err != nil {
+				return err
+			}
+			if nsPrefix != "" && namespace != "" {
+				return fmt.Errorf("--namespace-prefix and --namespace are mutually exclusive")
+			}
+
 			data := map[string]interface{}{
 				"resource_type": resourceType,
 				"name":          resourceName,
@@ -65,7 +91,16 @@ Examples:
 				data["namespace"] = namespace
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				if nsPrefix != "" {
+					if err := printNamespacePrefixDryRun(cmd.OutOrStdout(), workflowPrefix(cmd)+"get", nsPrefix); err != nil {
+						return err
+					}
+				}
+				return printDryRun(cmd.OutOrStdout(), workflowName, data)
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -74,117 +109,179 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "describe", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Describing %s %s", resourceType, resourceName)
+			if nsPrefix != "" {
+				if namespace, err = resolveNamespacePrefix(ctx, client, workflowPrefix(cmd)+"get", nsPrefix); err != nil {
+					return err
+				}
+				data["namespace"] = namespace
+			}
+
+			msg := fmt.Sprintf("Describing %s %s", resourceType, resourceName)
 			if namespace != "" {
-				fmt.Fprintf(os.Stderr, " (ns: %s)", namespace)
+				msg += fmt.Sprintf(" (ns: %s)", namespace)
+			}
+			progress.Printf(cmd, "%s\n", msg)
+
+			result, err := runCached(ctx, client, resultCacheFromFlags(cmd), project, region, workflowName, data, progress.Writer(cmd), progress.WarnWriter(cmd))
+			if err != nil {
+				return workflowRunError(err)
 			}
-			fmt.Fprintln(os.Stderr)
 
-			_, result, err := client.Run(ctx, "describe", data)
+			out, err := output.ResolveOutputWriter(outputFile)
 			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+				return err
 			}
+			defer out.Close()
 
 			if result.State == "FAILED" {
-				return fmt.Errorf("workflow failed: %s", result.Error)
+				return wf.WorkflowFailure(out, output.ParseFormat(outputFormat), result.Error)
 			}
 
 			format := output.ParseFormat(outputFormat)
 			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+				return output.PrintJSON(out, result.Result)
 			}
 
-			printDescribeText(result.Result)
+			noTruncate, _ := cmd.Flags().GetBool("no-truncate")
+			printDescribeText(out, result.Result, resourceType, describeOptions{
+				showAnnotations: showAnnotations,
+				noTruncate:      noTruncate,
+			})
+			if explain {
+				return runExplain(ctx, client, cmd, out, namespace, resourceName, "")
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&nsPrefix, "namespace-prefix", "", "Use the one namespace starting with this prefix (e.g. clusters-test-pd)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	addCacheFlags(cmd)
+	addDryRunFlag(cmd)
+	cmd.Flags().BoolVar(&showAnnotations, "show-annotations", false, "Print annotation keys and values instead of just the count")
+	addExplainFlag(cmd, &explain)
 
 	return cmd
 }
 
-func printDescribeText(data map[string]interface{}) {
-	resource, ok := data["resource"].(map[string]interface{})
-	if !ok {
-		_ = output.PrintJSON(os.Stdout, data)
-		return
-	}
+// describeOptions controls optional sections of the describe text output.
+type describeOptions struct {
+	showAnnotations bool
+	// noTruncate prints event, container and condition messages in full
+	// (--no-truncate).
+	noTruncate bool
+}
 
-	meta := output.AsMap(resource["metadata"])
-	spec := output.AsMap(resource["spec"])
-	status := output.AsMap(resource["status"])
+// Message length limits of the describe text output, lifted by --no-truncate.
+// Condition messages at or over conditionMessageMax are left out entirely.
+const (
+	eventMessageMax     = 70
+	containerMessageMax = 80
+	conditionMessageMax = 50
+)
 
-	isPod := false
-	if containers, ok := spec["containers"].([]interface{}); ok && len(containers) > 0 {
-		isPod = true
+// truncate cuts msg to limit bytes unless --no-truncate was given.
+func (o describeOptions) truncate(msg string, limit int) string {
+	if o.noTruncate || len(msg) <= limit {
+		return msg
+	}
+	return msg[:limit]
+}
+
+func printDescribeText(w io.Writer, data map[string]interface{}, resourceType string, opts describeOptions) {
+	meta, spec, status, ok := describedResource(data)
+	if !ok {
+		_ = output.PrintJSON(w, data)
+		return
 	}
 
-	fmt.Fprintf(os.Stdout, "Name:              %s\n", output.GetString(meta, "name"))
+	fmt.Fprintf(w, "Name:              %s\n", output.GetString(meta, "name"))
 	if ns := output.GetString(meta, "namespace"); ns != "" {
-		fmt.Fprintf(os.Stdout, "Namespace:         %s\n", ns)
+		fmt.Fprintf(w, "Namespace:         %s\n", ns)
 	}
 
-	if isPod {
-		printPodDescribe(meta, spec, status)
-	} else {
-		printGenericDescribe(meta, spec, status)
+	switch resourceType {
+	case "pods":
+		printPodDescribe(w, opts, meta, spec, status)
+	case "deployments":
+		printDeploymentDescribe(w, opts, meta, spec, status)
+	case "services":
+		printServiceDescribe(w, opts, meta, spec, status, data)
+	case "nodes":
+		printNodeDescribe(w, opts, meta, spec, status)
+	default:
+		printGenericDescribe(w, opts, meta, spec, status)
 	}
 
-	printConditions(data)
-	printEvents(data)
+	printConditions(w, opts, data)
+	printEvents(w, opts, data)
+}
+
+// describedResource splits a describe workflow result into the resource's
+// metadata, spec and status. ok is false when the result has no resource.
+func describedResource(data map[string]interface{}) (meta, spec, status map[string]interface{}, ok bool) {
+	resource, ok := data["resource"].(map[string]interface{})
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return output.AsMap(resource["metadata"]), output.AsMap(resource["spec"]), output.AsMap(resource["status"]), true
 }
 
-func printPodDescribe(meta, spec, status map[string]interface{}) {
+func printPodDescribe(w io.Writer, opts describeOptions, meta, spec, status map[string]interface{}) {
 	if sa := output.GetString(spec, "serviceAccountName"); sa != "" {
-		fmt.Fprintf(os.Stdout, "Service Account:   %s\n", sa)
+		fmt.Fprintf(w, "Service Account:   %s\n", sa)
 	}
 	if node := output.GetString(spec, "nodeName"); node != "" {
-		fmt.Fprintf(os.Stdout, "Node:              %s\n", node)
+		fmt.Fprintf(w, "Node:              %s\n", node)
 	}
 	if startTime := output.GetString(status, "startTime"); startTime != "" {
-		fmt.Fprintf(os.Stdout, "Start Time:        %s\n", startTime)
+		fmt.Fprintf(w, "Start Time:        %s\n", startTime)
 	}
 
-	printLabelsAndAnnotations(meta)
+	printLabelsAndAnnotations(w, meta, opts.showAnnotations)
 
-	fmt.Fprintf(os.Stdout, "Status:            %s\n", output.GetString(status, "phase"))
+	fmt.Fprintf(w, "Status:            %s\n", output.GetString(status, "phase"))
 	if podIP := output.GetString(status, "podIP"); podIP != "" {
-		fmt.Fprintf(os.Stdout, "IP:                %s\n", podIP)
+		fmt.Fprintf(w, "IP:                %s\n", podIP)
 	}
 	if hostIP := output.GetString(status, "hostIP"); hostIP != "" {
-		fmt.Fprintf(os.Stdout, "Node IP:           %s\n", hostIP)
+		fmt.Fprintf(w, "Node IP:           %s\n", hostIP)
 	}
 
+	fmt.Fprintf(w, "QoS Class:         %s\n", podQOSClass(spec))
+	requests, limits := podResourceTotals(spec)
+	fmt.Fprintf(w, "Resources (total): requests %s, limits %s\n", formatResourceTotals(requests), formatResourceTotals(limits))
+
 	if initContainers, ok := spec["initContainers"].([]interface{}); ok && len(initContainers) > 0 {
 		initStatuses, _ := status["initContainerStatuses"].([]interface{})
-		fmt.Fprintln(os.Stdout, "\nInit Containers:")
+		fmt.Fprintln(w, "\nInit Containers:")
 		for _, ic := range initContainers {
 			icSpec := output.AsMap(ic)
 			name := output.GetString(icSpec, "name")
 			icStatus := findContainerStatus(initStatuses, name)
-			printContainerDetail(icSpec, icStatus)
+			printContainerDetail(w, opts, icSpec, icStatus)
 		}
 	}
 
 	if containers, ok := spec["containers"].([]interface{}); ok && len(containers) > 0 {
 		containerStatuses, _ := status["containerStatuses"].([]interface{})
-		fmt.Fprintln(os.Stdout, "\nContainers:")
+		fmt.Fprintln(w, "\nContainers:")
 		for _, c := range containers {
 			cSpec := output.AsMap(c)
 			name := output.GetString(cSpec, "name")
 			cStatus := findContainerStatus(containerStatuses, name)
-			printContainerDetail(cSpec, cStatus)
+			printContainerDetail(w, opts, cSpec, cStatus)
 		}
 	}
 
 	if volumes, ok := spec["volumes"].([]interface{}); ok && len(volumes) > 0 {
-		fmt.Fprintln(os.Stdout, "\nVolumes:")
+		fmt.Fprintln(w, "\nVolumes:")
 		limit := len(volumes)
 		if limit > 5 {
 			limit = 5
@@ -193,74 +290,390 @@ func printPodDescribe(meta, spec, status map[string]interface{}) {
 			vm := output.AsMap(v)
 			name := output.GetString(vm, "name")
 			volType := volumeType(vm)
-			fmt.Fprintf(os.Stdout, "  %s:\n", name)
-			fmt.Fprintf(os.Stdout, "    Type:    %s\n", volType)
+			fmt.Fprintf(w, "  %s:\n", name)
+			fmt.Fprintf(w, "    Type:    %s\n", volType)
 		}
 		if len(volumes) > 5 {
-			fmt.Fprintf(os.Stdout, "  ... and %d more volumes\n", len(volumes)-5)
+			fmt.Fprintf(w, "  ... and %d more volumes\n", len(volumes)-5)
 		}
 	}
 }
 
-func printGenericDescribe(meta, spec, status map[string]interface{}) {
+// qosResources are the resources that determine a pod's QoS class.
+var qosResources = []string{"cpu", "memory"}
+
+// podContainers returns the init and regular containers of a pod spec.
+func podContainers(spec map[string]interface{}) []interface{} {
+	var all []interface{}
+	if ic, ok := spec["initContainers"].([]interface{}); ok {
+		all = append(all, ic...)
+	}
+	if c, ok := spec["containers"].([]interface{}); ok {
+		all = append(all, c...)
+	}
+	return all
+}
+
+// podResourceTotals sums CPU (cores) and memory (bytes) requests and limits
+// across the pod's regular containers.
+func podResourceTotals(spec map[string]interface{}) (requests, limits map[string]float64) {
+	requests = map[string]float64{}
+	limits = map[string]float64{}
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		res := output.AsMap(output.AsMap(c)["resources"])
+		addQuantities(requests, output.AsMap(res["requests"]))
+		addQuantities(limits, output.AsMap(res["limits"]))
+	}
+	return requests, limits
+}
+
+func addQuantities(totals map[string]float64, m map[string]interface{}) {
+	for _, name := range qosResources {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		if q, err := output.ParseQuantity(fmt.Sprintf("%v", v)); err == nil {
+			totals[name] += q
+		}
+	}
+}
+
+// formatResourceTotals renders summed CPU/memory totals, e.g. "cpu=350m memory=768Mi".
+func formatResourceTotals(totals map[string]float64) string {
+	var parts []string
+	if cpu, ok := totals["cpu"]; ok {
+		parts = append(parts, "cpu="+output.FormatCPU(cpu))
+	}
+	if mem, ok := totals["memory"]; ok {
+		parts = append(parts, "memory="+output.FormatMemory(mem))
+	}
+	if len(parts) == 0 {
+		return "<none>"
+	}
+	return strings.Join(parts, " ")
+}
+
+// podQOSClass computes the pod QoS class using the Kubernetes rules:
+// BestEffort when no container sets CPU or memory requests or limits,
+// Guaranteed when every container has CPU and memory limits with equal
+// requests (or no requests), and Burstable otherwise.
+func podQOSClass(spec map[string]interface{}) string {
+	anySet := false
+	guaranteed := true
+	for _, c := range podContainers(spec) {
+		res := output.AsMap(output.AsMap(c)["resources"])
+		requests := output.AsMap(res["requests"])
+		limits := output.AsMap(res["limits"])
+		for _, name := range qosResources {
+			req := quantityOf(requests, name)
+			lim := quantityOf(limits, name)
+			if req > 0 || lim > 0 {
+				anySet = true
+			}
+			if lim == 0 || (req > 0 && req != lim) {
+				guaranteed = false
+			}
+		}
+	}
+
+	switch {
+	case !anySet:
+		return "BestEffort"
+	case guaranteed:
+		return "Guaranteed"
+	default:
+		return "Burstable"
+	}
+}
+
+func quantityOf(m map[string]interface{}, name string) float64 {
+	v, ok := m[name]
+	if !ok {
+		return 0
+	}
+	q, err := output.ParseQuantity(fmt.Sprintf("%v", v))
+	if err != nil {
+		return 0
+	}
+	return q
+}
+
+func printGenericDescribe(w io.Writer, opts describeOptions, meta, spec, status map[string]interface{}) {
 	if created := output.GetString(meta, "creationTimestamp"); created != "" {
-		fmt.Fprintf(os.Stdout, "Created:           %s\n", created)
+		fmt.Fprintf(w, "Created:           %s\n", created)
 	}
 
-	printLabelsAndAnnotations(meta)
+	printLabelsAndAnnotations(w, meta, opts.showAnnotations)
 
 	if phase := output.GetString(status, "phase"); phase != "" {
-		fmt.Fprintf(os.Stdout, "Status:            %s\n", phase)
+		fmt.Fprintf(w, "Status:            %s\n", phase)
 	}
 
 	_ = spec
 }
 
-func printLabelsAndAnnotations(meta map[string]interface{}) {
+func printDeploymentDescribe(w io.Writer, opts describeOptions, meta, spec, status map[string]interface{}) {
+	if created := output.GetString(meta, "creationTimestamp"); created != "" {
+		fmt.Fprintf(w, "Created:           %s\n", created)
+	}
+
+	printLabelsAndAnnotations(w, meta, opts.showAnnotations)
+
+	selector := output.AsMap(spec["selector"])
+	fmt.Fprintf(w, "Selector:          %s\n", formatSelector(output.AsMap(selector["matchLabels"])))
+
+	fmt.Fprintf(w, "Replicas:          %d desired | %d updated | %d total | %d available | %d unavailable\n",
+		intVal(spec, "replicas"),
+		intVal(status, "updatedReplicas"),
+		intVal(status, "replicas"),
+		intVal(status, "availableReplicas"),
+		intVal(status, "unavailableReplicas"))
+
+	strategy := output.AsMap(spec["strategy"])
+	if strategyType := output.GetString(strategy, "type"); strategyType != "" {
+		fmt.Fprintf(w, "Strategy:          %s\n", strategyType)
+	}
+	if ru := output.AsMap(strategy["rollingUpdate"]); len(ru) > 0 {
+		fmt.Fprintf(w, "Rolling Update:    %v max unavailable, %v max surge\n", ru["maxUnavailable"], ru["maxSurge"])
+	}
+	if minReady := intVal(spec, "minReadySeconds"); minReady > 0 {
+		fmt.Fprintf(w, "Min Ready Seconds: %d\n", minReady)
+	}
+}
+
+func printServiceDescribe(w io.Writer, opts describeOptions, meta, spec, status, data map[string]interface{}) {
+	if created := output.GetString(meta, "creationTimestamp"); created != "" {
+		fmt.Fprintf(w, "Created:           %s\n", created)
+	}
+
+	printLabelsAndAnnotations(w, meta, opts.showAnnotations)
+
+	fmt.Fprintf(w, "Selector:          %s\n", formatSelector(output.AsMap(spec["selector"])))
+	fmt.Fprintf(w, "Type:              %s\n", output.GetString(spec, "type"))
+	if ip := output.GetString(spec, "clusterIP"); ip != "" {
+		fmt.Fprintf(w, "IP:                %s\n", ip)
+	}
+	if externalIPs, ok := spec["externalIPs"].([]interface{}); ok && len(externalIPs) > 0 {
+		fmt.Fprintf(w, "External IPs:      %s\n", joinValues(externalIPs))
+	}
+	lb := output.AsMap(status["loadBalancer"])
+	if ingress, ok := lb["ingress"].([]interface{}); ok && len(ingress) > 0 {
+		var addrs []string
+		for _, in := range ingress {
+			im := output.AsMap(in)
+			if ip := output.GetString(im, "ip"); ip != "" {
+				addrs = append(addrs, ip)
+			} else if host := output.GetString(im, "hostname"); host != "" {
+				addrs = append(addrs, host)
+			}
+		}
+		fmt.Fprintf(w, "LoadBalancer:      %s\n", strings.Join(addrs, ", "))
+	}
+
+	if ports, ok := spec["ports"].([]interface{}); ok && len(ports) > 0 {
+		fmt.Fprintln(w, "Ports:")
+		for _, p := range ports {
+			pm := output.AsMap(p)
+			name := output.GetString(pm, "name")
+			if name == "" {
+				name = "<unset>"
+			}
+			proto := output.GetString(pm, "protocol")
+			if proto == "" {
+				proto = "TCP"
+			}
+			line := fmt.Sprintf("  %s  %v/%s -> %v", name, pm["port"], proto, pm["targetPort"])
+			if nodePort, ok := pm["nodePort"]; ok {
+				line += fmt.Sprintf(" (node port %v)", nodePort)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	if endpoints, ok := data["endpoints"].([]interface{}); ok {
+		if len(endpoints) == 0 {
+			fmt.Fprintln(w, "Endpoints:         <none>")
+		} else {
+			fmt.Fprintf(w, "Endpoints:         %s\n", joinValues(endpoints))
+		}
+	}
+
+	if affinity := output.GetString(spec, "sessionAffinity"); affinity != "" {
+		fmt.Fprintf(w, "Session Affinity:  %s\n", affinity)
+	}
+}
+
+func printNodeDescribe(w io.Writer, opts describeOptions, meta, spec, status map[string]interface{}) {
+	if created := output.GetString(meta, "creationTimestamp"); created != "" {
+		fmt.Fprintf(w, "Created:           %s\n", created)
+	}
+
+	printLabelsAndAnnotations(w, meta, opts.showAnnotations)
+
+	if taints, ok := spec["taints"].([]interface{}); ok && len(taints) > 0 {
+		fmt.Fprintln(w, "Taints:")
+		for _, t := range taints {
+			tm := output.AsMap(t)
+			taint := output.GetString(tm, "key")
+			if v := output.GetString(tm, "value"); v != "" {
+				taint += "=" + v
+			}
+			taint += ":" + output.GetString(tm, "effect")
+			fmt.Fprintf(w, "                   %s\n", taint)
+		}
+	} else {
+		fmt.Fprintln(w, "Taints:            <none>")
+	}
+	if unschedulable, ok := spec["unschedulable"].(bool); ok {
+		fmt.Fprintf(w, "Unschedulable:     %v\n", unschedulable)
+	} else {
+		fmt.Fprintln(w, "Unschedulable:     false")
+	}
+
+	if addresses, ok := status["addresses"].([]interface{}); ok && len(addresses) > 0 {
+		fmt.Fprintln(w, "Addresses:")
+		for _, a := range addresses {
+			am := output.AsMap(a)
+			fmt.Fprintf(w, "  %s: %s\n", output.GetString(am, "type"), output.GetString(am, "address"))
+		}
+	}
+
+	printResourceList(w, "Capacity:", output.AsMap(status["capacity"]))
+	printResourceList(w, "Allocatable:", output.AsMap(status["allocatable"]))
+
+	if info := output.AsMap(status["nodeInfo"]); len(info) > 0 {
+		fmt.Fprintln(w, "System Info:")
+		for _, f := range []struct{ label, key string }{
+			{"OS Image", "osImage"},
+			{"Kernel Version", "kernelVersion"},
+			{"Container Runtime", "containerRuntimeVersion"},
+			{"Kubelet Version", "kubeletVersion"},
+		} {
+			if v := output.GetString(info, f.key); v != "" {
+				fmt.Fprintf(w, "  %-19s%s\n", f.label+":", v)
+			}
+		}
+	}
+}
+
+// printResourceList prints a capacity-style map (cpu, memory, pods, ...) with
+// keys sorted for stable output.
+func printResourceList(w io.Writer, title string, m map[string]interface{}) {
+	if len(m) == 0 {
+		return
+	}
+	fmt.Fprintln(w, title)
+	for _, k := range sortedKeys(m) {
+		fmt.Fprintf(w, "  %-19s%v\n", k+":", m[k])
+	}
+}
+
+// formatSelector renders a label selector map as sorted k=v pairs.
+func formatSelector(m map[string]interface{}) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+	parts := make([]string, 0, len(m))
+	for k, v := range m {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func joinValues(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, fmt.Sprintf("%v", v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// intVal reads a JSON number from m as an int, returning 0 when absent.
+func intVal(m map[string]interface{}, key string) int {
+	if f, ok := m[key].(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+// lastAppliedAnnotation holds a full copy of the object and is too large to print.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+func printLabelsAndAnnotations(w io.Writer, meta map[string]interface{}, showAnnotations bool) {
 	if labels, ok := meta["labels"].(map[string]interface{}); ok && len(labels) > 0 {
-		fmt.Fprintln(os.Stdout, "Labels:")
-		for k, v := range labels {
-			fmt.Fprintf(os.Stdout, "                   %s=%v\n", k, v)
+		fmt.Fprintln(w, "Labels:")
+		for _, k := range sortedKeys(labels) {
+			fmt.Fprintf(w, "                   %s=%v\n", k, labels[k])
 		}
 	} else {
-		fmt.Fprintln(os.Stdout, "Labels:            <none>")
+		fmt.Fprintln(w, "Labels:            <none>")
+	}
+
+	annotations, ok := meta["annotations"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if !showAnnotations {
+		fmt.Fprintf(w, "Annotations:       %d\n", len(annotations))
+		return
 	}
-	if annotations, ok := meta["annotations"].(map[string]interface{}); ok {
-		fmt.Fprintf(os.Stdout, "Annotations:       %d\n", len(annotations))
+	if len(annotations) == 0 {
+		fmt.Fprintln(w, "Annotations:       <none>")
+		return
+	}
+	fmt.Fprintln(w, "Annotations:")
+	for _, k := range sortedKeys(annotations) {
+		v := fmt.Sprintf("%v", annotations[k])
+		if k == lastAppliedAnnotation {
+			v = fmt.Sprintf("<omitted, %d bytes>", len(v))
+		}
+		fmt.Fprintf(w, "                   %s: %s\n", k, v)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
 }
 
-func printContainerDetail(spec, status map[string]interface{}) {
+func printContainerDetail(w io.Writer, opts describeOptions, spec, status map[string]interface{}) {
 	name := output.GetString(spec, "name")
 	image := output.GetString(spec, "image")
 	if idx := strings.Index(image, "@"); idx > 0 {
 		image = image[:idx]
 	}
 
-	fmt.Fprintf(os.Stdout, "  %s:\n", name)
-	fmt.Fprintf(os.Stdout, "    Image:          %s\n", image)
+	fmt.Fprintf(w, "  %s:\n", name)
+	fmt.Fprintf(w, "    Image:          %s\n", image)
 
 	if len(status) > 0 {
 		state := output.AsMap(status["state"])
-		printContainerState("    State:          ", state)
+		printContainerState(w, opts, "    State:          ", state)
 
 		if lastState := output.AsMap(status["lastState"]); len(lastState) > 0 {
 			if terminated := output.AsMap(lastState["terminated"]); len(terminated) > 0 {
-				fmt.Fprintf(os.Stdout, "    Last State:     Terminated\n")
+				fmt.Fprintf(w, "    Last State:     Terminated\n")
 				if reason := output.GetString(terminated, "reason"); reason != "" {
-					fmt.Fprintf(os.Stdout, "      Reason:       %s\n", reason)
+					fmt.Fprintf(w, "      Reason:       %s\n", reason)
 				}
-				fmt.Fprintf(os.Stdout, "      Exit Code:    %v\n", terminated["exitCode"])
+				fmt.Fprintf(w, "      Exit Code:    %v\n", terminated["exitCode"])
 				if finished := output.GetString(terminated, "finishedAt"); finished != "" {
-					fmt.Fprintf(os.Stdout, "      Finished:     %s\n", finished)
+					fmt.Fprintf(w, "      Finished:     %s\n", finished)
 				}
 			}
 		}
 
-		fmt.Fprintf(os.Stdout, "    Ready:          %v\n", status["ready"])
-		fmt.Fprintf(os.Stdout, "    Restart Count:  %v\n", status["restartCount"])
+		fmt.Fprintf(w, "    Ready:          %v\n", status["ready"])
+		fmt.Fprintf(w, "    Restart Count:  %v\n", status["restartCount"])
 	} else {
-		fmt.Fprintln(os.Stdout, "    State:          Unknown (no status)")
+		fmt.Fprintln(w, "    State:          Unknown (no status)")
 	}
 
 	if ports, ok := spec["ports"].([]interface{}); ok && len(ports) > 0 {
@@ -273,44 +686,41 @@ func printContainerDetail(spec, status map[string]interface{}) {
 			}
 			portStrs = append(portStrs, fmt.Sprintf("%v/%s", pm["containerPort"], proto))
 		}
-		fmt.Fprintf(os.Stdout, "    Ports:          %s\n", strings.Join(portStrs, ", "))
+		fmt.Fprintf(w, "    Ports:          %s\n", strings.Join(portStrs, ", "))
 	}
 
 	if resources := output.AsMap(spec["resources"]); len(resources) > 0 {
 		if limits := output.AsMap(resources["limits"]); len(limits) > 0 {
-			fmt.Fprintf(os.Stdout, "    Limits:         %s\n", formatResourceMap(limits))
+			fmt.Fprintf(w, "    Limits:         %s\n", formatResourceMap(limits))
 		}
 		if requests := output.AsMap(resources["requests"]); len(requests) > 0 {
-			fmt.Fprintf(os.Stdout, "    Requests:       %s\n", formatResourceMap(requests))
+			fmt.Fprintf(w, "    Requests:       %s\n", formatResourceMap(requests))
 		}
 	}
 }
 
-func printContainerState(prefix string, state map[string]interface{}) {
+func printContainerState(w io.Writer, opts describeOptions, prefix string, state map[string]interface{}) {
 	if waiting := output.AsMap(state["waiting"]); len(waiting) > 0 {
-		fmt.Fprintf(os.Stdout, "%sWaiting\n", prefix)
+		fmt.Fprintf(w, "%sWaiting\n", prefix)
 		if reason := output.GetString(waiting, "reason"); reason != "" {
-			fmt.Fprintf(os.Stdout, "      Reason:       %s\n", reason)
+			fmt.Fprintf(w, "      Reason:       %s\n", reason)
 		}
 		if msg := output.GetString(waiting, "message"); msg != "" {
-			if len(msg) > 80 {
-				msg = msg[:80]
-			}
-			fmt.Fprintf(os.Stdout, "      Message:      %s\n", msg)
+			fmt.Fprintf(w, "      Message:      %s\n", opts.truncate(msg, containerMessageMax))
 		}
 	} else if running := output.AsMap(state["running"]); len(running) > 0 {
-		fmt.Fprintf(os.Stdout, "%sRunning\n", prefix)
+		fmt.Fprintf(w, "%sRunning\n", prefix)
 		if started := output.GetString(running, "startedAt"); started != "" {
-			fmt.Fprintf(os.Stdout, "      Started:      %s\n", started)
+			fmt.Fprintf(w, "      Started:      %s\n", started)
 		}
 	} else if terminated := output.AsMap(state["terminated"]); len(terminated) > 0 {
-		fmt.Fprintf(os.Stdout, "%sTerminated\n", prefix)
+		fmt.Fprintf(w, "%sTerminated\n", prefix)
 		if reason := output.GetString(terminated, "reason"); reason != "" {
-			fmt.Fprintf(os.Stdout, "      Reason:       %s\n", reason)
+			fmt.Fprintf(w, "      Reason:       %s\n", reason)
 		}
-		fmt.Fprintf(os.Stdout, "      Exit Code:    %v\n", terminated["exitCode"])
+		fmt.Fprintf(w, "      Exit Code:    %v\n", terminated["exitCode"])
 	} else {
-		fmt.Fprintf(os.Stdout, "%sUnknown\n", prefix)
+		fmt.Fprintf(w, "%sUnknown\n", prefix)
 	}
 }
 
@@ -354,53 +764,48 @@ func formatResourceMap(m map[string]interface{}) string {
 	return strings.Join(parts, ", ")
 }
 
-func printConditions(data map[string]interface{}) {
+func printConditions(w io.Writer, opts describeOptions, data map[string]interface{}) {
 	conditions, ok := data["conditions"].([]interface{})
 	if !ok || len(conditions) == 0 {
 		return
 	}
-	fmt.Fprintln(os.Stdout, "\nConditions:")
+	fmt.Fprintln(w, "\nConditions:")
 	for _, c := range conditions {
 		cm := output.AsMap(c)
 		line := fmt.Sprintf("  %s: %s", output.GetString(cm, "type"), output.GetString(cm, "status"))
 		if reason := output.GetString(cm, "reason"); reason != "" {
 			line += fmt.Sprintf(" (%s)", reason)
 		}
-		if msg := output.GetString(cm, "message"); msg != "" && len(msg) < 50 {
+		if msg := output.GetString(cm, "message"); msg != "" && (opts.noTruncate || len(msg) < conditionMessageMax) {
 			line += fmt.Sprintf(" - %s", msg)
 		}
-		fmt.Fprintln(os.Stdout, line)
+		fmt.Fprintln(w, line)
 	}
 }
 
-func printEvents(data map[string]interface{}) {
+func printEvents(w io.Writer, opts describeOptions, data map[string]interface{}) {
 	events, ok := data["events"].(map[string]interface{})
 	if !ok {
 		return
 	}
 	items, _ := events["items"].([]interface{})
-	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(w)
 	if len(items) == 0 {
-		fmt.Fprintln(os.Stdout, "Events:            <none>")
+		fmt.Fprintln(w, "Events:            <none>")
 		return
 	}
-	fmt.Fprintln(os.Stdout, "Events:")
-	t := output.NewTable(os.Stdout, "AGE", "TYPE", "REASON", "MESSAGE")
+	fmt.Fprintln(w, "Events:")
+	t := output.NewTable(w, "AGE", "TYPE", "REASON", "MESSAGE")
+	if opts.noTruncate {
+		t.SetMaxWidth("MESSAGE", 0)
+	}
 	for _, item := range items {
 		ev := output.AsMap(item)
-		lastTimestamp := output.GetString(ev, "lastTimestamp")
-		if lastTimestamp == "" {
-			lastTimestamp = output.GetString(ev, "eventTime")
-		}
-		msg := output.GetString(ev, "message")
-		if len(msg) > 70 {
-			msg = msg[:70]
-		}
 		t.AddRow(
-			output.Age(lastTimestamp),
+			output.EventAge(ev),
 			output.GetString(ev, "type"),
 			output.GetString(ev, "reason"),
-			msg,
+			opts.truncate(output.GetString(ev, "message"), eventMessageMax),
 		)
 	}
 	_ = t.Flush()
@@ -0,0 +1,289 @@
+package ops
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintDescribeText_Deployment(t *testing.T) {
+	data := map[string]interface{}{
+		"resource": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":              "operator",
+				"namespace":         "hypershift",
+				"creationTimestamp": "2025-01-01T00:00:00Z",
+				"labels":            map[string]interface{}{"app": "operator"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": float64(2),
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"name": "operator", "app": "operator"},
+				},
+				"strategy": map[string]interface{}{
+					"type": "RollingUpdate",
+					"rollingUpdate": map[string]interface{}{
+						"maxUnavailable": "25%",
+						"maxSurge":       "25%",
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"replicas":            float64(2),
+				"updatedReplicas":     float64(2),
+				"availableReplicas":   float64(1),
+				"unavailableReplicas": float64(1),
+			},
+		},
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Available", "status": "True", "reason": "MinimumReplicasAvailable"},
+		},
+	}
+
+	want := `Name:              operator
+Namespace:         hypershift
+Created:           2025-01-01T00:00:00Z
+Labels:
+                   app=operator
+Selector:          app=operator,name=operator
+Replicas:          2 desired | 2 updated | 2 total | 1 available | 1 unavailable
+Strategy:          RollingUpdate
+Rolling Update:    25% max unavailable, 25% max surge
+
+Conditions:
+  Available: True (MinimumReplicasAvailable)
+`
+
+	var buf bytes.Buffer
+	printDescribeText(&buf, data, "deployments", describeOptions{})
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestPrintDescribeText_Service(t *testing.T) {
+	data := map[string]interface{}{
+		"resource": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":              "kube-apiserver",
+				"namespace":         "clusters-abc",
+				"creationTimestamp": "2025-01-01T00:00:00Z",
+			},
+			"spec": map[string]interface{}{
+				"type":      "ClusterIP",
+				"clusterIP": "10.0.0.12",
+				"selector":  map[string]interface{}{"app": "kube-apiserver"},
+				"ports": []interface{}{
+					map[string]interface{}{"name": "client", "port": float64(6443), "protocol": "TCP", "targetPort": "client"},
+				},
+				"sessionAffinity": "None",
+			},
+		},
+	}
+
+	want := `Name:              kube-apiserver
+Namespace:         clusters-abc
+Created:           2025-01-01T00:00:00Z
+Labels:            <none>
+Selector:          app=kube-apiserver
+Type:              ClusterIP
+IP:                10.0.0.12
+Ports:
+  client  6443/TCP -> client
+Session Affinity:  None
+`
+
+	var buf bytes.Buffer
+	printDescribeText(&buf, data, "services", describeOptions{})
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func containerWithResources(requests, limits map[string]interface{}) interface{} {
+	res := map[string]interface{}{}
+	if requests != nil {
+		res["requests"] = requests
+	}
+	if limits != nil {
+		res["limits"] = limits
+	}
+	return map[string]interface{}{"name": "c", "resources": res}
+}
+
+func TestPodQOSClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []interface{}
+		want       string
+	}{
+		{
+			name:       "no container sets resources",
+			containers: []interface{}{containerWithResources(nil, nil)},
+			want:       "BestEffort",
+		},
+		{
+			name: "requests equal limits for cpu and memory",
+			containers: []interface{}{
+				containerWithResources(
+					map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+					map[string]interface{}{"cpu": "0.5", "memory": "1024Mi"},
+				),
+			},
+			want: "Guaranteed",
+		},
+		{
+			name: "only limits are set",
+			containers: []interface{}{
+				containerWithResources(nil, map[string]interface{}{"cpu": "1", "memory": "512Mi"}),
+			},
+			want: "Guaranteed",
+		},
+		{
+			name: "requests are below limits",
+			containers: []interface{}{
+				containerWithResources(
+					map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+					map[string]interface{}{"cpu": "1", "memory": "512Mi"},
+				),
+			},
+			want: "Burstable",
+		},
+		{
+			name: "one container has no resources",
+			containers: []interface{}{
+				containerWithResources(nil, map[string]interface{}{"cpu": "1", "memory": "512Mi"}),
+				containerWithResources(nil, nil),
+			},
+			want: "Burstable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := map[string]interface{}{"containers": tt.containers}
+			if got := podQOSClass(spec); got != tt.want {
+				t.Errorf("podQOSClass() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodResourceTotals(t *testing.T) {
+	spec := map[string]interface{}{
+		"containers": []interface{}{
+			containerWithResources(
+				map[string]interface{}{"cpu": "250m", "memory": "512Mi"},
+				map[string]interface{}{"cpu": "1"},
+			),
+			containerWithResources(
+				map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+				nil,
+			),
+		},
+	}
+	requests, limits := podResourceTotals(spec)
+	if got := formatResourceTotals(requests); got != "cpu=350m memory=768Mi" {
+		t.Errorf("requests = %q", got)
+	}
+	if got := formatResourceTotals(limits); got != "cpu=1" {
+		t.Errorf("limits = %q", got)
+	}
+	if got := formatResourceTotals(map[string]float64{}); got != "<none>" {
+		t.Errorf("empty totals = %q", got)
+	}
+}
+
+func TestPrintLabelsAndAnnotations(t *testing.T) {
+	meta := map[string]interface{}{
+		"labels": map[string]interface{}{"zone": "a", "app": "etcd", "role": "db"},
+		"annotations": map[string]interface{}{
+			"owner":               "sre",
+			lastAppliedAnnotation: `{"apiVersion":"v1","kind":"Pod"}`,
+		},
+	}
+
+	t.Run("annotations are hidden", func(t *testing.T) {
+		var buf bytes.Buffer
+		printLabelsAndAnnotations(&buf, meta, false)
+		want := `Labels:
+                   app=etcd
+                   role=db
+                   zone=a
+Annotations:       2
+`
+		if got := buf.String(); got != want {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("annotations are shown", func(t *testing.T) {
+		var buf bytes.Buffer
+		printLabelsAndAnnotations(&buf, meta, true)
+		want := `Labels:
+                   app=etcd
+                   role=db
+                   zone=a
+Annotations:
+                   kubectl.kubernetes.io/last-applied-configuration: <omitted, 32 bytes>
+                   owner: sre
+`
+		if got := buf.String(); got != want {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+}
+
+func TestPrintEvents_Truncation(t *testing.T) {
+	long := "Failed to pull image \"quay.io/example/operator:v1\": rpc error: code = Unknown desc = manifest unknown"
+	data := map[string]interface{}{
+		"events": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"type": "Warning", "reason": "Failed", "message": long},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		noTruncate bool
+		want       string
+		notWant    string
+	}{
+		{"--no-truncate is not set", false, long[:eventMessageMax], long},
+		{"--no-truncate is set", true, long, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printEvents(&buf, describeOptions{noTruncate: tt.noTruncate}, data)
+			got := buf.String()
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("expected %q in output, got:\n%s", tt.want, got)
+			}
+			if tt.notWant != "" && strings.Contains(got, tt.notWant) {
+				t.Errorf("expected the message to be truncated, got:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestPrintConditions_LongMessage(t *testing.T) {
+	long := strings.Repeat("x", conditionMessageMax+10)
+	data := map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "message": long},
+		},
+	}
+
+	var buf bytes.Buffer
+	printConditions(&buf, describeOptions{}, data)
+	if strings.Contains(buf.String(), long) {
+		t.Errorf("expected the long message to be left out, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	printConditions(&buf, describeOptions{noTruncate: true}, data)
+	if !strings.Contains(buf.String(), "  Ready: False - "+long) {
+		t.Errorf("expected the long message with --no-truncate, got:\n%s", buf.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/cloudrun"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -62,14 +63,14 @@ Examples:
 
 			client := cloudrun.NewClient(ctx, project, region)
 
-			fmt.Fprintf(os.Stderr, "Discovering diagnose-agent service in %s/%s...\n", project, region)
+			progress.Printf(cmd, "Discovering diagnose-agent service in %s/%s...\n", project, region)
 			serviceURL, err := client.DiscoverServiceURL(ctx, serviceName)
 			if err != nil {
 				return fmt.Errorf("discovering service: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Sending query to diagnose-agent...\n")
-			fmt.Fprintf(os.Stderr, "  Query: %s\n\n", query)
+			progress.Printf(cmd, "Sending query to diagnose-agent...\n")
+			progress.Printf(cmd, "  Query: %s\n\n", query)
 
 			format := output.ParseFormat(outputFormat)
 
@@ -82,13 +83,13 @@ Examples:
 				case "tool_call":
 					step++
 					desc := formatToolCall(event.Tool, event.Parameters)
-					fmt.Fprintf(os.Stderr, "  [%d] %s\n", step, desc)
+					progress.Printf(cmd, "  [%d] %s\n", step, desc)
 				case "tool_result":
 					result := unquoteResult(event.Result)
 					if len(result) > 80 {
 						result = result[:80] + "..."
 					}
-					fmt.Fprintf(os.Stderr, "      -> %s\n", result)
+					progress.Printf(cmd, "      -> %s\n", result)
 				}
 			})
 			if err != nil {
@@ -99,7 +100,7 @@ Examples:
 				return fmt.Errorf("diagnose-agent error: %s", resp.Error)
 			}
 
-			fmt.Fprintln(os.Stderr)
+			progress.Printf(cmd, "\n")
 
 			if format == output.FormatJSON {
 				return output.PrintJSON(os.Stdout, resp)
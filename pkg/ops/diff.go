@@ -0,0 +1,292 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "diff <workflow-or-type> <execution-a> <execution-b>",
+		Short: "Compare the results of two workflow executions",
+		Long: `Compare the resources returned by two earlier executions, e.g. two runs
+of "ops get pods" at different points in time.
+
+The first argument is a workflow name, or a resource type (pods, deploy, ...)
+for executions of the get workflow. Execution IDs may be abbreviated to any
+unique prefix of a recent execution.
+
+Text output is a unified diff of the rendered tables. With -o json, prints
+the namespace/name of added, removed, and changed resources.
+
+Examples:
+  # What changed between two get pods runs
+  gcphcp ops diff pods abc123 def456
+
+  # Structured diff of any workflow's results
+  gcphcp ops diff get abc123 def456 -o json`,
+
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowName, resourceType := diffTarget(args[0], workflowPrefix(cmd))
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			var (
+				ids   [2]string
+				items [2][]interface{}
+			)
+			for i, idPrefix := range args[1:] {
+				ids[i], items[i], err = fetchExecutionItems(ctx, client, project, region, workflowName, idPrefix)
+				if err != nil {
+					return err
+				}
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			if output.ParseFormat(outputFormat) == output.FormatJSON {
+				return output.PrintJSON(out, diffResources(items[0], items[1]))
+			}
+
+			if resourceType == "" {
+				resourceType = resourceTypeFromItems(items[0], items[1], workflowName)
+			}
+			var tables [2]bytes.Buffer
+			for i := range items {
+				if err := output.PrintResourceTable(&tables[i], items[i], resourceType); err != nil {
+					return err
+				}
+			}
+			return printUnifiedDiff(out, ids[0], ids[1], tables[0].String(), tables[1].String())
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+
+	return cmd
+}
+
+// diffTarget maps the first diff argument to a workflow name and, when the
+// argument is a resource type, the type used to render get results. A
+// resource type maps to the get workflow named with prefix; a workflow name
+// is used as given.
+func diffTarget(arg, prefix string) (workflowName, resourceType string) {
+	if expanded, ok := resourceTypeExpand[arg]; ok {
+		return prefix + "get", expanded
+	}
+	for _, rt := range resourceTypeExpand {
+		if rt == arg {
+			return prefix + "get", arg
+		}
+	}
+	return arg, ""
+}
+
+// resourceTypeFromItems derives a plural resource type from the kind of the
+// first item, falling back to fallback.
+func resourceTypeFromItems(a, b []interface{}, fallback string) string {
+	for _, items := range [][]interface{}{a, b} {
+		for _, item := range items {
+			if kind := output.GetString(output.AsMap(item), "kind"); kind != "" {
+				return strings.ToLower(kind) + "s"
+			}
+		}
+	}
+	return fallback
+}
+
+// fetchExecutionItems resolves an execution ID prefix and returns the full
+// ID and the resource items of its result.
+func fetchExecutionItems(ctx context.Context, client *workflows.Client, project, region, workflowName, idPrefix string) (string, []interface{}, error) {
+	execID, err := client.ResolveExecution(ctx, workflowName, idPrefix)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving execution: %w", err)
+	}
+
+	execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
+		project, region, workflowName, execID)
+	result, err := client.GetExecution(ctx, execName)
+	if err != nil {
+		return "", nil, fmt.Errorf("getting execution %s: %w", execID, err)
+	}
+	if result.State != "SUCCEEDED" {
+		return "", nil, fmt.Errorf("execution %s is %s; only succeeded executions can be compared", execID, result.State)
+	}
+
+	items, ok := output.ResourceItems(result.Result)
+	if !ok {
+		return "", nil, fmt.Errorf("execution %s did not return a resource list", execID)
+	}
+	return execID, items, nil
+}
+
+// resourceDiff lists resources, by namespace/name, that differ between two
+// results.
+type resourceDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// diffResources compares two item lists by namespace/name. A resource is
+// changed when anything other than server-managed bookkeeping
+// (resourceVersion, managedFields) differs.
+func diffResources(before, after []interface{}) resourceDiff {
+	a := indexResources(before)
+	b := indexResources(after)
+
+	d := resourceDiff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for key, item := range b {
+		old, ok := a[key]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, key)
+		case !reflect.DeepEqual(withoutBookkeeping(old), withoutBookkeeping(item)):
+			d.Changed = append(d.Changed, key)
+		}
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			d.Removed = append(d.Removed, key)
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Changed)
+	return d
+}
+
+// indexResources keys items by namespace/name, or name for cluster-scoped
+// resources.
+func indexResources(items []interface{}) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{}, len(items))
+	for _, raw := range items {
+		item := output.AsMap(raw)
+		meta := output.AsMap(item["metadata"])
+		key := output.GetString(meta, "name")
+		if ns := output.GetString(meta, "namespace"); ns != "" {
+			key = ns + "/" + key
+		}
+		index[key] = item
+	}
+	return index
+}
+
+// withoutBookkeeping returns a copy of item without fields that change on every
+// write regardless of content.
+func withoutBookkeeping(item map[string]interface{}) map[string]interface{} {
+	meta := output.AsMap(item["metadata"])
+	trimmed := make(map[string]interface{}, len(meta))
+	for k, v := range meta {
+		if k == "resourceVersion" || k == "managedFields" {
+			continue
+		}
+		trimmed[k] = v
+	}
+
+	c := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		c[k] = v
+	}
+	c["metadata"] = trimmed
+	return c
+}
+
+// printUnifiedDiff writes a line diff of a and b with full context, or a
+// note when they are identical.
+func printUnifiedDiff(w io.Writer, labelA, labelB, a, b string) error {
+	if a == b {
+		_, err := fmt.Fprintln(w, "No differences.")
+		return err
+	}
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", labelA, labelB)
+	for _, line := range diffLines(strings.Split(strings.TrimRight(a, "\n"), "\n"), strings.Split(strings.TrimRight(b, "\n"), "\n")) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffLines returns a and b merged into lines prefixed with " ", "-", or "+",
+// based on their longest common subsequence.
+func diffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+	return lines
+}
@@ -0,0 +1,133 @@
+package ops
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func diffPod(name, phase, resourceVersion string) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            name,
+			"namespace":       "hypershift",
+			"resourceVersion": resourceVersion,
+		},
+		"status": map[string]interface{}{"phase": phase},
+	}
+}
+
+func TestDiffResources(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []interface{}
+		after  []interface{}
+		want   resourceDiff
+	}{
+		{
+			name:   "one pod is added and one removed",
+			before: []interface{}{diffPod("etcd-0", "Running", "1"), diffPod("old-0", "Running", "1")},
+			after:  []interface{}{diffPod("etcd-0", "Running", "1"), diffPod("new-0", "Pending", "1")},
+			want: resourceDiff{
+				Added:   []string{"hypershift/new-0"},
+				Removed: []string{"hypershift/old-0"},
+				Changed: []string{},
+			},
+		},
+		{
+			name:   "pod's status changes",
+			before: []interface{}{diffPod("etcd-0", "Running", "1")},
+			after:  []interface{}{diffPod("etcd-0", "Failed", "2")},
+			want:   resourceDiff{Added: []string{}, Removed: []string{}, Changed: []string{"hypershift/etcd-0"}},
+		},
+		{
+			name:   "only the resourceVersion differs",
+			before: []interface{}{diffPod("etcd-0", "Running", "1")},
+			after:  []interface{}{diffPod("etcd-0", "Running", "7")},
+			want:   resourceDiff{Added: []string{}, Removed: []string{}, Changed: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffResources(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintUnifiedDiff_PodTables(t *testing.T) {
+	before := []interface{}{diffPod("etcd-0", "Running", "1"), diffPod("old-0", "Running", "1")}
+	after := []interface{}{diffPod("etcd-0", "Running", "1"), diffPod("new-0", "Running", "1")}
+
+	var a, b, buf bytes.Buffer
+	if err := output.PrintResourceTable(&a, before, "pods"); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.PrintResourceTable(&b, after, "pods"); err != nil {
+		t.Fatal(err)
+	}
+	if err := printUnifiedDiff(&buf, "exec-a", "exec-b", a.String(), b.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	var added, removed, unchanged []string
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")[2:] {
+		switch line[0] {
+		case '+':
+			added = append(added, line)
+		case '-':
+			removed = append(removed, line)
+		default:
+			unchanged = append(unchanged, line)
+		}
+	}
+	if !strings.HasPrefix(buf.String(), "--- exec-a\n+++ exec-b\n") {
+		t.Errorf("missing diff header:\n%s", buf.String())
+	}
+	if len(added) != 1 || !strings.Contains(added[0], "new-0") {
+		t.Errorf("expected new-0 added, got %v", added)
+	}
+	if len(removed) != 1 || !strings.Contains(removed[0], "old-0") {
+		t.Errorf("expected old-0 removed, got %v", removed)
+	}
+	if len(unchanged) != 2 || !strings.Contains(unchanged[1], "etcd-0") {
+		t.Errorf("expected header and etcd-0 as unchanged, got %v", unchanged)
+	}
+}
+
+func TestPrintUnifiedDiff_Identical(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printUnifiedDiff(&buf, "a", "b", "same\n", "same\n"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "No differences.\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestDiffTarget(t *testing.T) {
+	tests := []struct {
+		arg          string
+		prefix       string
+		wantWorkflow string
+		wantType     string
+	}{
+		{arg: "po", wantWorkflow: "get", wantType: "pods"},
+		{arg: "pods", wantWorkflow: "get", wantType: "pods"},
+		{arg: "logs", wantWorkflow: "logs", wantType: ""},
+		{arg: "pods", prefix: "gcphcp-", wantWorkflow: "gcphcp-get", wantType: "pods"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix+tt.arg, func(t *testing.T) {
+			wf, rt := diffTarget(tt.arg, tt.prefix)
+			if wf != tt.wantWorkflow || rt != tt.wantType {
+				t.Errorf("diffTarget(%q) = %q, %q", tt.arg, wf, rt)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/spf13/cobra"
+)
+
+// doctorWorkflows are the workflows the convenience commands depend on.
+var doctorWorkflows = []string{"get", "logs", "describe"}
+
+// workflowLister is the subset of *workflows.Client used by doctor, so tests
+// can substitute a canned workflow list.
+type workflowLister interface {
+	List(ctx context.Context) ([]workflows.WorkflowInfo, error)
+}
+
+// doctorCheck is one line of the doctor checklist. Err is nil when the check
+// passed; its message is printed as the remediation hint otherwise.
+type doctorCheck struct {
+	Name string
+	Err  error
+}
+
+func newDoctorCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check credentials, API access, and workflow deployment",
+		Long: `Verify that the environment is ready for ops commands: Application
+Default Credentials are present and unexpired, the Workflows API is reachable
+in the project/region, and the get, logs, and describe workflows are deployed.
+
+Prints a checklist with a remediation hint for each failed check and exits
+non-zero if any check fails.
+
+Examples:
+  gcphcp ops doctor --project my-project --region us-central1`,
+
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			var lister workflowLister
+			credErr := workflows.CheckCredentials(ctx)
+			if credErr == nil {
+				client, err := workflows.NewClient(ctx, project, region)
+				if err != nil {
+					credErr = err
+				} else {
+					defer client.Close()
+					lister = client
+				}
+			}
+
+			prefix := workflowPrefix(cmd)
+			required := make([]string, len(doctorWorkflows))
+			for i, name := range doctorWorkflows {
+				required[i] = prefix + name
+			}
+
+			checks := runDoctorChecks(ctx, credErr, lister, project, region, required)
+			return printDoctorChecks(cmd.OutOrStdout(), checks)
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait for the checks")
+
+	return cmd
+}
+
+// runDoctorChecks builds the checklist. credErr is the result of the
+// credentials check; when it failed (or lister is nil) the API and workflow
+// checks are reported as skipped failures since they cannot run.
+func runDoctorChecks(ctx context.Context, credErr error, lister workflowLister, project, region string, required []string) []doctorCheck {
+	apiName := fmt.Sprintf("Workflows API reachable in %s/%s", project, region)
+	checks := []doctorCheck{{Name: "Application Default Credentials", Err: credErr}}
+
+	if credErr != nil || lister == nil {
+		skipped := fmt.Errorf("skipped: credentials check failed")
+		checks = append(checks, doctorCheck{Name: apiName, Err: skipped})
+		for _, name := range required {
+			checks = append(checks, doctorCheck{Name: fmt.Sprintf("Workflow %q deployed", name), Err: skipped})
+		}
+		return checks
+	}
+
+	deployed, err := lister.List(ctx)
+	checks = append(checks, doctorCheck{Name: apiName, Err: err})
+	if err != nil {
+		skipped := fmt.Errorf("skipped: Workflows API unreachable")
+		for _, name := range required {
+			checks = append(checks, doctorCheck{Name: fmt.Sprintf("Workflow %q deployed", name), Err: skipped})
+		}
+		return checks
+	}
+
+	present := make(map[string]bool, len(deployed))
+	for _, wf := range deployed {
+		present[wf.Name] = true
+	}
+	for _, name := range required {
+		check := doctorCheck{Name: fmt.Sprintf("Workflow %q deployed", name)}
+		if !present[name] {
+			check.Err = fmt.Errorf("workflow %q not found in %s/%s\n\n"+
+				"  Deploy the ops workflows to this project, or check --project and --region", name, project, region)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// printDoctorChecks writes the checklist to w and returns an error naming the
+// number of failed checks, or nil if all passed.
+func printDoctorChecks(w io.Writer, checks []doctorCheck) error {
+	failed := 0
+	for _, c := range checks {
+		if c.Err == nil {
+			fmt.Fprintf(w, "✓ %s\n", c.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "✗ %s\n", c.Name)
+		for _, line := range strings.Split(c.Err.Error(), "\n") {
+			if line == "" {
+				continue
+			}
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
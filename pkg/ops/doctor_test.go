@@ -0,0 +1,99 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+// fakeLister returns a canned workflow list.
+type fakeLister struct {
+	workflows []workflows.WorkflowInfo
+	err       error
+	called    bool
+}
+
+func (f *fakeLister) List(context.Context) ([]workflows.WorkflowInfo, error) {
+	f.called = true
+	return f.workflows, f.err
+}
+
+func failedChecks(checks []doctorCheck) []string {
+	var names []string
+	for _, c := range checks {
+		if c.Err != nil {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+func TestRunDoctorChecks(t *testing.T) {
+	required := []string{"get", "logs", "describe"}
+
+	t.Run("every workflow is deployed", func(t *testing.T) {
+		lister := &fakeLister{workflows: []workflows.WorkflowInfo{{Name: "get"}, {Name: "logs"}, {Name: "describe"}, {Name: "exec"}}}
+		checks := runDoctorChecks(context.Background(), nil, lister, "p", "r", required)
+		if len(checks) != 5 {
+			t.Fatalf("got %d checks, want 5", len(checks))
+		}
+		if failed := failedChecks(checks); len(failed) != 0 {
+			t.Errorf("unexpected failures: %v", failed)
+		}
+	})
+
+	t.Run("workflows are missing", func(t *testing.T) {
+		lister := &fakeLister{workflows: []workflows.WorkflowInfo{{Name: "get"}}}
+		checks := runDoctorChecks(context.Background(), nil, lister, "p", "r", required)
+		failed := failedChecks(checks)
+		want := []string{`Workflow "logs" deployed`, `Workflow "describe" deployed`}
+		if strings.Join(failed, ",") != strings.Join(want, ",") {
+			t.Errorf("failed = %v, want %v", failed, want)
+		}
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		lister := &fakeLister{err: errors.New("listing workflows: permission denied")}
+		checks := runDoctorChecks(context.Background(), nil, lister, "p", "r", required)
+		if len(failedChecks(checks)) != 4 {
+			t.Errorf("got %d failures, want 4", len(failedChecks(checks)))
+		}
+		if !strings.Contains(checks[1].Err.Error(), "permission denied") {
+			t.Errorf("API check error = %v", checks[1].Err)
+		}
+	})
+
+	t.Run("credentials are missing", func(t *testing.T) {
+		lister := &fakeLister{}
+		checks := runDoctorChecks(context.Background(), errors.New("no GCP credentials found"), lister, "p", "r", required)
+		if lister.called {
+			t.Error("List called despite failed credentials check")
+		}
+		if len(failedChecks(checks)) != len(checks) {
+			t.Errorf("got %d failures, want %d", len(failedChecks(checks)), len(checks))
+		}
+	})
+}
+
+func TestPrintDoctorChecks(t *testing.T) {
+	var buf bytes.Buffer
+	err := printDoctorChecks(&buf, []doctorCheck{
+		{Name: "Application Default Credentials"},
+		{Name: `Workflow "logs" deployed`, Err: errors.New("workflow \"logs\" not found\n\n  Deploy the ops workflows")},
+	})
+	if err == nil || err.Error() != "1 of 2 checks failed" {
+		t.Errorf("err = %v, want 1 of 2 checks failed", err)
+	}
+
+	want := "✓ Application Default Credentials\n" +
+		"✗ Workflow \"logs\" deployed\n" +
+		"    workflow \"logs\" not found\n" +
+		"      Deploy the ops workflows\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
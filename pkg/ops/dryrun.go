@@ -0,0 +1,44 @@
+package ops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addDryRunFlag registers --dry-run on a convenience command that wraps a
+// single workflow.
+func addDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Print the equivalent 'gcphcp ops wf run' command instead of executing the workflow")
+}
+
+// printDryRun writes the wf run invocation that would execute workflowName
+// with data. The arguments are compact JSON with sorted keys, quoted for a
+// POSIX shell.
+func printDryRun(w io.Writer, workflowName string, data map[string]interface{}) error {
+	args, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshaling arguments: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "gcphcp ops wf run %s --data %s\n", workflowName, shellQuote(string(args)))
+	return err
+}
+
+// printNamespacePrefixDryRun writes the get call that lists namespaces to
+// resolve --namespace-prefix, followed by a shell comment explaining that
+// the next command runs with the resolved namespace.
+func printNamespacePrefixDryRun(w io.Writer, getWorkflow, prefix string) error {
+	if err := printDryRun(w, getWorkflow, map[string]interface{}{"resource_type": "namespaces"}); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "# then with \"namespace\" set to the one namespace starting with %q:\n", prefix)
+	return err
+}
+
+// shellQuote wraps s in single quotes, escaping any embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
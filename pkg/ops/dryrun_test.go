@@ -0,0 +1,117 @@
+package ops
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPrintDryRun_QuotesData(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]interface{}{"namespace": "it's", "resource_type": "pods"}
+	if err := printDryRun(&buf, "get", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `gcphcp ops wf run get --data '{"namespace":"it'\''s","resource_type":"pods"}'` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// newDryRunGetCmd returns a get command with the root persistent flags it
+// reads registered locally.
+func newDryRunGetCmd() *cobra.Command {
+	cmd := newGetCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.Flags().String("project", "", "")
+	cmd.Flags().String("region", "", "")
+	cmd.Flags().StringP("output", "o", "", "")
+	cmd.Flags().String("workflow-prefix", "", "")
+	return cmd
+}
+
+func TestGetCmd_DryRun(t *testing.T) {
+	// Point credentials at a file that does not exist so any attempt to
+	// construct a workflows client fails the command.
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+
+	cmd := newDryRunGetCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"pods", "-n", "foo", "-l", "app=x", "--project", "p", "--region", "r", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `gcphcp ops wf run get --data '{"label_selector":"app=x","namespace":"foo","resource_type":"pods"}'` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGetCmd_DryRunWorkflowPrefix(t *testing.T) {
+	cmd := newDryRunGetCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"nodes", "--project", "p", "--region", "r", "--workflow-prefix", "gcphcp-", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `gcphcp ops wf run gcphcp-get --data '{"resource_type":"nodes"}'` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGetCmd_DryRunStdin(t *testing.T) {
+	cmd := newDryRunGetCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("# targets\npods etcd-0\n\nsvc/etcd-client\n"))
+	cmd.SetArgs([]string{"-", "-n", "clusters-abc", "--project", "p", "--region", "r", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `gcphcp ops wf run get --data '{"name":"etcd-0","namespace":"clusters-abc","resource_type":"pods"}'` + "\n" +
+		`gcphcp ops wf run get --data '{"name":"etcd-client","namespace":"clusters-abc","resource_type":"services"}'` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGetCmd_StdinRejectsName(t *testing.T) {
+	cmd := newDryRunGetCmd()
+	cmd.SetIn(strings.NewReader("pods etcd-0\n"))
+	cmd.SetArgs([]string{"-", "etcd-0", "--project", "p", "--region", "r", "--dry-run"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "stdin") {
+		t.Errorf("expected an error about stdin, got %v", err)
+	}
+}
+
+func TestLogsCmd_DryRunNamespacePrefix(t *testing.T) {
+	cmd := newLogsCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	for _, name := range []string{"project", "region", "output", "workflow-prefix"} {
+		cmd.Flags().String(name, "", "")
+	}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"kas-0", "--namespace-prefix", "clusters-abc", "--project", "p", "--region", "r", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `gcphcp ops wf run get --data '{"resource_type":"namespaces"}'` + "\n" +
+		`# then with "namespace" set to the one namespace starting with "clusters-abc":` + "\n" +
+		`gcphcp ops wf run logs --data '{"pod":"kas-0","tail_lines":100}'` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -1,14 +1,15 @@
 package ops
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +31,8 @@ Examples:
   gcphcp ops etcd compact -n clusters-abc123`,
 	}
 
+	cmd.PersistentFlags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+
 	cmd.AddCommand(newEtcdHealthCmd())
 	cmd.AddCommand(newEtcdStatusCmd())
 	cmd.AddCommand(newEtcdMemberListCmd())
@@ -54,11 +57,11 @@ Examples:
   gcphcp ops etcd health -n clusters-abc123
   gcphcp ops etcd health -n clusters-abc123 -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEtcdCommand(cmd, "etcd-health", namespace, timeout, func(format output.Format, result map[string]interface{}) error {
+			return runEtcdCommand(cmd, "etcd-health", namespace, timeout, func(w io.Writer, format output.Format, result map[string]interface{}) error {
 				if format == output.FormatJSON {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
-				return output.PrintTable(os.Stdout, parseEtcdOutput(result), etcdHealthColumns)
+				return output.PrintTable(w, parseEtcdOutput(result), etcdHealthColumns)
 			})
 		},
 	}
@@ -86,11 +89,11 @@ Examples:
   gcphcp ops etcd status -n clusters-abc123
   gcphcp ops etcd status -n clusters-abc123 -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEtcdCommand(cmd, "etcd-status", namespace, timeout, func(format output.Format, result map[string]interface{}) error {
+			return runEtcdCommand(cmd, "etcd-status", namespace, timeout, func(w io.Writer, format output.Format, result map[string]interface{}) error {
 				if format == output.FormatJSON {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
-				return output.PrintTable(os.Stdout, parseEtcdOutput(result), etcdStatusColumns)
+				return output.PrintTable(w, parseEtcdOutput(result), etcdStatusColumns)
 			})
 		},
 	}
@@ -118,18 +121,18 @@ Examples:
   gcphcp ops etcd member-list -n clusters-abc123
   gcphcp ops etcd member-list -n clusters-abc123 -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEtcdCommand(cmd, "etcd-member-list", namespace, timeout, func(format output.Format, result map[string]interface{}) error {
+			return runEtcdCommand(cmd, "etcd-member-list", namespace, timeout, func(w io.Writer, format output.Format, result map[string]interface{}) error {
 				if format == output.FormatJSON {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
 				parsed := parseEtcdOutput(result)
 				// member-list returns {header, members}, extract the members array
 				if m, ok := parsed.(map[string]interface{}); ok {
 					if members, ok := m["members"].([]interface{}); ok {
-						return output.PrintTable(os.Stdout, members, etcdMemberColumns)
+						return output.PrintTable(w, members, etcdMemberColumns)
 					}
 				}
-				return output.PrintJSON(os.Stdout, parsed)
+				return output.PrintJSON(w, parsed)
 			})
 		},
 	}
@@ -156,15 +159,15 @@ This is a mutating operation that compacts etcd storage.
 Examples:
   gcphcp ops etcd defrag -n clusters-abc123`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEtcdCommand(cmd, "etcd-defrag", namespace, timeout, func(format output.Format, result map[string]interface{}) error {
+			return runEtcdCommand(cmd, "etcd-defrag", namespace, timeout, func(w io.Writer, format output.Format, result map[string]interface{}) error {
 				if format == output.FormatJSON {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
 				// defrag output is plain text
 				if raw, ok := result["output"].(string); ok {
-					fmt.Fprintln(os.Stdout, raw)
+					fmt.Fprintln(w, raw)
 				} else {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
 				return nil
 			})
@@ -194,15 +197,15 @@ the sidecar auto-defrag mechanism.
 Examples:
   gcphcp ops etcd compact -n clusters-abc123`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEtcdCommand(cmd, "etcd-compact", namespace, timeout, func(format output.Format, result map[string]interface{}) error {
+			return runEtcdCommand(cmd, "etcd-compact", namespace, timeout, func(w io.Writer, format output.Format, result map[string]interface{}) error {
 				if format == output.FormatJSON {
-					return output.PrintJSON(os.Stdout, result)
+					return output.PrintJSON(w, result)
 				}
 				// compact returns "results" (string per member), not "output"
 				results, _ := result["results"].([]interface{})
 				for _, r := range results {
 					if s, ok := r.(string); ok {
-						fmt.Fprintln(os.Stdout, s)
+						fmt.Fprintln(w, s)
 					}
 				}
 				return nil
@@ -218,10 +221,11 @@ Examples:
 }
 
 // runEtcdCommand is the shared workflow execution logic for all etcd subcommands.
-func runEtcdCommand(cmd *cobra.Command, etcdCommand, namespace string, timeout time.Duration, printer func(output.Format, map[string]interface{}) error) error {
+func runEtcdCommand(cmd *cobra.Command, etcdCommand, namespace string, timeout time.Duration, printer func(io.Writer, output.Format, map[string]interface{}) error) error {
 	project, _ := cmd.Flags().GetString("project")
 	region, _ := cmd.Flags().GetString("region")
 	outputFormat, _ := cmd.Flags().GetString("output")
+	outputFile, _ := cmd.Flags().GetString("output-file")
 
 	if project == "" {
 		return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -235,7 +239,7 @@ func runEtcdCommand(cmd *cobra.Command, etcdCommand, namespace string, timeout t
 		"command":   etcdCommand,
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 	defer cancel()
 
 	client, err := workflows.NewClient(ctx, project, region)
@@ -244,23 +248,29 @@ func runEtcdCommand(cmd *cobra.Command, etcdCommand, namespace string, timeout t
 	}
 	defer client.Close()
 
-	if err := checkPAMGate(ctx, client, "etcd-ops", cmd, os.Stderr); err != nil {
+	if err := checkPAMGate(ctx, client, "etcd-ops", cmd, progress.Stderr(cmd)); err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stderr, "Running %s (ns: %s)\n", etcdCommand, namespace)
+	progress.Printf(cmd, "Running %s (ns: %s)\n", etcdCommand, namespace)
 
 	_, result, err := client.Run(ctx, "etcd-ops", data)
 	if err != nil {
-		return fmt.Errorf("executing workflow: %w", err)
+		return workflowRunError(err)
+	}
+
+	out, err := output.ResolveOutputWriter(outputFile)
+	if err != nil {
+		return err
 	}
+	defer out.Close()
 
 	if result.State == "FAILED" {
 		// Some etcd commands (e.g. health) embed valid JSON in the error
 		// when the job exits non-zero. Try to extract and display it.
 		if parsed := parseJSONFromError(result.Error); parsed != nil {
 			format := output.ParseFormat(outputFormat)
-			if err := printer(format, map[string]interface{}{"output": parsed}); err != nil {
+			if err := printer(out, format, map[string]interface{}{"output": parsed}); err != nil {
 				return err
 			}
 			return fmt.Errorf("etcd reported errors (see output above)")
@@ -269,7 +279,7 @@ func runEtcdCommand(cmd *cobra.Command, etcdCommand, namespace string, timeout t
 	}
 
 	format := output.ParseFormat(outputFormat)
-	return printer(format, result.Result)
+	return printer(out, format, result.Result)
 }
 
 // cleanEtcdError extracts human-readable messages from a workflow RuntimeError.
@@ -0,0 +1,155 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newExecCmd() *cobra.Command {
+	var (
+		namespace string
+		container string
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "exec <pod-name> -- <command> [args...]",
+		Short: "Run a command in a pod via Cloud Workflows",
+		Long: `Run a command in a Kubernetes pod using the exec workflow.
+Works like kubectl exec but runs through Cloud Workflows.
+
+Workflows are not interactive, so exec is single-shot: there is no TTY and no
+stdin. The command runs to completion and its stdout and stderr are printed
+once the workflow finishes.
+
+Examples:
+  # List files in a pod
+  gcphcp ops exec my-pod -n default -- ls -la /tmp
+
+  # Run a command in a specific container
+  gcphcp ops exec etcd-0 -n clusters-test -c etcd -- etcdctl endpoint health`,
+
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, _, err := parseExecArgs(args, cmd.ArgsLenAtDash())
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			podName, command, _ := parseExecArgs(args, cmd.ArgsLenAtDash())
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+			if namespace == "" {
+				return fmt.Errorf("--namespace is required for exec")
+			}
+
+			data := map[string]interface{}{
+				"namespace": namespace,
+				"pod":       podName,
+				"command":   command,
+			}
+			if container != "" {
+				data["container"] = container
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			if err := checkPAMGate(ctx, client, "exec", cmd, progress.Stderr(cmd)); err != nil {
+				return err
+			}
+
+			msg := fmt.Sprintf("Running %q in %s", command[0], podName)
+			if container != "" {
+				msg += fmt.Sprintf(" (container: %s)", container)
+			}
+			progress.Printf(cmd, "%s in %s\n", msg, namespace)
+
+			_, result, err := client.Run(ctx, "exec", data)
+			if err != nil {
+				return workflowRunError(err)
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			if result.State == "FAILED" {
+				return wf.WorkflowFailure(out, output.ParseFormat(outputFormat), result.Error)
+			}
+
+			format := output.ParseFormat(outputFormat)
+			if format == output.FormatJSON {
+				return output.PrintJSON(out, result.Result)
+			}
+
+			usage := fmt.Sprintf("gcphcp ops exec %s -n %s -c <container> -- <command>", podName, namespace)
+			if err := containerRequired(progress.Stderr(cmd), result.Result, podName, usage); err != nil {
+				return err
+			}
+
+			if status, _ := result.Result["status"].(string); status == "error" {
+				return fmt.Errorf("exec failed: %v", result.Result["error"])
+			}
+
+			if stdout, ok := result.Result["stdout"].(string); ok && stdout != "" {
+				fmt.Fprint(out, stdout)
+			}
+			if stderr, ok := result.Result["stderr"].(string); ok && stderr != "" {
+				fmt.Fprint(os.Stderr, stderr)
+			}
+
+			if code, ok := result.Result["exit_code"].(float64); ok && code != 0 {
+				return fmt.Errorf("command exited with code %d", int(code))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Container name")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+
+	return cmd
+}
+
+// parseExecArgs splits exec positional args into the pod name and the command
+// that follows "--". dash is the value of cmd.ArgsLenAtDash() (-1 if absent).
+func parseExecArgs(args []string, dash int) (string, []string, error) {
+	if dash < 0 {
+		return "", nil, fmt.Errorf("command must follow \"--\", e.g. exec <pod-name> -- <command>")
+	}
+	if dash != 1 {
+		return "", nil, fmt.Errorf("exactly one pod name is required before \"--\", got %d", dash)
+	}
+	if len(args) == dash {
+		return "", nil, fmt.Errorf("no command given after \"--\"")
+	}
+	return args[0], args[dash:], nil
+}
@@ -0,0 +1,167 @@
+package ops
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseExecArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		dash        int
+		wantPod     string
+		wantCommand []string
+		wantErr     string
+	}{
+		{
+			name:        "command follows --",
+			args:        []string{"my-pod", "ls", "-la", "/tmp"},
+			dash:        1,
+			wantPod:     "my-pod",
+			wantCommand: []string{"ls", "-la", "/tmp"},
+		},
+		{
+			name:    "-- is missing",
+			args:    []string{"my-pod", "ls"},
+			dash:    -1,
+			wantErr: "must follow",
+		},
+		{
+			name:    "no pod precedes --",
+			args:    []string{"ls"},
+			dash:    0,
+			wantErr: "exactly one pod name",
+		},
+		{
+			name:    "two names precede --",
+			args:    []string{"pod-a", "pod-b", "ls"},
+			dash:    2,
+			wantErr: "exactly one pod name",
+		},
+		{
+			name:    "nothing follows --",
+			args:    []string{"my-pod"},
+			dash:    1,
+			wantErr: "no command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, command, err := parseExecArgs(tt.args, tt.dash)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pod != tt.wantPod {
+				t.Errorf("pod = %q, want %q", pod, tt.wantPod)
+			}
+			if !reflect.DeepEqual(command, tt.wantCommand) {
+				t.Errorf("command = %v, want %v", command, tt.wantCommand)
+			}
+		})
+	}
+}
+
+// newTestExecCmd returns the exec command with the root persistent flags it
+// reads registered locally.
+func newTestExecCmd() *cobra.Command {
+	cmd := newExecCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.Flags().String("project", "", "")
+	cmd.Flags().String("region", "", "")
+	cmd.Flags().StringP("output", "o", "", "")
+	return cmd
+}
+
+func TestExecCmd_ArgsAfterDash(t *testing.T) {
+	// Point credentials at a file that does not exist so the command stops
+	// at client creation once its arguments were accepted.
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "flags after -- belong to the command",
+			args:    []string{"my-pod", "--project", "p", "--region", "r", "--", "cat", "-n", "/etc/hosts"},
+			wantErr: "--namespace is required",
+		},
+		{
+			name:    "valid",
+			args:    []string{"my-pod", "-n", "default", "--project", "p", "--region", "r", "--", "cat", "-n", "/etc/hosts"},
+			wantErr: "creating client",
+		},
+		{
+			name:    "flags before the pod",
+			args:    []string{"-n", "default", "my-pod", "--", "ls"},
+			wantErr: "--project is required",
+		},
+		{
+			name:    "missing dash",
+			args:    []string{"my-pod", "-n", "default", "ls"},
+			wantErr: "must follow",
+		},
+		{
+			name:    "nothing after dash",
+			args:    []string{"my-pod", "-n", "default", "--"},
+			wantErr: "no command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newTestExecCmd()
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestContainerRequired(t *testing.T) {
+	t.Run("pod has multiple containers", func(t *testing.T) {
+		var buf bytes.Buffer
+		result := map[string]interface{}{
+			"status":               "container_required",
+			"available_containers": []interface{}{"etcd", "etcd-metrics"},
+		}
+
+		err := containerRequired(&buf, result, "etcd-0", "gcphcp ops exec etcd-0 -n ns -c <container> -- <command>")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		out := buf.String()
+		for _, want := range []string{`pod "etcd-0"`, "  - etcd\n", "  - etcd-metrics\n", "Use: gcphcp ops exec etcd-0"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected %q in output, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("result succeeded", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := containerRequired(&buf, map[string]interface{}{"status": "success"}, "p", "u"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got %q", buf.String())
+		}
+	})
+}
@@ -1,12 +1,12 @@
 package ops
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -41,6 +41,7 @@ Examples:
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -55,7 +56,7 @@ Examples:
 				"new_size":  size,
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -64,20 +65,26 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "expand-volume", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, "expand-volume", cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Expanding PVC %s to %s (ns: %s)\n", pvcName, size, namespace)
+			progress.Printf(cmd, "Expanding PVC %s to %s (ns: %s)\n", pvcName, size, namespace)
 
 			_, result, err := client.Run(ctx, "expand-volume", data)
 			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+				return workflowRunError(err)
 			}
 
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
 			format := output.ParseFormat(outputFormat)
 			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+				return output.PrintJSON(out, result.Result)
 			}
 
 			status := output.GetString(result.Result, "status")
@@ -93,7 +100,7 @@ Examples:
 
 			oldSize := output.GetString(result.Result, "old_size")
 			newSize := output.GetString(result.Result, "new_size")
-			fmt.Fprintf(os.Stdout, "persistentvolumeclaim \"%s\" expanded: %s → %s\n", pvcName, oldSize, newSize)
+			fmt.Fprintf(out, "persistentvolumeclaim \"%s\" expanded: %s → %s\n", pvcName, oldSize, newSize)
 			return nil
 		},
 	}
@@ -103,6 +110,7 @@ Examples:
 	cmd.Flags().StringVar(&size, "size", "", "New storage size (e.g., 20Gi) (required)")
 	_ = cmd.MarkFlagRequired("size")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 
 	return cmd
 }
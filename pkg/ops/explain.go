@@ -0,0 +1,115 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/analysis"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// analyzeWorkflow is the workflow that runs AI analysis on a pod.
+const analyzeWorkflow = "analyze"
+
+// addExplainFlag registers --explain, and --save-analysis for its result, on
+// a command that targets a single pod.
+func addExplainFlag(cmd *cobra.Command, explain *bool) {
+	cmd.Flags().BoolVar(explain, "explain", false, "After the normal output, run the analyze workflow on the pod and print its AI analysis")
+	addSaveAnalysisFlag(cmd)
+}
+
+// addSaveAnalysisFlag registers --save-analysis on a command that runs the
+// analyze workflow.
+func addSaveAnalysisFlag(cmd *cobra.Command) {
+	cmd.Flags().String("save-analysis", "", "Append each analysis (time, pod, namespace, severity, summary) as a JSON line to this file")
+}
+
+// saveAnalysis appends result to the --save-analysis history file, if set.
+func saveAnalysis(cmd *cobra.Command, result map[string]interface{}, namespace, pod string) error {
+	path, _ := cmd.Flags().GetString("save-analysis")
+	if path == "" {
+		return nil
+	}
+	return analysis.AppendRecord(path, analysis.RecordFromResult(result, namespace, pod, time.Now()))
+}
+
+// validateExplain rejects --explain where its text report cannot be shown.
+func validateExplain(explain bool, resourceType, outputFormat string) error {
+	if !explain {
+		return nil
+	}
+	if resourceType != "pods" {
+		return fmt.Errorf("--explain only applies to pods")
+	}
+	if output.ParseFormat(outputFormat) != output.FormatText {
+		return fmt.Errorf("--explain requires text output")
+	}
+	return nil
+}
+
+// runExplain gates and runs the analyze workflow for a pod, analyzing the
+// logs of container when set, and writes the report to w.
+func runExplain(ctx context.Context, client *workflows.Client, cmd *cobra.Command, w io.Writer, namespace, pod, container string) error {
+	workflowName := workflowPrefix(cmd) + analyzeWorkflow
+	progress.With(cmd, "workflow", workflowName)
+	if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
+		return err
+	}
+	progress.Printf(cmd, "Analyzing %s in %s (this may take a moment)...\n", pod, namespace)
+	result, err := explainPod(ctx, client, workflowName, w, progress.Stderr(cmd), namespace, pod, container)
+	if err != nil {
+		return err
+	}
+	return saveAnalysis(cmd, result, namespace, pod)
+}
+
+// explainPod runs the analyze workflow (deployed as workflowName) for a pod,
+// renders the result as text, and returns it. A "container_required" result
+// for a multi-container pod is reported on stderr, as in analyze.
+func explainPod(ctx context.Context, runner workflowRunner, workflowName string, w, stderr io.Writer, namespace, pod, container string) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"namespace": namespace,
+		"pod":       pod,
+	}
+	if container != "" {
+		data["container"] = container
+	}
+	result, err := runAnalysis(ctx, runner, workflowName, data)
+	if err != nil {
+		return nil, err
+	}
+	usage := fmt.Sprintf("gcphcp ops analyze %s -n %s -c <container>", pod, namespace)
+	if err := containerRequired(stderr, result, pod, usage); err != nil {
+		return nil, err
+	}
+	return result, printAnalysis(w, result, namespace, pod)
+}
+
+// runAnalysis runs the analyze workflow with data and returns its result.
+func runAnalysis(ctx context.Context, runner workflowRunner, workflowName string, data map[string]interface{}) (map[string]interface{}, error) {
+	_, result, err := runner.Run(ctx, workflowName, data)
+	if err != nil {
+		return nil, workflowRunError(err)
+	}
+	if result.State == "FAILED" {
+		return nil, fmt.Errorf("analyzing pod %s: %s", data["pod"], result.Error)
+	}
+	if result.Result == nil {
+		return map[string]interface{}{}, nil
+	}
+	return result.Result, nil
+}
+
+// printAnalysis renders an analyze result with output.PrintAnalysis, which
+// also covers results carrying an analysis error or no analysis at all.
+func printAnalysis(w io.Writer, result map[string]interface{}, namespace, pod string) error {
+	if output.GetString(result, "name") == "" {
+		result["name"] = pod
+	}
+	return output.PrintAnalysis(w, result, namespace)
+}
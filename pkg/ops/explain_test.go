@@ -0,0 +1,153 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func TestExplainPod(t *testing.T) {
+	t.Run("analysis is structured", func(t *testing.T) {
+		var gotArgs map[string]interface{}
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"gcphcp-analyze": func(args map[string]interface{}) *workflows.ExecutionResult {
+				gotArgs = args
+				return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+					"analysis": map[string]interface{}{
+						"pod_phase":          "Running",
+						"events_count":       float64(4),
+						"log_lines_analyzed": float64(200),
+						"ai_analysis": "```json\n" + `{"summary":"etcd is slow.","severity":"HIGH",` +
+							`"errors_detected":["apply request took too long"],"root_cause":"Disk latency.",` +
+							`"recommended_actions":["Move etcd to SSD"]}` + "\n```",
+					},
+				}}
+			},
+		}}
+
+		var buf bytes.Buffer
+		if _, err := explainPod(context.Background(), runner, "gcphcp-analyze", &buf, &bytes.Buffer{}, "clusters-abc", "etcd-0", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want := map[string]interface{}{"namespace": "clusters-abc", "pod": "etcd-0"}; !reflect.DeepEqual(gotArgs, want) {
+			t.Errorf("args = %v, want %v", gotArgs, want)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			"POD ANALYSIS", "Pod:       etcd-0", "Namespace: clusters-abc", "Phase:     Running",
+			"AI ANALYSIS", "Severity:  HIGH", "Summary", "etcd is slow.", "Errors Detected",
+			"apply request took too long", "Root Cause Analysis", "Disk latency.", "Recommended Actions", "Move etcd to SSD",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("analysis reports an error", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"analyze": func(map[string]interface{}) *workflows.ExecutionResult {
+				return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+					"analysis": map[string]interface{}{"error": "Vertex AI quota exceeded"},
+				}}
+			},
+		}}
+
+		var buf bytes.Buffer
+		if _, err := explainPod(context.Background(), runner, "analyze", &buf, &bytes.Buffer{}, "ns", "etcd-0", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "Vertex AI quota exceeded") {
+			t.Errorf("expected analysis error in output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("analysis is empty", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"analyze": func(map[string]interface{}) *workflows.ExecutionResult {
+				return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{}}
+			},
+		}}
+
+		var buf bytes.Buffer
+		if _, err := explainPod(context.Background(), runner, "analyze", &buf, &bytes.Buffer{}, "ns", "etcd-0", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "No analysis available.") {
+			t.Errorf("expected empty-analysis message in output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("workflow fails", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"analyze": func(map[string]interface{}) *workflows.ExecutionResult {
+				return &workflows.ExecutionResult{State: "FAILED", Error: "pod not found"}
+			},
+		}}
+
+		_, err := explainPod(context.Background(), runner, "analyze", &bytes.Buffer{}, &bytes.Buffer{}, "ns", "etcd-0", "")
+		if err == nil || !strings.Contains(err.Error(), "pod not found") {
+			t.Errorf("err = %v, want it to mention pod not found", err)
+		}
+	})
+}
+
+func TestExplainPod_Container(t *testing.T) {
+	t.Run("forwards the container", func(t *testing.T) {
+		var gotArgs map[string]interface{}
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"analyze": func(args map[string]interface{}) *workflows.ExecutionResult {
+				gotArgs = args
+				return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{}}
+			},
+		}}
+		if _, err := explainPod(context.Background(), runner, "analyze", &bytes.Buffer{}, &bytes.Buffer{}, "ns", "etcd-0", "etcd"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotArgs["container"] != "etcd" {
+			t.Errorf("args = %v, want the container", gotArgs)
+		}
+	})
+
+	t.Run("container required", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"analyze": func(map[string]interface{}) *workflows.ExecutionResult {
+				return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+					"status":               "container_required",
+					"available_containers": []interface{}{"etcd", "metrics"},
+				}}
+			},
+		}}
+		var out, stderr bytes.Buffer
+		_, err := explainPod(context.Background(), runner, "analyze", &out, &stderr, "ns", "etcd-0", "")
+		if err == nil {
+			t.Fatal("expected an error for a multi-container pod")
+		}
+		if !strings.Contains(stderr.String(), "metrics") || !strings.Contains(stderr.String(), "-c <container>") {
+			t.Errorf("stderr should list the containers and usage, got %q", stderr.String())
+		}
+		if out.Len() != 0 {
+			t.Errorf("no analysis should be printed, got %q", out.String())
+		}
+	})
+}
+
+func TestValidateExplain(t *testing.T) {
+	if err := validateExplain(false, "deployments", "json"); err != nil {
+		t.Errorf("unexpected error without --explain: %v", err)
+	}
+	if err := validateExplain(true, "pods", "text"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateExplain(true, "deployments", "text"); err == nil {
+		t.Error("expected error for a non-pod resource")
+	}
+	if err := validateExplain(true, "pods", "json"); err == nil {
+		t.Error("expected error for JSON output")
+	}
+}
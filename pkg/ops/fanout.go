@@ -0,0 +1,153 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// defaultMaxConcurrency bounds how many get executions --all-namespaces
+// runs at once.
+const defaultMaxConcurrency = 5
+
+// clusterScopedTypes are resource types that have no namespace, so
+// --all-namespaces fetches them with a single execution.
+var clusterScopedTypes = map[string]bool{
+	"nodes":             true,
+	"namespaces":        true,
+	"persistentvolumes": true,
+	"storageclasses":    true,
+}
+
+// namespaceFailure records a namespace whose get execution failed during a
+// fan-out. The other namespaces' items are still returned.
+type namespaceFailure struct {
+	namespace string
+	err       error
+}
+
+// listNamespaces returns the names of all namespaces, sorted, using the get
+// workflow deployed as workflowName.
+func listNamespaces(ctx context.Context, runner workflowRunner, workflowName string) ([]string, error) {
+	_, result, err := runner.Run(ctx, workflowName, map[string]interface{}{"resource_type": "namespaces"})
+	if err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+	if result.State == "FAILED" {
+		return nil, fmt.Errorf("listing namespaces: %s", result.Error)
+	}
+
+	items, _ := result.Result["items"].([]interface{})
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if name := output.GetString(output.AsMap(output.AsMap(item)["metadata"]), "name"); name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// namespacesWithPrefix returns the namespaces that start with prefix.
+func namespacesWithPrefix(namespaces []string, prefix string) []string {
+	var matches []string
+	for _, ns := range namespaces {
+		if strings.HasPrefix(ns, prefix) {
+			matches = append(matches, ns)
+		}
+	}
+	return matches
+}
+
+// resolveNamespacePrefix returns the single namespace starting with prefix,
+// such as clusters-test-pd-test-pd for "clusters-test-pd", preferring an
+// exact match. It fails when no namespace or several match.
+func resolveNamespacePrefix(ctx context.Context, runner workflowRunner, workflowName, prefix string) (string, error) {
+	namespaces, err := listNamespaces(ctx, runner, workflowName)
+	if err != nil {
+		return "", err
+	}
+	matches := namespacesWithPrefix(namespaces, prefix)
+	for _, ns := range matches {
+		if ns == prefix {
+			return ns, nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no namespace starts with %q", prefix)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("--namespace-prefix %q matches %d namespaces (%s); use a longer prefix or --namespace", prefix, len(matches), strings.Join(matches, ", "))
+}
+
+// fetchAcrossNamespaces runs the get workflow, deployed as workflowName, once
+// per namespace with at most maxConcurrency executions in flight, and merges
+// the returned items sorted by namespace and name. A namespace that fails is reported in failures
+// rather than aborting the others.
+func fetchAcrossNamespaces(ctx context.Context, runner workflowRunner, workflowName string, data map[string]interface{}, namespaces []string, maxConcurrency int) (*workflows.ExecutionResult, []namespaceFailure) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	type nsResult struct {
+		items []interface{}
+		err   error
+	}
+	results := make([]nsResult, len(namespaces))
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, ns := range namespaces {
+		args := make(map[string]interface{}, len(data)+1)
+		for k, v := range data {
+			args[k] = v
+		}
+		args["namespace"] = ns
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, result, err := runner.Run(ctx, workflowName, args)
+			switch {
+			case err != nil:
+				results[i].err = err
+			case result.State == "FAILED":
+				results[i].err = fmt.Errorf("%s", result.Error)
+			default:
+				results[i].items, _ = result.Result["items"].([]interface{})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		items    []interface{}
+		failures []namespaceFailure
+	)
+	for i, r := range results {
+		if r.err != nil {
+			failures = append(failures, namespaceFailure{namespace: namespaces[i], err: r.err})
+			continue
+		}
+		items = append(items, r.items...)
+	}
+	output.SortItems(items)
+	if items == nil {
+		items = []interface{}{}
+	}
+
+	return &workflows.ExecutionResult{
+		State:  "SUCCEEDED",
+		Result: map[string]interface{}{"items": items},
+	}, failures
+}
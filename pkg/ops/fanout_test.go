@@ -0,0 +1,211 @@
+package ops
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func nsPod(namespace, name string) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"namespace": namespace, "name": name},
+	}
+}
+
+func itemNames(items []interface{}) []string {
+	var names []string
+	for _, item := range items {
+		meta := item.(map[string]interface{})["metadata"].(map[string]interface{})
+		names = append(names, meta["namespace"].(string)+"/"+meta["name"].(string))
+	}
+	return names
+}
+
+func TestFetchAcrossNamespaces(t *testing.T) {
+	podsByNS := map[string][]interface{}{
+		"kube-system": {nsPod("kube-system", "dns-1"), nsPod("kube-system", "dns-0")},
+		"hypershift":  {nsPod("hypershift", "operator-0")},
+		"empty":       nil,
+	}
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(args map[string]interface{}) *workflows.ExecutionResult {
+			items := podsByNS[args["namespace"].(string)]
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"items": items}}
+		},
+	}}
+
+	data := map[string]interface{}{"resource_type": "pods"}
+	result, failures := fetchAcrossNamespaces(context.Background(), runner, "get", data, []string{"kube-system", "hypershift", "empty"}, 2)
+
+	if len(failures) != 0 {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+	got := itemNames(result.Result["items"].([]interface{}))
+	want := []string{"hypershift/operator-0", "kube-system/dns-0", "kube-system/dns-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	if len(runner.calls) != 3 {
+		t.Errorf("expected one execution per namespace, got %d", len(runner.calls))
+	}
+	if _, ok := data["namespace"]; ok {
+		t.Error("fan-out should not modify the caller's arguments")
+	}
+}
+
+func TestFetchAcrossNamespaces_PartialFailure(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(args map[string]interface{}) *workflows.ExecutionResult {
+			ns := args["namespace"].(string)
+			if ns == "locked" {
+				return &workflows.ExecutionResult{State: "FAILED", Error: "forbidden"}
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{nsPod(ns, "app-0")},
+			}}
+		},
+	}}
+
+	result, failures := fetchAcrossNamespaces(context.Background(), runner, "get", map[string]interface{}{}, []string{"a", "locked", "b"}, 5)
+
+	if len(failures) != 1 || failures[0].namespace != "locked" || failures[0].err.Error() != "forbidden" {
+		t.Fatalf("failures = %v, want one for namespace locked", failures)
+	}
+	got := itemNames(result.Result["items"].([]interface{}))
+	if want := []string{"a/app-0", "b/app-0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+}
+
+// concurrencyRunner tracks the peak number of concurrent executions.
+type concurrencyRunner struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (r *concurrencyRunner) Run(_ context.Context, _ string, _ map[string]interface{}) (string, *workflows.ExecutionResult, error) {
+	r.mu.Lock()
+	r.running++
+	if r.running > r.peak {
+		r.peak = r.running
+	}
+	r.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	r.mu.Lock()
+	r.running--
+	r.mu.Unlock()
+	return "", &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{}}, nil
+}
+
+func TestFetchAcrossNamespaces_MaxConcurrency(t *testing.T) {
+	runner := &concurrencyRunner{}
+	namespaces := []string{"a", "b", "c", "d", "e", "f", "g"}
+
+	fetchAcrossNamespaces(context.Background(), runner, "get", map[string]interface{}{}, namespaces, 3)
+
+	if runner.peak > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", runner.peak)
+	}
+}
+
+func TestListNamespaces(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(args map[string]interface{}) *workflows.ExecutionResult {
+			if args["resource_type"] != "namespaces" {
+				t.Errorf("resource_type = %v, want namespaces", args["resource_type"])
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"metadata": map[string]interface{}{"name": "kube-system"}},
+					map[string]interface{}{"metadata": map[string]interface{}{"name": "default"}},
+				},
+			}}
+		},
+	}}
+
+	got, err := listNamespaces(context.Background(), runner, "get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"default", "kube-system"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestListNamespaces_WorkflowPrefix(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"gcphcp-get": func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"items": []interface{}{}}}
+		},
+	}}
+
+	if _, err := listNamespaces(context.Background(), runner, "gcphcp-get"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"gcphcp-get"}; !reflect.DeepEqual(runner.calls, want) {
+		t.Errorf("calls = %v, want %v", runner.calls, want)
+	}
+}
+
+func TestResolveNamespacePrefix(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			var items []interface{}
+			for _, ns := range []string{"clusters", "clusters-test-pd-test-pd", "clusters-abc", "clusters-abc-2", "hypershift"} {
+				items = append(items, map[string]interface{}{"metadata": map[string]interface{}{"name": ns}})
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"items": items}}
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		prefix  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "one namespace matches",
+			prefix: "clusters-test-pd",
+			want:   "clusters-test-pd-test-pd",
+		},
+		{
+			name:   "prefix is a namespace",
+			prefix: "clusters",
+			want:   "clusters",
+		},
+		{
+			name:    "several namespaces match",
+			prefix:  "clusters-a",
+			wantErr: "matches 2 namespaces (clusters-abc, clusters-abc-2)",
+		},
+		{
+			name:    "nothing matches",
+			prefix:  "openshift-",
+			wantErr: `no namespace starts with "openshift-"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveNamespacePrefix(context.Background(), runner, "get", tt.prefix)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q, %v", tt.wantErr, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("resolveNamespacePrefix(%q) = %q, %v; want %q", tt.prefix, got, err, tt.want)
+			}
+		})
+	}
+}
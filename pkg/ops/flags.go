@@ -0,0 +1,127 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+// GlobalFlags holds the persistent flags shared by the gcphcp root command
+// and the standalone gcphcp-ops binary, so both entry points register and
+// apply them the same way.
+type GlobalFlags struct {
+	Project        string
+	Region         string
+	Output         string
+	ConfigPath     string
+	ContextName    string
+	WorkflowPrefix string
+	Verbose        int
+	LogFormat      string
+	Impersonate    string
+	QuotaProject   string
+	Compact        bool
+	APIEndpoint    string
+	CallbacksBase  string
+}
+
+// AddFlags registers the flags on root's persistent flag set, with
+// defaults taken from the environment.
+func (g *GlobalFlags) AddFlags(root *cobra.Command) {
+	flags := root.PersistentFlags()
+	flags.StringVar(&g.Project, "project", os.Getenv("GCPHCP_PROJECT"), "GCP project ID (env: GCPHCP_PROJECT)")
+	flags.StringVar(&g.Region, "region", os.Getenv("GCPHCP_REGION"), "GCP region (env: GCPHCP_REGION)")
+	flags.StringVarP(&g.Output, "output", "o", "text", "Output format: text, json, jsonl, yaml, name")
+	flags.StringVar(&g.ConfigPath, "config", os.Getenv(config.EnvConfigPath), "Config file path (env: GCPHCP_CONFIG, default: $XDG_CONFIG_HOME/gcphcp/config.yaml)")
+	flags.StringVar(&g.ContextName, "context", "", "Config context to use (default: current-context from the config file)")
+	flags.StringVar(&g.WorkflowPrefix, "workflow-prefix", os.Getenv("GCPHCP_WORKFLOW_PREFIX"), "Prefix for the workflow names used by get, logs and describe (env: GCPHCP_WORKFLOW_PREFIX)")
+	flags.CountVarP(&g.Verbose, "verbose", "v", "Log workflow requests to stderr (-v: args, -vv: also raw results)")
+	flags.StringVar(&g.LogFormat, "log-format", "text", "Format of stderr messages: text, or json for one structured log record per line")
+	flags.BoolP("quiet", "q", false, "Suppress informational progress output on stderr (errors and warnings are still printed)")
+	flags.BoolVar(&g.Compact, "compact", false, "Print JSON output on one line without indentation")
+	flags.Bool("no-truncate", false, "Print event, container and condition messages in full instead of truncating them")
+	flags.StringVar(&g.Impersonate, "impersonate-service-account", os.Getenv("GCPHCP_IMPERSONATE_SERVICE_ACCOUNT"), "Service account email to impersonate for workflow API calls (env: GCPHCP_IMPERSONATE_SERVICE_ACCOUNT)")
+	flags.StringVar(&g.QuotaProject, "quota-project", os.Getenv("GCPHCP_QUOTA_PROJECT"), "Project billed for workflow API quota, when it differs from --project or the credentials' default (env: GCPHCP_QUOTA_PROJECT)")
+	flags.StringVar(&g.APIEndpoint, "api-endpoint", os.Getenv("GCPHCP_API_ENDPOINT"), "Workflows API endpoint (host:port) to use instead of Google's, e.g. a test fake or VPC-SC proxy (env: GCPHCP_API_ENDPOINT)")
+	flags.StringVar(&g.CallbacksBase, "callbacks-base", os.Getenv("GCPHCP_CALLBACKS_BASE"), "REST base URL for execution callbacks used with --api-endpoint, e.g. http://127.0.0.1:8080/v1 (env: GCPHCP_CALLBACKS_BASE)")
+}
+
+// ResolveConfig loads the config file and selected context, and fills in
+// project, region and workflow prefix from it when neither a flag nor the
+// environment set them.
+func (g *GlobalFlags) ResolveConfig() (*config.Config, error) {
+	cfg, err := config.Load(g.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err = cfg.ForContext(g.ContextName)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Project == "" && cfg.Project != "" {
+		g.Project = cfg.Project
+	}
+	if g.Region == "" && cfg.Region != "" {
+		g.Region = cfg.Region
+	}
+	if g.WorkflowPrefix == "" && cfg.WorkflowPrefix != "" {
+		g.WorkflowPrefix = cfg.WorkflowPrefix
+	}
+	return cfg, nil
+}
+
+// SetupPersistent is the persistent pre-run shared by both entry points:
+// it resolves the config, normalizes --region when checkRegion is set,
+// applies the configured output format, and stores the logging, credential
+// and endpoint settings in cmd's context for the workflows client.
+func (g *GlobalFlags) SetupPersistent(cmd *cobra.Command, checkRegion bool) error {
+	cfg, err := g.ResolveConfig()
+	if err != nil {
+		return err
+	}
+
+	if g.Region != "" && checkRegion {
+		if g.Region, err = config.NormalizeRegion(g.Region); err != nil {
+			return fmt.Errorf("invalid region: %w", err)
+		}
+	}
+	if !cmd.Flags().Changed("output") && cfg.Output != "" {
+		g.Output = cfg.Output
+	}
+
+	if err := progress.SetLogFormat(cmd, g.LogFormat); err != nil {
+		return err
+	}
+	if g.Verbose > 0 {
+		cmd.SetContext(workflows.ContextWithLogger(cmd.Context(), workflows.NewLogger(progress.DebugWriter(cmd), g.Verbose)))
+	}
+	cmd.SetContext(workflows.ContextWithImpersonation(cmd.Context(), g.Impersonate))
+	cmd.SetContext(workflows.ContextWithQuotaProject(cmd.Context(), g.QuotaProject))
+	cmd.SetContext(workflows.ContextWithAPIEndpoint(cmd.Context(), g.APIEndpoint))
+	cmd.SetContext(workflows.ContextWithCallbacksBase(cmd.Context(), g.CallbacksBase))
+	output.SetCompactJSON(g.Compact)
+	return nil
+}
+
+// ExitCode returns the process exit status for a command error: the code
+// of an ExitError, ExitWaitTimeout when a wait stopped at its deadline, and
+// 1 otherwise.
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	var timeoutErr *workflows.WaitTimeoutError
+	if errors.As(err, &timeoutErr) {
+		return ExitWaitTimeout
+	}
+	return 1
+}
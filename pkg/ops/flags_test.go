@@ -0,0 +1,73 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "exit error", err: &ExitError{Code: ExitDegraded, Msg: "2 pods not ready"}, want: ExitDegraded},
+		{name: "wrapped wait timeout", err: fmt.Errorf("waiting: %w", &workflows.WaitTimeoutError{State: "ACTIVE"}), want: ExitWaitTimeout},
+		{name: "workflow failure", err: workflows.NewExecutionFailedError("boom"), want: 1},
+		{name: "other", err: errors.New("--project is required"), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalFlags_SetupPersistent(t *testing.T) {
+	t.Setenv("GCPHCP_REGION", "")
+
+	tests := []struct {
+		name        string
+		checkRegion bool
+		region      string
+		wantRegion  string
+		wantErr     string
+	}{
+		{name: "region checked", checkRegion: true, region: "US-Central1", wantRegion: "us-central1"},
+		{name: "malformed region", checkRegion: true, region: "us-east-1", wantErr: "did you mean us-east1?"},
+		{name: "region not checked", region: "us-east-1", wantRegion: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g GlobalFlags
+			root := &cobra.Command{Use: "root", Run: func(*cobra.Command, []string) {}}
+			g.AddFlags(root)
+			g.ConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+			g.Region = tt.region
+
+			err := g.SetupPersistent(root, tt.checkRegion)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if g.Region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", g.Region, tt.wantRegion)
+			}
+		})
+	}
+}
@@ -1,12 +1,19 @@
 package ops
 
 import (
+	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/selector"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -54,12 +61,32 @@ func newGetCmd() *cobra.Command {
 	var (
 		namespace     string
 		labelSelector string
+		labelColumns  []string
+		showKind      bool
+		allNamespaces bool
+		nsPrefix      string
+		maxConc       int
 		analyze       bool
 		timeout       time.Duration
+		outputTmpl    string
+		limit         int
+		failNotReady  bool
+		continueToken string
+		watch         bool
+		watchInterval time.Duration
+		onlyChanges   bool
+		field         string
+		count         bool
+		snapshotDir   string
+		keep          int
+		yamlDocuments bool
+		chunked       bool
+		asTable       bool
+		watchTimeout  time.Duration
 	)
 
 	cmd := &cobra.Command{
-		Use:   "get <resource-type> [resource-name]",
+		Use:   "get <resource-type> [resource-name] | -",
 		Short: "Get Kubernetes resources via Cloud Workflows",
 		Long: `Get Kubernetes resources from a GKE cluster using the get workflow.
 Works like kubectl get but runs through Cloud Workflows.
@@ -81,32 +108,195 @@ Examples:
   gcphcp ops get hc -n clusters
   gcphcp ops get deploy -n clusters-test-pd-test-pd
 
-  # Filter by label selector
+  # One JSON object per line, for jq -c or log pipelines
+  gcphcp ops get pods -n hypershift -o jsonl
+
+  # Columns chosen by the API server, including a CRD's printer columns
+  gcphcp ops get hostedclusters -n clusters --as-table
+
+  # YAML: a single List document, or one "---"-separated document per item
+  gcphcp ops get pods -n hypershift -o yaml
+  gcphcp ops get pods -n hypershift -o yaml --yaml-documents
+
+  # Custom output with a Go template (helpers: age, default)
+  gcphcp ops get pods -n hypershift -o go-template='{{.metadata.name}} {{.status.phase}}'
+
+  # kubectl-style JSONPath
+  gcphcp ops get pods -n hypershift -o jsonpath='{.items[*].metadata.name}'
+
+  # Pick table columns by path, like kubectl -o custom-columns
+  gcphcp ops get pods -n hypershift -o custom-columns=NAME:.metadata.name,NODE:.spec.nodeName
+
+  # Just one value of one resource, for $(...) capture
+  POD_IP=$(gcphcp ops get pods etcd-0 -n hypershift --field .status.podIP)
+
+  # Pods in every namespace starting with a hosted cluster's prefix
+  gcphcp ops get pods -A --namespace-prefix clusters-test-pd
+
+  # Several resource types at once, one section per type
+  gcphcp ops get pods,svc,deploy -n hypershift
+
+  # Read "type name" or "type/name" lines from stdin, one section per line
+  printf 'pods etcd-0\nsvc/etcd-client\n' | gcphcp ops get - -n clusters-abc123
+
+  # Filter by label selector (equality, set-based, and existence clauses)
   gcphcp ops get pods -n hypershift -l app=nginx
+  gcphcp ops get pods -n hypershift -l 'env in (prod,staging),tier notin (cache),!canary'
+
+  # Pods in every namespace, fetched in parallel
+  gcphcp ops get pods -A --max-concurrency 10
+
+  # Show label values as columns
+  gcphcp ops get pods -n hypershift -L app,app.kubernetes.io/component
+
+  # Page through a large namespace
+  gcphcp ops get pods -n clusters-abc123 --limit 50
+  gcphcp ops get pods -n clusters-abc123 --limit 50 --continue <token>
 
   # List cluster-scoped resources
   gcphcp ops get nodes
-  gcphcp ops get namespaces`,
+  gcphcp ops get namespaces
+
+  # Re-use results for 30s while iterating on a runbook
+  gcphcp ops get nodes --cache-ttl 30s
+
+  # Re-poll every 5s, printing only when pods are added, removed, or change
+  gcphcp ops get pods -n hypershift --watch-only-changes
+
+  # Stream each poll as one JSON line with an _observed_at timestamp
+  gcphcp ops get pods -n hypershift --watch -o jsonl --chunked-output
+
+  # Just the number of matching items, for scripted assertions
+  [ "$(gcphcp ops get pods -n hypershift -l app=etcd --count)" -eq 3 ]
+
+  # Capture pods every minute during an incident, keeping the last 10 files
+  # (pods-20250101T120000Z.json, ...)
+  gcphcp ops get pods -n hypershift -o json --snapshot-dir ./incident --keep 10
 
-		Args: cobra.RangeArgs(1, 2),
+  # Fail a script when any pod is unhealthy
+  gcphcp ops get pods -n hypershift --fail-on-not-ready
+
+  # In CI, wait up to 10 minutes for every pod to become ready
+  gcphcp ops get pods -n hypershift --watch --fail-on-not-ready --watch-timeout 10m
+
+Exit codes:
+  0  success (with --fail-on-not-ready: every pod is Ready or Completed)
+  1  error (invalid flags, workflow failure, ...)
+  2  --timeout reached while the execution is still running, or --watch
+     with --fail-on-not-ready ended before every pod was ready
+  3  --fail-on-not-ready and at least one pod is not Ready or Completed`,
+
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeResourceType(true),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resourceType := args[0]
-			if expanded, ok := resourceTypeExpand[resourceType]; ok {
-				resourceType = expanded
+			var (
+				targets      []getTarget
+				resourceName string
+				fromStdin    = args[0] == "-"
+			)
+			if fromStdin {
+				if len(args) > 1 {
+					return fmt.Errorf("a resource name cannot be combined with reading targets from stdin (-)")
+				}
+				var err error
+				if targets, err = parseGetTargets(cmd.InOrStdin()); err != nil {
+					return err
+				}
+			} else {
+				resourceTypes, err := parseResourceTypes(args[0])
+				if err != nil {
+					return err
+				}
+				if len(args) > 1 {
+					resourceName = args[1]
+				}
+				if len(resourceTypes) > 1 && resourceName != "" {
+					return fmt.Errorf("a resource name cannot be combined with multiple resource types")
+				}
+				for _, rt := range resourceTypes {
+					targets = append(targets, getTarget{resourceType: rt, name: resourceName})
+				}
 			}
-
-			var resourceName string
-			if len(args) > 1 {
-				resourceName = args[1]
+			var resourceTypes []string
+			for _, t := range targets {
+				if !slices.Contains(resourceTypes, t.resourceType) {
+					resourceTypes = append(resourceTypes, t.resourceType)
+				}
 			}
+			resourceType := resourceTypes[0]
+			multi := len(targets) > 1 || fromStdin
 
+			if multi && continueToken != "" {
+				return fmt.Errorf("--continue cannot be used with multiple resource types")
+			}
+			if failNotReady && !slices.Contains(resourceTypes, "pods") {
+				return fmt.Errorf("--fail-on-not-ready only applies to pods")
+			}
 			if analyze && (resourceType != "pods" || resourceName == "") {
 				return fmt.Errorf("--analyze requires a specific pod name (e.g. gcphcp ops get pods my-pod -n ns --analyze)")
 			}
+			if field != "" {
+				if multi || allNamespaces || analyze {
+					return fmt.Errorf("--field needs a single resource; it cannot be used with multiple resource types, --all-namespaces or --analyze")
+				}
+				if err := output.ValidateFieldPath(field); err != nil {
+					return fmt.Errorf("invalid --field: %w", err)
+				}
+			}
+			if count && (field != "" || analyze || failNotReady) {
+				return fmt.Errorf("--count cannot be used with --field, --analyze or --fail-on-not-ready")
+			}
+			if asTable {
+				// A server-side Table has rows, not items, which these read.
+				if count || failNotReady || field != "" {
+					return fmt.Errorf("--as-table cannot be used with --count, --fail-on-not-ready or --field")
+				}
+				if outputFormat, _ := cmd.Flags().GetString("output"); output.ParseFormat(outputFormat) == output.FormatName {
+					return fmt.Errorf("--as-table cannot be used with -o name")
+				}
+			}
+			if onlyChanges {
+				watch = true
+			}
+			if watch && count {
+				return fmt.Errorf("--watch cannot be used with --count")
+			}
+			if snapshotDir != "" {
+				if watch {
+					return fmt.Errorf("--watch cannot be used with --snapshot-dir")
+				}
+				if outputFile, _ := cmd.Flags().GetString("output-file"); outputFile != "" {
+					return fmt.Errorf("--snapshot-dir and --output-file are mutually exclusive")
+				}
+				if keep < 0 {
+					return fmt.Errorf("--keep must be 0 (keep all) or more, got %d", keep)
+				}
+			}
+			if watchTimeout != 0 && !watch {
+				return fmt.Errorf("--watch-timeout requires --watch")
+			}
+			if chunked {
+				if !watch {
+					return fmt.Errorf("--chunked-output requires --watch")
+				}
+				outputFormat, _ := cmd.Flags().GetString("output")
+				if format := output.ParseFormat(outputFormat); format != output.FormatJSON && format != output.FormatJSONL {
+					return fmt.Errorf("--chunked-output requires -o json or -o jsonl")
+				}
+			}
+			if watch {
+				if err := validateWatch(multi, allNamespaces, analyze, failNotReady, continueToken, watchInterval, watchTimeout); err != nil {
+					return err
+				}
+			}
 
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
+			workflowName := workflowPrefix(cmd) + "get"
+			progress.With(cmd, "workflow", workflowName)
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+			noTruncate, _ := cmd.Flags().GetBool("no-truncate")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -115,23 +305,92 @@ Examples:
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
 
-			data := map[string]interface{}{
-				"resource_type": resourceType,
+			if labelSelector != "" {
+				normalized, err := selector.Parse(labelSelector)
+				if err != nil {
+					return err
+				}
+				labelSelector = normalized
 			}
-			if namespace != "" {
-				data["namespace"] = namespace
+
+			if tmpl, ok := output.TemplateFromFormat(outputFormat); ok {
+				outputTmpl = tmpl
 			}
-			if resourceName != "" {
-				data["name"] = resourceName
+			if outputTmpl != "" {
+				if _, err := output.ParseTemplate(outputTmpl); err != nil {
+					return err
+				}
 			}
-			if labelSelector != "" {
-				data["label_selector"] = labelSelector
+			jsonPath, _ := output.JSONPathFromFormat(outputFormat)
+			if jsonPath != "" {
+				if _, err := output.ParseJSONPath(jsonPath); err != nil {
+					return err
+				}
 			}
-			if analyze {
-				data["analyze"] = true
+			customColumns, _ := output.CustomColumnsFromFormat(outputFormat)
+			if customColumns != "" {
+				if err := output.ValidateCustomColumns(customColumns); err != nil {
+					return err
+				}
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			if allNamespaces {
+				if namespace != "" {
+					return fmt.Errorf("--all-namespaces and --namespace are mutually exclusive")
+				}
+				if continueToken != "" {
+					return fmt.Errorf("--continue cannot be used with --all-namespaces")
+				}
+			}
+			if nsPrefix != "" && namespace != "" {
+				return fmt.Errorf("--namespace-prefix and --namespace are mutually exclusive")
+			}
+
+			getData := func(target getTarget) map[string]interface{} {
+				data := map[string]interface{}{
+					"resource_type": target.resourceType,
+				}
+				if namespace != "" {
+					data["namespace"] = namespace
+				}
+				if nsPrefix != "" && !allNamespaces && !clusterScopedTypes[target.resourceType] {
+					data["namespace_prefix"] = nsPrefix
+				}
+				if target.name != "" {
+					data["name"] = target.name
+				}
+				if labelSelector != "" {
+					data["label_selector"] = labelSelector
+				}
+				if analyze {
+					data["analyze"] = true
+				}
+				if limit > 0 {
+					data["limit"] = limit
+				}
+				if continueToken != "" {
+					data["continue"] = continueToken
+				}
+				if asTable {
+					data["as_table"] = true
+				}
+				return data
+			}
+
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				for _, target := range targets {
+					if err := printDryRun(cmd.OutOrStdout(), workflowName, getData(target)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			ctxTimeout := timeout
+			if watch {
+				ctxTimeout = maxWatchDuration
+			}
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), ctxTimeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -140,52 +399,529 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "get", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			if analyze {
-				fmt.Fprintf(os.Stderr, "Analyzing %s/%s in %s (this may take a moment)...\n", resourceType, resourceName, namespace)
-			} else {
-				fmt.Fprintf(os.Stderr, "Getting %s", resourceType)
-				if resourceName != "" {
-					fmt.Fprintf(os.Stderr, " %s", resourceName)
+			if watch {
+				out, err := output.ResolveOutputWriter(outputFile)
+				if err != nil {
+					return err
 				}
-				if namespace != "" {
-					fmt.Fprintf(os.Stderr, " (ns: %s)", namespace)
+				defer out.Close()
+
+				opts := getRenderOptions{
+					format:        output.ParseFormat(outputFormat),
+					outputTmpl:    outputTmpl,
+					jsonPath:      jsonPath,
+					columns:       customColumns,
+					field:         field,
+					namespace:     namespace,
+					labelColumns:  labelColumns,
+					showKind:      showKind,
+					yamlDocuments: yamlDocuments,
+					noTruncate:    noTruncate,
 				}
-				if labelSelector != "" {
-					fmt.Fprintf(os.Stderr, " (selector: %s)", labelSelector)
+				var watchUntil func(map[string]interface{}) error
+				if failNotReady {
+					watchUntil = podHealthError
+				}
+				var watchFilter func(map[string]interface{})
+				if nsPrefix != "" && !clusterScopedTypes[targets[0].resourceType] {
+					watchFilter = func(result map[string]interface{}) { filterNamespacePrefix(result, nsPrefix) }
 				}
-				fmt.Fprintln(os.Stderr)
+				progress.Printf(cmd, "Watching %s every %s (Ctrl+C to stop)\n", resourceType, watchInterval)
+				return watchGet(ctx, client, workflowName, getData(targets[0]), out, progress.Writer(cmd), watchOptions{
+					interval:    watchInterval,
+					pollTimeout: timeout,
+					onlyChanges: onlyChanges,
+					chunked:     chunked,
+					budget:      watchTimeout,
+					until:       watchUntil,
+					filter:      watchFilter,
+					format:      opts.format,
+				}, func(w io.Writer, result map[string]interface{}) error {
+					return printGetResult(w, result, resourceType, opts)
+				})
 			}
 
-			_, result, err := client.Run(ctx, "get", data)
-			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+			resultCache := resultCacheFromFlags(cmd)
+
+			var (
+				sections   []getSection
+				namespaces []string
+			)
+			for _, target := range targets {
+				rt := target.resourceType
+				data := getData(target)
+
+				if analyze {
+					progress.Printf(cmd, "Analyzing %s/%s in %s (this may take a moment)...\n", rt, target.name, namespace)
+				} else {
+					msg := "Getting " + rt
+					if target.name != "" {
+						msg += " " + target.name
+					}
+					if namespace != "" {
+						msg += fmt.Sprintf(" (ns: %s)", namespace)
+					}
+					if labelSelector != "" {
+						msg += fmt.Sprintf(" (selector: %s)", labelSelector)
+					}
+					progress.Printf(cmd, "%s\n", msg)
+				}
+
+				if allNamespaces && !clusterScopedTypes[rt] {
+					if namespaces == nil {
+						if namespaces, err = listNamespaces(ctx, client, workflowName); err != nil {
+							return err
+						}
+						if nsPrefix != "" {
+							namespaces = namespacesWithPrefix(namespaces, nsPrefix)
+						}
+					}
+					progress.Printf(cmd, "Fetching %s from %d namespaces (max %d concurrent)...\n", rt, len(namespaces), maxConc)
+					result, failures := fetchAcrossNamespaces(ctx, client, workflowName, data, namespaces, maxConc)
+					for _, f := range failures {
+						progress.Warnf(cmd, "Warning: getting %s in namespace %s: %v\n", rt, f.namespace, f.err)
+					}
+					if len(failures) > 0 && len(failures) == len(namespaces) {
+						return fmt.Errorf("getting %s failed in every namespace", rt)
+					}
+					sections = append(sections, getSection{resourceType: rt, label: target.label, result: result})
+					continue
+				}
+
+				result, err := runCached(ctx, client, resultCache, project, region, workflowName, data, progress.Writer(cmd), progress.WarnWriter(cmd))
+				if err != nil {
+					return workflowRunError(err)
+				}
+				if nsPrefix != "" && !clusterScopedTypes[rt] && result.State != "FAILED" {
+					filterNamespacePrefix(result.Result, nsPrefix)
+				}
+				sections = append(sections, getSection{resourceType: rt, label: target.label, result: result, args: data})
 			}
 
-			if result.State == "FAILED" {
-				return fmt.Errorf("workflow failed: %s", result.Error)
+			var snap *snapshotTarget
+			if snapshotDir != "" {
+				snap = newSnapshotTarget(snapshotDir, snapshotPrefix(targets, fromStdin), output.ParseFormat(outputFormat), keep)
+				outputFile = snap.path
+			}
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
 			}
+			defer out.Close()
 
-			format := output.ParseFormat(outputFormat)
-			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+			for _, sec := range sections {
+				if sec.result.State == "FAILED" {
+					return wf.WorkflowFailure(out, output.ParseFormat(outputFormat), sec.result.Error)
+				}
 			}
 
-			if analyze {
-				return output.PrintAnalysis(os.Stdout, result.Result, namespace)
+			if count {
+				if err := printGetCount(ctx, client, workflowName, out, sections, output.ParseFormat(outputFormat)); err != nil {
+					return err
+				}
+				return snap.finish(cmd)
 			}
 
-			return output.PrintResourceTable(os.Stdout, result.Result, resourceType)
+			opts := getRenderOptions{
+				format:        output.ParseFormat(outputFormat),
+				outputTmpl:    outputTmpl,
+				jsonPath:      jsonPath,
+				columns:       customColumns,
+				field:         field,
+				analyze:       analyze,
+				namespace:     namespace,
+				labelColumns:  labelColumns,
+				showKind:      showKind,
+				yamlDocuments: yamlDocuments,
+				noTruncate:    noTruncate,
+			}
+			if multi {
+				err = printGetSections(out, sections, opts)
+			} else {
+				err = printGetResult(out, sections[0].result.Result, resourceType, opts)
+			}
+			if err != nil {
+				return err
+			}
+			if err := snap.finish(cmd); err != nil || !failNotReady {
+				return err
+			}
+			return sectionsHealthError(sections)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
-	cmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector (e.g. app=nginx)")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Get resources from every namespace, one execution per namespace")
+	cmd.Flags().StringVar(&nsPrefix, "namespace-prefix", "", "Only namespaces starting with this prefix (e.g. clusters-test-pd); with -A, only those namespaces are fetched")
+	cmd.Flags().IntVar(&maxConc, "max-concurrency", defaultMaxConcurrency, "Maximum concurrent executions with --all-namespaces")
+	cmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector, validated before sending (e.g. app=nginx, 'env in (a,b)', !key)")
+	cmd.Flags().StringSliceVarP(&labelColumns, "label-columns", "L", nil, "Label keys to show as extra table columns (e.g. app,tier)")
+	cmd.Flags().BoolVar(&showKind, "show-kind", false, "Prefix table NAME values with the kind (pod/etcd-0); always on for multiple resource types")
 	cmd.Flags().BoolVar(&analyze, "analyze", false, "Run AI analysis on a pod (requires a specific pod name)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of items per page (workflow default 20, max 50)")
+	cmd.Flags().StringVar(&continueToken, "continue", "", "Continue token from a previous page to fetch the next page")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Re-run the get every --watch-interval until interrupted (--timeout applies to each poll)")
+	cmd.Flags().DurationVar(&watchInterval, "watch-interval", 5*time.Second, "Time between polls with --watch")
+	cmd.Flags().DurationVar(&watchTimeout, "watch-timeout", 0, "End --watch after this long in total (0 for no limit); with --fail-on-not-ready, exit 2 if pods are still not ready")
+	cmd.Flags().BoolVar(&asTable, "as-table", false, "Ask the get workflow for a server-side Table and print the API server's columns (CRD printer columns included)")
+	cmd.Flags().BoolVar(&chunked, "chunked-output", false, "With --watch and -o json or jsonl, print each poll as one compact JSON line with an _observed_at timestamp")
+	cmd.Flags().BoolVar(&onlyChanges, "watch-only-changes", false, "Watch, but only re-print when items are added, removed, or change resourceVersion or status")
+	addCacheFlags(cmd)
+	addDryRunFlag(cmd)
+	cmd.Flags().BoolVar(&failNotReady, "fail-on-not-ready", false, "Exit with status 3 if any pod is not Ready or Completed")
+	cmd.Flags().BoolVar(&count, "count", false, "Print only the number of matching items (summed over resource types); {\"count\": N} with -o json")
+	cmd.Flags().BoolVar(&yamlDocuments, "yaml-documents", false, "With -o yaml, write each item as its own ---separated document instead of a single List")
+	cmd.Flags().StringVar(&snapshotDir, "snapshot-dir", "", "Write the output to a new timestamped file in this directory (e.g. pods-20250101T120000Z.json) instead of stdout")
+	cmd.Flags().IntVar(&keep, "keep", 10, "With --snapshot-dir, keep only the newest N snapshots of the same resource types (0 keeps all)")
+	cmd.Flags().StringVar(&field, "field", "", "Print only this field (e.g. .status.podIP) of the single returned resource, undecorated")
+	cmd.Flags().StringVar(&outputTmpl, "output-template", "", "Go template applied to each item (same as -o go-template=...)")
 
 	return cmd
 }
+
+// validateWatch rejects flags that cannot be combined with --watch.
+// --fail-on-not-ready needs a --watch-timeout budget to watch against.
+func validateWatch(multi, allNamespaces, analyze, failNotReady bool, continueToken string, interval, budget time.Duration) error {
+	switch {
+	case multi:
+		return fmt.Errorf("--watch cannot be used with multiple resource types")
+	case allNamespaces:
+		return fmt.Errorf("--watch cannot be used with --all-namespaces")
+	case analyze:
+		return fmt.Errorf("--watch cannot be used with --analyze")
+	case failNotReady && budget <= 0:
+		return fmt.Errorf("--watch with --fail-on-not-ready requires --watch-timeout")
+	case budget < 0:
+		return fmt.Errorf("--watch-timeout must not be negative")
+	case continueToken != "":
+		return fmt.Errorf("--watch cannot be used with --continue")
+	case interval <= 0:
+		return fmt.Errorf("--watch-interval must be positive")
+	}
+	return nil
+}
+
+// parseResourceTypes splits a comma-separated resource type argument such as
+// "pods,svc,deploy" and expands each alias, preserving order and dropping
+// duplicates.
+func parseResourceTypes(arg string) ([]string, error) {
+	var types []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(arg, ",") {
+		rt := strings.TrimSpace(part)
+		if rt == "" {
+			return nil, fmt.Errorf("invalid resource type list %q: empty entry", arg)
+		}
+		if expanded, ok := resourceTypeExpand[rt]; ok {
+			rt = expanded
+		}
+		if seen[rt] {
+			continue
+		}
+		seen[rt] = true
+		types = append(types, rt)
+	}
+	return types, nil
+}
+
+// getTarget is one get workflow call: a resource type and, optionally, a
+// resource name. label is the stdin line the target was read from, if any.
+type getTarget struct {
+	resourceType string
+	name         string
+	label        string
+}
+
+// parseGetTargets reads `get -` targets from r, one per line, as
+// "<type> <name>", "<type>/<name>" or a bare "<type>". Type aliases are
+// expanded; blank lines and lines starting with '#' are skipped.
+func parseGetTargets(r io.Reader) ([]getTarget, error) {
+	var targets []getTarget
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 1 && strings.Contains(line, "/") {
+			fields = strings.SplitN(line, "/", 2)
+		}
+		if len(fields) > 2 || fields[0] == "" || (len(fields) == 2 && (fields[1] == "" || strings.Contains(fields[1], "/"))) {
+			return nil, fmt.Errorf("stdin line %d: invalid target %q: expected \"<type> <name>\" or \"<type>/<name>\"", lineNo, line)
+		}
+		if strings.Contains(fields[0], ",") {
+			return nil, fmt.Errorf("stdin line %d: invalid target %q: one resource type per line", lineNo, line)
+		}
+
+		target := getTarget{resourceType: fields[0], label: line}
+		if expanded, ok := resourceTypeExpand[target.resourceType]; ok {
+			target.resourceType = expanded
+		}
+		if len(fields) == 2 {
+			target.name = fields[1]
+		}
+		targets = append(targets, target)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading targets from stdin: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets on stdin: expected \"<type> <name>\" or \"<type>/<name>\" lines")
+	}
+	return targets, nil
+}
+
+// getSection is the workflow result for one target of a get. label, when
+// set, replaces the resource type as the section header.
+type getSection struct {
+	resourceType string
+	label        string
+	result       *workflows.ExecutionResult
+	// args are the get workflow arguments that produced result, used to
+	// fetch further pages. They are nil for merged --all-namespaces results.
+	args map[string]interface{}
+}
+
+// printGetCount writes the number of items across all sections: a bare
+// number, or {"count": N} in JSON. A named resource counts as one item.
+// When a section's result is one page of a longer list, the remaining pages
+// are fetched with the get workflow, deployed as workflowName, and counted
+// too.
+func printGetCount(ctx context.Context, runner workflowRunner, workflowName string, w io.Writer, sections []getSection, format output.Format) error {
+	n := 0
+	for _, sec := range sections {
+		c, err := countAllItems(ctx, runner, workflowName, sec.args, sec.result.Result)
+		if err != nil {
+			return err
+		}
+		n += c
+	}
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, map[string]interface{}{"count": n})
+	}
+	_, err := fmt.Fprintln(w, n)
+	return err
+}
+
+// countAllItems returns the number of resources in a get result and in the
+// pages after it, following continue tokens until the last page. With nil
+// args the result is counted as is.
+func countAllItems(ctx context.Context, runner workflowRunner, workflowName string, args, result map[string]interface{}) (int, error) {
+	n := countItems(result)
+	if args == nil {
+		return n, nil
+	}
+	for token := pageContinueToken(result); token != ""; token = pageContinueToken(result) {
+		next := make(map[string]interface{}, len(args)+1)
+		for k, v := range args {
+			next[k] = v
+		}
+		next["continue"] = token
+
+		_, page, err := runner.Run(ctx, workflowName, next)
+		if err != nil {
+			return 0, workflowRunError(err)
+		}
+		if page.State == "FAILED" {
+			return 0, fmt.Errorf("fetching the next page to count: %s", page.Error)
+		}
+		result = page.Result
+		n += countItems(result)
+	}
+	return n, nil
+}
+
+// pageContinueToken returns the continue token of a get result, which is
+// either a list or a list wrapped under "resource".
+func pageContinueToken(result map[string]interface{}) string {
+	if resource := output.AsMap(result["resource"]); resource["items"] != nil {
+		return output.ContinueToken(resource)
+	}
+	return output.ContinueToken(result)
+}
+
+// countItems returns the number of resources in a get result.
+func countItems(result map[string]interface{}) int {
+	if items, ok := result["items"].([]interface{}); ok {
+		return len(items)
+	}
+	if _, ok := result["resource"].(map[string]interface{}); ok {
+		return 1
+	}
+	return 0
+}
+
+// getRenderOptions carries the output settings shared by every section.
+type getRenderOptions struct {
+	format       output.Format
+	outputTmpl   string
+	jsonPath     string
+	columns      string
+	field        string
+	analyze      bool
+	namespace    string
+	labelColumns []string
+	// showKind prefixes table NAME values with the kind (pod/etcd-0); it is
+	// always on for multiple resource types.
+	showKind bool
+	// yamlDocuments writes -o yaml lists as one document per item instead
+	// of a single List.
+	yamlDocuments bool
+	// noTruncate prints table MESSAGE cells in full (--no-truncate).
+	noTruncate bool
+}
+
+// printGetResult renders a single get workflow result.
+func printGetResult(w io.Writer, result map[string]interface{}, resourceType string, opts getRenderOptions) error {
+	if opts.field != "" {
+		return output.PrintField(w, opts.field, result)
+	}
+	if opts.outputTmpl != "" {
+		return output.PrintTemplate(w, opts.outputTmpl, result)
+	}
+	if opts.jsonPath != "" {
+		return output.PrintJSONPath(w, opts.jsonPath, result)
+	}
+	if opts.columns != "" {
+		return output.PrintCustomColumns(w, opts.columns, result)
+	}
+
+	switch opts.format {
+	case output.FormatJSON:
+		return output.PrintJSON(w, result)
+	case output.FormatJSONL:
+		return output.PrintJSONL(w, result)
+	case output.FormatYAML:
+		if opts.yamlDocuments {
+			return output.PrintYAMLDocuments(w, result)
+		}
+		return output.PrintYAML(w, result)
+	case output.FormatName:
+		return output.PrintResourceNames(w, result, resourceType)
+	}
+
+	if opts.analyze {
+		return output.PrintAnalysis(w, result, opts.namespace)
+	}
+
+	return output.PrintResourceTableWithOptions(w, result, resourceType, output.TableOptions{
+		LabelColumns: opts.labelColumns,
+		ShowKind:     opts.showKind,
+		NoTruncate:   opts.noTruncate,
+	})
+}
+
+// printGetSections renders results for several resource types in the order
+// they were requested. Tables get a "=== <type> ===" header (or the stdin
+// line, for `get -`) and kind-prefixed names; JSON output is a single object
+// with one entry per section; line-oriented formats are simply concatenated.
+func printGetSections(w io.Writer, sections []getSection, opts getRenderOptions) error {
+	opts.showKind = true
+	if opts.format == output.FormatJSON && opts.outputTmpl == "" && opts.jsonPath == "" {
+		results := make([]interface{}, len(sections))
+		for i, sec := range sections {
+			entry := map[string]interface{}{
+				"resource_type": sec.resourceType,
+				"result":        sec.result.Result,
+			}
+			if sec.label != "" {
+				entry["input"] = sec.label
+			}
+			results[i] = entry
+		}
+		return output.PrintJSON(w, map[string]interface{}{"results": results})
+	}
+
+	textTables := opts.format == output.FormatText && opts.outputTmpl == "" && opts.jsonPath == ""
+	for i, sec := range sections {
+		if opts.format == output.FormatYAML && i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		if textTables {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			header := sec.resourceType
+			if sec.label != "" {
+				header = sec.label
+			}
+			fmt.Fprintf(w, "=== %s ===\n", header)
+		}
+		if err := printGetResult(w, sec.result.Result, sec.resourceType, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotTarget is where `get --snapshot-dir` writes one capture, and how
+// many older captures of the same resource types it keeps.
+type snapshotTarget struct {
+	dir    string
+	prefix string
+	ext    string
+	path   string
+	keep   int
+}
+
+func newSnapshotTarget(dir, prefix string, format output.Format, keep int) *snapshotTarget {
+	ext := output.SnapshotExtension(format)
+	return &snapshotTarget{
+		dir:    dir,
+		prefix: prefix,
+		ext:    ext,
+		path:   output.SnapshotPath(dir, prefix, ext, time.Now()),
+		keep:   keep,
+	}
+}
+
+// finish prunes old snapshots and reports the written file. It is a no-op
+// on a nil target, i.e. without --snapshot-dir.
+func (s *snapshotTarget) finish(cmd *cobra.Command) error {
+	if s == nil {
+		return nil
+	}
+	removed, err := output.PruneSnapshots(s.dir, s.prefix, s.ext, s.keep)
+	if err != nil {
+		return err
+	}
+	progress.Printf(cmd, "Wrote %s\n", s.path)
+	if len(removed) > 0 {
+		progress.Printf(cmd, "Pruned %d old snapshot(s), keeping %d\n", len(removed), s.keep)
+	}
+	return nil
+}
+
+// snapshotPrefix names the snapshot files of a get after its resource types
+// ("pods", "pods_services"), or "get" for targets read from stdin.
+func snapshotPrefix(targets []getTarget, fromStdin bool) string {
+	if fromStdin {
+		return "get"
+	}
+	types := make([]string, len(targets))
+	for i, t := range targets {
+		types[i] = t.resourceType
+	}
+	return strings.Join(types, "_")
+}
+
+// filterNamespacePrefix drops the items of a get result whose namespace does
+// not start with prefix, in case the get workflow ignored namespace_prefix.
+// Single-resource results are left alone.
+func filterNamespacePrefix(result map[string]interface{}, prefix string) {
+	list := result
+	if resource := output.AsMap(result["resource"]); resource["items"] != nil {
+		list = resource
+	}
+	if items, ok := list["items"].([]interface{}); ok {
+		list["items"] = output.FilterByNamespacePrefix(items, prefix)
+	}
+}
@@ -0,0 +1,385 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestParseResourceTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single type is given",
+			arg:  "po",
+			want: []string{"pods"},
+		},
+		{
+			name: "several aliases are given",
+			arg:  "pods,svc,deploy",
+			want: []string{"pods", "services", "deployments"},
+		},
+		{
+			name: "type repeats",
+			arg:  "po,pods,svc",
+			want: []string{"pods", "services"},
+		},
+		{
+			name:    "entry is empty",
+			arg:     "pods,,svc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResourceTypes(tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func getSectionsFixture() []getSection {
+	item := func(name string) interface{} {
+		return map[string]interface{}{"metadata": map[string]interface{}{"name": name, "namespace": "ns"}}
+	}
+	return []getSection{
+		{resourceType: "pods", result: &workflows.ExecutionResult{Result: map[string]interface{}{"items": []interface{}{item("etcd-0")}}}},
+		{resourceType: "services", result: &workflows.ExecutionResult{Result: map[string]interface{}{"items": []interface{}{item("etcd-client")}}}},
+		{resourceType: "deployments", result: &workflows.ExecutionResult{Result: map[string]interface{}{"items": []interface{}{}}}},
+	}
+}
+
+func TestPrintGetSections_TextOrder(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printGetSections(&buf, getSectionsFixture(), getRenderOptions{format: output.FormatText}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	headers := []string{"=== pods ===", "=== services ===", "=== deployments ==="}
+	last := -1
+	for _, h := range headers {
+		idx := strings.Index(out, h)
+		if idx < 0 {
+			t.Fatalf("missing header %q in:\n%s", h, out)
+		}
+		if idx < last {
+			t.Errorf("header %q out of order in:\n%s", h, out)
+		}
+		last = idx
+	}
+
+	podsIdx := strings.Index(out, "etcd-0")
+	svcIdx := strings.Index(out, "etcd-client")
+	if podsIdx < strings.Index(out, headers[0]) || podsIdx > strings.Index(out, headers[1]) {
+		t.Errorf("pod row not under pods header:\n%s", out)
+	}
+	if svcIdx < strings.Index(out, headers[1]) || svcIdx > strings.Index(out, headers[2]) {
+		t.Errorf("service row not under services header:\n%s", out)
+	}
+	if !strings.Contains(out, "No deployments found.") {
+		t.Errorf("expected empty deployments message:\n%s", out)
+	}
+}
+
+func TestGetShowKind(t *testing.T) {
+	sections := getSectionsFixture()
+
+	t.Run("one resource type is listed", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printGetResult(&buf, sections[0].result.Result, "pods", getRenderOptions{format: output.FormatText}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(buf.String(), "pod/etcd-0") || !strings.Contains(buf.String(), "etcd-0") {
+			t.Errorf("expected a bare name, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("one resource type is listed with --show-kind", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printGetResult(&buf, sections[0].result.Result, "pods", getRenderOptions{format: output.FormatText, showKind: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "pod/etcd-0") {
+			t.Errorf("expected pod/etcd-0, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("several resource types are listed", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printGetSections(&buf, sections, getRenderOptions{format: output.FormatText}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"pod/etcd-0", "service/etcd-client"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("expected %s, got:\n%s", want, buf.String())
+			}
+		}
+	})
+}
+
+func TestPrintGetSections_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printGetSections(&buf, getSectionsFixture(), getRenderOptions{format: output.FormatJSON}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Results []struct {
+			ResourceType string `json:"resource_type"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var types []string
+	for _, r := range got.Results {
+		types = append(types, r.ResourceType)
+	}
+	if want := []string{"pods", "services", "deployments"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("result order = %v, want %v", types, want)
+	}
+}
+
+func TestPrintGetSections_Name(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printGetSections(&buf, getSectionsFixture(), getRenderOptions{format: output.FormatName}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "pod/etcd-0\nservice/etcd-client\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseGetTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []getTarget
+		wantErr string
+	}{
+		{
+			name:  "lines use both forms",
+			input: "pods etcd-0\nsvc/etcd-client\nnodes\n",
+			want: []getTarget{
+				{resourceType: "pods", name: "etcd-0", label: "pods etcd-0"},
+				{resourceType: "services", name: "etcd-client", label: "svc/etcd-client"},
+				{resourceType: "nodes", label: "nodes"},
+			},
+		},
+		{
+			name:  "there are blank lines and comments",
+			input: "# control plane\n\n  deploy   operator  \n",
+			want:  []getTarget{{resourceType: "deployments", name: "operator", label: "deploy   operator"}},
+		},
+		{
+			name:    "line has too many fields",
+			input:   "pods etcd-0\npods etcd-1 extra\n",
+			wantErr: "stdin line 2",
+		},
+		{
+			name:    "line lists several types",
+			input:   "pods,svc\n",
+			wantErr: "one resource type per line",
+		},
+		{
+			name:    "name is empty",
+			input:   "pods/\n",
+			wantErr: "invalid target",
+		},
+		{
+			name:    "there are no targets",
+			input:   "# nothing\n",
+			wantErr: "no targets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGetTargets(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintGetSections_StdinLabels(t *testing.T) {
+	sections := getSectionsFixture()[:2]
+	sections[0].label = "pods etcd-0"
+	sections[1].label = "svc/etcd-client"
+
+	var buf bytes.Buffer
+	if err := printGetSections(&buf, sections, getRenderOptions{format: output.FormatText}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"=== pods etcd-0 ===", "=== svc/etcd-client ==="} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing header %q in:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := printGetSections(&buf, sections, getRenderOptions{format: output.FormatJSON}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"input": "svc/etcd-client"`) {
+		t.Errorf("expected the input line in JSON, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintGetCount(t *testing.T) {
+	sections := getSectionsFixture()
+	single := []getSection{{resourceType: "pods", result: &workflows.ExecutionResult{Result: map[string]interface{}{
+		"resource": map[string]interface{}{"metadata": map[string]interface{}{"name": "etcd-0"}},
+	}}}}
+
+	tests := []struct {
+		name     string
+		sections []getSection
+		format   output.Format
+		want     string
+	}{
+		{
+			name:     "output is text",
+			sections: sections[:1],
+			format:   output.FormatText,
+			want:     "1\n",
+		},
+		{
+			name:     "several resource types are listed",
+			sections: sections,
+			format:   output.FormatText,
+			want:     "2\n",
+		},
+		{
+			name:     "named resource is returned",
+			sections: single,
+			format:   output.FormatText,
+			want:     "1\n",
+		},
+		{
+			name:     "output is JSON",
+			sections: sections,
+			format:   output.FormatJSON,
+			want:     "{\n  \"count\": 2\n}\n",
+		},
+		{
+			name:     "there are no items",
+			sections: sections[2:],
+			format:   output.FormatJSON,
+			want:     "{\n  \"count\": 0\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printGetCount(context.Background(), nil, "get", &buf, tt.sections, tt.format); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotPrefix(t *testing.T) {
+	targets := []getTarget{{resourceType: "pods"}, {resourceType: "services"}}
+	if got := snapshotPrefix(targets[:1], false); got != "pods" {
+		t.Errorf("single type prefix = %q", got)
+	}
+	if got := snapshotPrefix(targets, false); got != "pods_services" {
+		t.Errorf("multi type prefix = %q", got)
+	}
+	if got := snapshotPrefix(targets, true); got != "get" {
+		t.Errorf("stdin prefix = %q", got)
+	}
+}
+
+func TestPrintGetCount_Paged(t *testing.T) {
+	pages := map[string]map[string]interface{}{
+		"":   {"items": []interface{}{nsPod("ns", "a"), nsPod("ns", "b")}, "metadata": map[string]interface{}{"continue": "p2"}},
+		"p2": {"items": []interface{}{nsPod("ns", "c"), nsPod("ns", "d")}, "metadata": map[string]interface{}{"continue": "p3"}},
+		"p3": {"items": []interface{}{nsPod("ns", "e")}, "metadata": map[string]interface{}{}},
+	}
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(args map[string]interface{}) *workflows.ExecutionResult {
+			token, _ := args["continue"].(string)
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: pages[token]}
+		},
+	}}
+	sections := []getSection{{
+		resourceType: "pods",
+		result:       &workflows.ExecutionResult{State: "SUCCEEDED", Result: pages[""]},
+		args:         map[string]interface{}{"resource_type": "pods", "namespace": "ns"},
+	}}
+
+	var buf bytes.Buffer
+	if err := printGetCount(context.Background(), runner, "get", &buf, sections, output.FormatText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "5\n" {
+		t.Errorf("got %q, want every page counted", buf.String())
+	}
+	if len(runner.calls) != 2 {
+		t.Errorf("fetched %d more pages, want 2", len(runner.calls))
+	}
+}
+
+func TestGetCmd_AsTableConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"count", []string{"--count"}, "--as-table cannot be used with --count"},
+		{"fail-on-not-ready", []string{"--fail-on-not-ready"}, "--as-table cannot be used with --count, --fail-on-not-ready"},
+		{"field", []string{"--field", "status.phase"}, "--as-table cannot be used with --count, --fail-on-not-ready or --field"},
+		{"output name", []string{"-o", "name"}, "--as-table cannot be used with -o name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newDryRunGetCmd()
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetArgs(append([]string{"pods", "-n", "foo", "--project", "p", "--region", "r", "--dry-run", "--as-table"}, tt.args...))
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package ops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// Exit codes returned by commands. ExitWaitTimeout means the command stopped
+// waiting at its --timeout while the execution kept running; ExitDegraded is
+// used by commands that check resource health. Any other failure (bad flags,
+// workflow errors) exits with 1.
+const (
+	ExitHealthy     = 0
+	ExitWaitTimeout = 2
+	ExitDegraded    = 3
+)
+
+// ExitError is returned when a command has completed its output but must
+// exit with a specific status. The entry points use ExitCode as the process
+// exit status.
+type ExitError struct {
+	Code int
+	Msg  string
+}
+
+func (e *ExitError) Error() string { return e.Msg }
+
+// ExitCode returns the process exit status for the error.
+func (e *ExitError) ExitCode() int { return e.Code }
+
+// unhealthyPods returns a description of every pod in items that is neither
+// Running with all containers ready nor Completed.
+func unhealthyPods(items []interface{}) []string {
+	var unhealthy []string
+	for _, item := range items {
+		pod := output.AsMap(item)
+		healthy, status, ready := output.PodHealth(pod)
+		if healthy {
+			continue
+		}
+		name := output.GetString(output.AsMap(pod["metadata"]), "name")
+		unhealthy = append(unhealthy, fmt.Sprintf("%s (%s, %s ready)", name, status, ready))
+	}
+	return unhealthy
+}
+
+// podHealthError returns an ExitError with ExitDegraded if any pod in the get
+// result is unhealthy, or nil if all pods are healthy.
+func podHealthError(result map[string]interface{}) error {
+	return notReadyError(unhealthyPods(podItems(result)))
+}
+
+// sectionsHealthError is podHealthError over every pods section of a
+// multi-resource get, so a not-ready pod in any section fails the command.
+func sectionsHealthError(sections []getSection) error {
+	var unhealthy []string
+	for _, sec := range sections {
+		if sec.resourceType == "pods" {
+			unhealthy = append(unhealthy, unhealthyPods(podItems(sec.result.Result))...)
+		}
+	}
+	return notReadyError(unhealthy)
+}
+
+// podItems returns the pods in a get result: its items, or the single
+// resource of a get by name.
+func podItems(result map[string]interface{}) []interface{} {
+	items, ok := result["items"].([]interface{})
+	if !ok {
+		if resource, rOk := result["resource"].(map[string]interface{}); rOk {
+			items = []interface{}{resource}
+		}
+	}
+	return items
+}
+
+// notReadyError returns an ExitError with ExitDegraded listing the unhealthy
+// pods, or nil if there are none.
+func notReadyError(unhealthy []string) error {
+	if len(unhealthy) == 0 {
+		return nil
+	}
+	noun := "pods"
+	if len(unhealthy) == 1 {
+		noun = "pod"
+	}
+	return &ExitError{
+		Code: ExitDegraded,
+		Msg:  fmt.Sprintf("%d %s not ready: %s", len(unhealthy), noun, strings.Join(unhealthy, ", ")),
+	}
+}
@@ -0,0 +1,104 @@
+package ops
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func healthTestPod(name, phase string, ready bool, state map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name},
+		"status": map[string]interface{}{
+			"phase": phase,
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"ready": ready, "state": state},
+			},
+		},
+	}
+}
+
+func TestPodHealthError(t *testing.T) {
+	running := map[string]interface{}{"running": map[string]interface{}{}}
+	completed := map[string]interface{}{"terminated": map[string]interface{}{"reason": "Completed", "exitCode": float64(0)}}
+	crashloop := map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff"}}
+
+	t.Run("all pods are Ready or Completed", func(t *testing.T) {
+		result := map[string]interface{}{"items": []interface{}{
+			healthTestPod("etcd-0", "Running", true, running),
+			healthTestPod("migrate-job", "Succeeded", false, completed),
+		}}
+		if err := podHealthError(result); err != nil {
+			t.Fatalf("expected healthy, got %v", err)
+		}
+	})
+
+	t.Run("pod is crashlooping", func(t *testing.T) {
+		result := map[string]interface{}{"items": []interface{}{
+			healthTestPod("etcd-0", "Running", true, running),
+			healthTestPod("kube-apiserver-abc", "Running", false, crashloop),
+		}}
+		err := podHealthError(result)
+
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("expected *ExitError, got %v", err)
+		}
+		if exitErr.ExitCode() != ExitDegraded {
+			t.Errorf("exit code = %d, want %d", exitErr.ExitCode(), ExitDegraded)
+		}
+		if !strings.Contains(err.Error(), "kube-apiserver-abc (CrashLoopBackOff, 0/1 ready)") {
+			t.Errorf("unexpected message: %v", err)
+		}
+		if strings.Contains(err.Error(), "etcd-0") {
+			t.Errorf("healthy pod should not be listed: %v", err)
+		}
+	})
+
+	t.Run("running pod is not ready", func(t *testing.T) {
+		result := map[string]interface{}{"resource": healthTestPod("etcd-1", "Running", false, running)}
+		if err := podHealthError(result); err == nil {
+			t.Fatal("expected unhealthy")
+		}
+	})
+}
+
+func TestSectionsHealthError(t *testing.T) {
+	running := map[string]interface{}{"running": map[string]interface{}{}}
+	crashloop := map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff"}}
+	section := func(resourceType string, pods ...interface{}) getSection {
+		return getSection{
+			resourceType: resourceType,
+			result:       &workflows.ExecutionResult{Result: map[string]interface{}{"items": pods}},
+		}
+	}
+
+	t.Run("second section not ready", func(t *testing.T) {
+		sections := []getSection{
+			section("pods", healthTestPod("etcd-0", "Running", true, running)),
+			section("deployments"),
+			section("pods", healthTestPod("kube-apiserver-abc", "Running", false, crashloop)),
+		}
+		err := sectionsHealthError(sections)
+
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitDegraded {
+			t.Fatalf("expected ExitDegraded, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "1 pod not ready: kube-apiserver-abc") {
+			t.Errorf("unexpected message: %v", err)
+		}
+	})
+
+	t.Run("all sections ready", func(t *testing.T) {
+		sections := []getSection{
+			section("pods", healthTestPod("etcd-0", "Running", true, running)),
+			section("pods", healthTestPod("etcd-1", "Running", true, running)),
+		}
+		if err := sectionsHealthError(sections); err != nil {
+			t.Fatalf("expected healthy, got %v", err)
+		}
+	})
+}
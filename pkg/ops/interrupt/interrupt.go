@@ -0,0 +1,96 @@
+// Package interrupt handles Ctrl+C (SIGINT) and SIGTERM for commands that
+// run Cloud Workflows, so an interrupted command tells the user how to
+// check on the execution it left running.
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+// ErrInterrupted is the cause of a context cancelled by a signal.
+var ErrInterrupted = errors.New("interrupted")
+
+// WithTimeout returns a context that is cancelled after timeout or when the
+// process receives SIGINT or SIGTERM. If a workflow execution was started
+// with the context before the signal arrived, a hint for checking on it with
+// "wf status" is written to stderr. The returned cancel function stops
+// signal handling and must be called when the command finishes. A timeout
+// of zero or less sets no deadline.
+func WithTimeout(parent context.Context, timeout time.Duration, stderr io.Writer) (context.Context, context.CancelFunc) {
+	ctx, cancelTimeout := parent, context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancelTimeout = context.WithTimeout(parent, timeout)
+	}
+	ctx, cancelCause := context.WithCancelCause(ctx)
+
+	var (
+		mu       sync.Mutex
+		execName string
+	)
+	ctx = workflows.ContextWithExecutionObserver(ctx, func(name string) {
+		mu.Lock()
+		execName = name
+		mu.Unlock()
+	})
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+			mu.Lock()
+			name := execName
+			mu.Unlock()
+			if name != "" {
+				PrintHint(stderr, name)
+			}
+			cancelCause(ErrInterrupted)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			close(done)
+			cancelCause(context.Canceled)
+			cancelTimeout()
+		})
+	}
+}
+
+// Interrupted reports whether ctx was cancelled by a signal.
+func Interrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrInterrupted)
+}
+
+// PrintHint tells the user that the execution keeps running after the
+// command exits and how to check on it.
+func PrintHint(w io.Writer, execName string) {
+	_, execID := workflows.ParseExecutionName(execName)
+	fmt.Fprintf(w, "\nInterrupted. Execution %s is still running; check status with:\n", execID)
+	fmt.Fprintf(w, "  %s\n", StatusCommand(execName))
+}
+
+// StatusCommand returns the "wf status" command line that checks on an
+// execution.
+func StatusCommand(execName string) string {
+	workflow, execID := workflows.ParseExecutionName(execName)
+	if workflow == "" {
+		workflow = "<workflow>"
+	}
+	return fmt.Sprintf("gcphcp ops wf status %s %s", workflow, execID)
+}
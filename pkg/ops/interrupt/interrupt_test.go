@@ -0,0 +1,67 @@
+package interrupt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		execName string
+		want     string
+	}{
+		{
+			name:     "full execution name",
+			execName: "projects/my-proj/locations/us-central1/workflows/get/executions/abc-123",
+			want:     "gcphcp ops wf status get abc-123\n",
+		},
+		{
+			name:     "bare execution ID",
+			execName: "abc-123",
+			want:     "gcphcp ops wf status <workflow> abc-123\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintHint(&buf, tt.execName)
+			got := buf.String()
+			if !strings.Contains(got, "Execution abc-123 is still running") {
+				t.Errorf("expected execution ID in hint, got:\n%s", got)
+			}
+			if !strings.HasSuffix(got, tt.want) {
+				t.Errorf("expected hint to end with %q, got:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithTimeout_CancelIsNotInterrupt(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), time.Minute, &bytes.Buffer{})
+	cancel()
+	cancel()
+
+	if ctx.Err() == nil {
+		t.Fatal("expected context to be cancelled")
+	}
+	if Interrupted(ctx) {
+		t.Error("a normal cancel should not report an interrupt")
+	}
+}
+
+func TestWithTimeout_NoDeadline(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), 0, &bytes.Buffer{})
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("a zero timeout should set no deadline")
+	}
+	if ctx.Err() != nil {
+		t.Errorf("context done before cancel: %v", ctx.Err())
+	}
+}
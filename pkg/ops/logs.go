@@ -3,21 +3,40 @@ package ops
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 func newLogsCmd() *cobra.Command {
 	var (
-		namespace string
-		container string
-		tailLines int
-		previous  bool
-		timeout   time.Duration
+		namespace   string
+		nsPrefix    string
+		container   string
+		containerRE string
+		tailLines   int
+		previous    bool
+		timeout     time.Duration
+		grep        string
+		exclude     string
+		sinceLine   int
+		timestamps  bool
+		lineNumbers bool
+		limitBytes  int64
+		explain     bool
+		autoPick    bool
+		contextN    int
+		afterN      int
+		beforeN     int
 	)
 
 	cmd := &cobra.Command{
@@ -30,14 +49,35 @@ Examples:
   # Get logs for a pod
   gcphcp ops logs kube-apiserver-abc123 -n clusters-test-pd-test-pd
 
+  # Find the namespace by prefix instead of typing it out
+  gcphcp ops logs kube-apiserver-abc123 --namespace-prefix clusters-test-pd
+
   # Get logs from a specific container
   gcphcp ops logs my-pod -n default -c my-container
 
+  # Get logs from every container whose name matches a pattern
+  gcphcp ops logs etcd-0 -n clusters-abc123 --container-regex '^etcd'
+
+  # Pick the app container of a multi-container pod without naming it
+  gcphcp ops logs etcd-0 -n clusters-abc123 --auto-container
+
   # Get last 50 lines
   gcphcp ops logs my-pod -n default --tail 50
 
   # Get logs from previous container instance (crashloop debugging)
-  gcphcp ops logs my-pod -n default --previous`,
+  gcphcp ops logs my-pod -n default --previous
+
+  # Keep only error lines, dropping noisy health checks
+  gcphcp ops logs my-pod -n default --tail 1000 --grep 'error|fail' --exclude healthz
+
+  # Show 3 lines around each match, like grep -C 3
+  gcphcp ops logs my-pod -n default --tail 1000 --grep panic -C 3
+
+  # Cap the response at 64 KiB for very chatty containers
+  gcphcp ops logs my-pod -n default --tail 5000 --limit-bytes 65536
+
+  # Follow the logs with an AI analysis of the pod
+  gcphcp ops logs my-pod -n default --explain`,
 
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,7 +85,10 @@ Examples:
 
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
+			workflowName := workflowPrefix(cmd) + "logs"
+			progress.With(cmd, "workflow", workflowName)
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -53,8 +96,48 @@ Examples:
 			if region == "" {
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
-			if namespace == "" {
-				return fmt.Errorf("--namespace is required for logs")
+			if nsPrefix != "" && namespace != "" {
+				return fmt.Errorf("--namespace-prefix and --namespace are mutually exclusive")
+			}
+			if namespace == "" && nsPrefix == "" {
+				return fmt.Errorf("--namespace (or --namespace-prefix) is required for logs")
+			}
+			if limitBytes < 0 {
+				return fmt.Errorf("--limit-bytes must not be negative")
+			}
+			if err := validateExplain(explain, "pods", outputFormat); err != nil {
+				return err
+			}
+
+			filter, err := newLogFilter(grep, exclude, sinceLine)
+			if err != nil {
+				return err
+			}
+			var containerMatch *regexp.Regexp
+			if containerRE != "" {
+				if container != "" {
+					return fmt.Errorf("--container and --container-regex are mutually exclusive")
+				}
+				if containerMatch, err = regexp.Compile(containerRE); err != nil {
+					return fmt.Errorf("invalid --container-regex pattern: %w", err)
+				}
+			}
+			if autoPick && (container != "" || containerRE != "") {
+				return fmt.Errorf("--auto-container cannot be combined with --container or --container-regex")
+			}
+			filter.lineNumbers = lineNumbers
+			if contextN < 0 || afterN < 0 || beforeN < 0 {
+				return fmt.Errorf("--context-lines, --after-context and --before-context must not be negative")
+			}
+			filter.before, filter.after = contextN, contextN
+			if cmd.Flags().Changed("before-context") {
+				filter.before = beforeN
+			}
+			if cmd.Flags().Changed("after-context") {
+				filter.after = afterN
+			}
+			if (filter.before > 0 || filter.after > 0) && filter.grep == nil {
+				return fmt.Errorf("--context-lines, --after-context and --before-context require --grep")
 			}
 
 			data := map[string]interface{}{
@@ -68,8 +151,26 @@ Examples:
 			if previous {
 				data["previous"] = true
 			}
+			if timestamps {
+				data["timestamps"] = true
+			}
+			if limitBytes > 0 {
+				data["limit_bytes"] = limitBytes
+			}
+			if nsPrefix != "" {
+				delete(data, "namespace")
+			}
+
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				if nsPrefix != "" {
+					if err := printNamespacePrefixDryRun(cmd.OutOrStdout(), workflowPrefix(cmd)+"get", nsPrefix); err != nil {
+						return err
+					}
+				}
+				return printDryRun(cmd.OutOrStdout(), workflowName, data)
+			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -78,59 +179,460 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "logs", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Getting logs for %s", podName)
+			if nsPrefix != "" {
+				if namespace, err = resolveNamespacePrefix(ctx, client, workflowPrefix(cmd)+"get", nsPrefix); err != nil {
+					return err
+				}
+				data["namespace"] = namespace
+			}
+
+			if autoPick {
+				picked, reason, err := autoSelectContainer(ctx, client, workflowPrefix(cmd)+"describe", namespace, podName)
+				if err != nil {
+					return err
+				}
+				progress.Printf(cmd, "Auto-selected container %s (%s)\n", picked, reason)
+				container = picked
+				data["container"] = picked
+			}
+
+			msg := "Getting logs for " + podName
 			if container != "" {
-				fmt.Fprintf(os.Stderr, " (container: %s)", container)
+				msg += fmt.Sprintf(" (container: %s)", container)
 			}
-			fmt.Fprintf(os.Stderr, " in %s\n", namespace)
+			progress.Printf(cmd, "%s in %s\n", msg, namespace)
 			if previous {
-				fmt.Fprintf(os.Stderr, "Previous container instance\n")
+				progress.Printf(cmd, "Previous container instance\n")
 			}
 
-			_, result, err := client.Run(ctx, "logs", data)
+			_, result, err := client.Run(ctx, workflowName, data)
 			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+				return workflowRunError(err)
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
 			}
+			defer out.Close()
 
 			if result.State == "FAILED" {
-				return fmt.Errorf("workflow failed: %s", result.Error)
+				return wf.WorkflowFailure(out, output.ParseFormat(outputFormat), result.Error)
 			}
 
 			format := output.ParseFormat(outputFormat)
-			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+			if containerMatch != nil && output.GetString(result.Result, "status") == "container_required" {
+				containers, err := matchingContainers(result.Result, containerMatch)
+				if err != nil {
+					return err
+				}
+				progress.Printf(cmd, "Matched containers: %s\n", strings.Join(containers, ", "))
+				blocks, err := fetchContainerLogs(ctx, client, workflowName, data, containers)
+				if err != nil {
+					return err
+				}
+				if err := printContainerLogs(out, format, blocks, filter); err != nil {
+					return err
+				}
+				for _, summary := range containerLogsSummaries(blocks) {
+					progress.Printf(cmd, "%s\n", summary)
+				}
+				if explain {
+					return runExplain(ctx, client, cmd, out, namespace, podName, container)
+				}
+				return nil
 			}
-
-			if status, _ := result.Result["status"].(string); status == "container_required" {
-				fmt.Fprintf(os.Stderr, "Error: pod %q has multiple containers; you must specify one:\n", podName)
-				if containers, ok := result.Result["available_containers"].([]interface{}); ok {
-					for _, c := range containers {
-						fmt.Fprintf(os.Stderr, "  - %v\n", c)
-					}
+			if format != output.FormatJSON {
+				usage := fmt.Sprintf("gcphcp ops logs %s -n %s -c <container>", podName, namespace)
+				if err := containerRequired(progress.Stderr(cmd), result.Result, podName, usage); err != nil {
+					return err
 				}
-				fmt.Fprintf(os.Stderr, "\nUse: gcphcp ops logs %s -n %s -c <container>\n", podName, namespace)
-				return fmt.Errorf("container name required")
 			}
 
-			if logs, ok := result.Result["logs"]; ok {
-				fmt.Fprintln(os.Stdout, logs)
-			} else {
-				return output.PrintJSON(os.Stdout, result.Result)
+			if err := printLogs(out, format, result.Result, filter); err != nil {
+				return err
+			}
+			if logs, ok := parseLogsResult(result.Result); ok {
+				progress.Printf(cmd, "%s\n", logs.summary())
+			}
+			if explain {
+				return runExplain(ctx, client, cmd, out, namespace, podName, container)
 			}
-
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required unless --namespace-prefix is set)")
+	cmd.Flags().StringVar(&nsPrefix, "namespace-prefix", "", "Use the one namespace starting with this prefix (e.g. clusters-test-pd)")
 	cmd.Flags().StringVarP(&container, "container", "c", "", "Container name")
+	cmd.Flags().StringVar(&containerRE, "container-regex", "", "Fetch logs from every container whose name matches this regular expression")
 	cmd.Flags().IntVar(&tailLines, "tail", 100, "Number of log lines to retrieve")
 	cmd.Flags().BoolVar(&previous, "previous", false, "Get logs from previous container instance")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only print lines matching this regular expression")
+	cmd.Flags().StringVar(&exclude, "exclude", "", "Drop lines matching this regular expression (wins over --grep)")
+	cmd.Flags().IntVarP(&contextN, "context-lines", "C", 0, "Print this many lines around each --grep match, like grep -C")
+	cmd.Flags().IntVarP(&afterN, "after-context", "A", 0, "Print this many lines after each --grep match (overrides -C)")
+	cmd.Flags().IntVarP(&beforeN, "before-context", "B", 0, "Print this many lines before each --grep match (overrides -C)")
+	cmd.Flags().IntVar(&sinceLine, "since-line", 0, "Skip lines before this 1-based line number of the fetched logs")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Include an RFC3339 timestamp at the start of each log line")
+	cmd.Flags().BoolVar(&lineNumbers, "line-numbers", false, "Prefix each printed line with its line number in the fetched logs")
+	cmd.Flags().BoolVar(&autoPick, "auto-container", false, "Describe the pod first and pick its app container instead of failing on multi-container pods")
+	addExplainFlag(cmd, &explain)
+	cmd.Flags().Int64Var(&limitBytes, "limit-bytes", 0, "Maximum bytes of logs the workflow returns (0 for no limit)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	addDryRunFlag(cmd)
 
 	return cmd
 }
+
+// logsResult is a logs workflow result. Only Logs is always set; newer
+// workflow versions also report which container was read and whether the
+// output was cut short by --tail or --limit-bytes.
+type logsResult struct {
+	Logs      string
+	Container string
+	Truncated bool
+	LineCount int
+	Bytes     int64
+}
+
+// parseLogsResult reads a logs workflow result. It reports false when the
+// result has no "logs" text, such as a container_required response. A
+// metadata field of the wrong type is left at its zero value.
+func parseLogsResult(result map[string]interface{}) (logsResult, bool) {
+	text, ok := result["logs"].(string)
+	if !ok {
+		return logsResult{}, false
+	}
+	logs := logsResult{Logs: text}
+	logs.Container, _ = result["container"].(string)
+	logs.Truncated, _ = result["truncated"].(bool)
+	if n, ok := result["line_count"].(float64); ok {
+		logs.LineCount = int(n)
+	}
+	if n, ok := result["bytes"].(float64); ok {
+		logs.Bytes = int64(n)
+	}
+	return logs, true
+}
+
+// summary describes the fetched logs before client-side filtering, e.g.
+// "(fetched 12.3 KiB, 100 lines from etcd; truncated)". The workflow's
+// line_count and bytes are used when present, otherwise the text is
+// measured.
+func (r logsResult) summary() string {
+	size := int(r.Bytes)
+	if size <= 0 {
+		size = len(r.Logs)
+	}
+	lines := r.LineCount
+	if lines <= 0 && r.Logs != "" {
+		lines = strings.Count(strings.TrimSuffix(r.Logs, "\n"), "\n") + 1
+	}
+	noun := "lines"
+	if lines == 1 {
+		noun = "line"
+	}
+	note := fmt.Sprintf("fetched %s, %d %s", output.FormatBytes(size), lines, noun)
+	if r.Container != "" {
+		note += " from " + r.Container
+	}
+	if r.Truncated {
+		note += "; truncated"
+	}
+	return "(" + note + ")"
+}
+
+// printLogs writes a logs workflow result. JSON output is the raw result,
+// metadata included; otherwise the logs text is printed after client-side
+// filtering.
+func printLogs(w io.Writer, format output.Format, result map[string]interface{}, filter logFilter) error {
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, result)
+	}
+
+	logs, ok := parseLogsResult(result)
+	if !ok {
+		return output.PrintJSON(w, result)
+	}
+	fmt.Fprintln(w, filter.apply(logs.Logs))
+	return nil
+}
+
+// containerRequired reports a "container_required" workflow result, listing
+// the pod's containers and how to re-run with -c. It returns nil for any other
+// result status.
+func containerRequired(w io.Writer, result map[string]interface{}, podName, usage string) error {
+	if status, _ := result["status"].(string); status != "container_required" {
+		return nil
+	}
+
+	fmt.Fprintf(w, "Error: pod %q has multiple containers; you must specify one:\n", podName)
+	if containers, ok := result["available_containers"].([]interface{}); ok {
+		for _, c := range containers {
+			fmt.Fprintf(w, "  - %v\n", c)
+		}
+	}
+	fmt.Fprintf(w, "\nUse: %s\n", usage)
+	return fmt.Errorf("container name required")
+}
+
+// sidecarContainer matches names of containers that run next to the app
+// container of a pod: proxies and log or token helpers.
+var sidecarContainer = regexp.MustCompile(`(^|-)(proxy|sidecar)($|-)|^konnectivity-|^istio-|^envoy|^audit-logs$|token-minter`)
+
+// defaultContainerAnnotation names a pod's default container, as honored by
+// kubectl logs.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// autoSelectContainer describes the pod with the describe workflow
+// (deployed as describeWorkflow) and picks the container to read logs from.
+// See pickContainer.
+func autoSelectContainer(ctx context.Context, runner workflowRunner, describeWorkflow, namespace, podName string) (name, reason string, err error) {
+	_, result, err := runner.Run(ctx, describeWorkflow, map[string]interface{}{
+		"resource_type": "pods",
+		"name":          podName,
+		"namespace":     namespace,
+	})
+	if err != nil {
+		return "", "", workflowRunError(err)
+	}
+	if result.State == "FAILED" {
+		return "", "", fmt.Errorf("describing pod %s for --auto-container: %s", podName, result.Error)
+	}
+	return pickContainer(result.Result, podName)
+}
+
+// pickContainer chooses a container from a describe result: the one named
+// by the default-container annotation, the pod's only container, or its
+// only container that is not a sidecar, in that order. reason says which
+// rule applied. It fails when several app containers remain.
+func pickContainer(data map[string]interface{}, podName string) (name, reason string, err error) {
+	meta, spec, _, ok := describedResource(data)
+	if !ok {
+		return "", "", fmt.Errorf("describe returned no pod %s", podName)
+	}
+
+	var all, primary []string
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		n := output.GetString(output.AsMap(c), "name")
+		if n == "" {
+			continue
+		}
+		all = append(all, n)
+		if !sidecarContainer.MatchString(n) {
+			primary = append(primary, n)
+		}
+	}
+
+	if def := output.GetString(output.AsMap(meta["annotations"]), defaultContainerAnnotation); def != "" {
+		for _, n := range all {
+			if n == def {
+				return n, "default-container annotation", nil
+			}
+		}
+	}
+	switch {
+	case len(all) == 0:
+		return "", "", fmt.Errorf("pod %s has no containers", podName)
+	case len(all) == 1:
+		return all[0], "only container", nil
+	case len(primary) == 1:
+		return primary[0], "only non-sidecar container", nil
+	}
+	return "", "", fmt.Errorf("--auto-container: pod %s has several app containers (%s); choose one with -c", podName, strings.Join(all, ", "))
+}
+
+// containerLogs is the logs workflow result for one container of a pod.
+type containerLogs struct {
+	Container string                 `json:"container"`
+	Result    map[string]interface{} `json:"result"`
+}
+
+// matchingContainers returns the available_containers of a
+// container_required result whose names match re, in the order the workflow
+// listed them.
+func matchingContainers(result map[string]interface{}, re *regexp.Regexp) ([]string, error) {
+	available, _ := result["available_containers"].([]interface{})
+	var matched, all []string
+	for _, c := range available {
+		name := fmt.Sprintf("%v", c)
+		all = append(all, name)
+		if re.MatchString(name) {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no container matches --container-regex %q (available: %s)", re, strings.Join(all, ", "))
+	}
+	return matched, nil
+}
+
+// fetchContainerLogs runs the logs workflow (deployed as workflowName) once
+// per container with the shared arguments in data.
+func fetchContainerLogs(ctx context.Context, runner workflowRunner, workflowName string, data map[string]interface{}, containers []string) ([]containerLogs, error) {
+	blocks := make([]containerLogs, 0, len(containers))
+	for _, name := range containers {
+		args := make(map[string]interface{}, len(data)+1)
+		for k, v := range data {
+			args[k] = v
+		}
+		args["container"] = name
+
+		_, result, err := runner.Run(ctx, workflowName, args)
+		if err != nil {
+			return nil, workflowRunError(err)
+		}
+		if result.State == "FAILED" {
+			return nil, fmt.Errorf("getting logs for container %s: %s", name, result.Error)
+		}
+		blocks = append(blocks, containerLogs{Container: name, Result: result.Result})
+	}
+	return blocks, nil
+}
+
+// containerLogsSummaries describes the fetched logs of each block, as
+// logsResult.summary does, naming the container the block was fetched for
+// when the workflow did not report one.
+func containerLogsSummaries(blocks []containerLogs) []string {
+	var summaries []string
+	for _, b := range blocks {
+		logs, ok := parseLogsResult(b.Result)
+		if !ok {
+			continue
+		}
+		if logs.Container == "" {
+			logs.Container = b.Container
+		}
+		summaries = append(summaries, logs.summary())
+	}
+	return summaries
+}
+
+// printContainerLogs writes the logs of several containers. Text output
+// prefixes every line with "[container] "; JSON output is a list of
+// {"container", "result"} objects.
+func printContainerLogs(w io.Writer, format output.Format, blocks []containerLogs, filter logFilter) error {
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, blocks)
+	}
+
+	for _, b := range blocks {
+		logs, ok := parseLogsResult(b.Result)
+		if !ok {
+			continue
+		}
+		text := filter.apply(logs.Logs)
+		if text == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			fmt.Fprintf(w, "[%s] %s\n", b.Container, line)
+		}
+	}
+	return nil
+}
+
+// logFilter selects and decorates lines from fetched logs on the client side.
+type logFilter struct {
+	grep        *regexp.Regexp
+	exclude     *regexp.Regexp
+	sinceLine   int
+	lineNumbers bool
+	// before and after add that many context lines around each kept line,
+	// like grep -B and -A.
+	before, after int
+}
+
+// newLogFilter compiles the --grep and --exclude patterns.
+func newLogFilter(grep, exclude string, sinceLine int) (logFilter, error) {
+	f := logFilter{sinceLine: sinceLine}
+	if grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return f, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		f.grep = re
+	}
+	if exclude != "" {
+		re, err := regexp.Compile(exclude)
+		if err != nil {
+			return f, fmt.Errorf("invalid --exclude pattern: %w", err)
+		}
+		f.exclude = re
+	}
+	return f, nil
+}
+
+// apply drops lines before sinceLine, keeps lines matching grep and drops
+// lines matching exclude. Exclude wins when a line matches both. With
+// before or after, the lines around each kept line are printed too and
+// non-contiguous blocks are separated by "--", as grep -C does. With
+// lineNumbers, each kept line is prefixed with its position in logs, so the
+// numbers stay stable when filters are added.
+func (f logFilter) apply(logs string) string {
+	if f.grep == nil && f.exclude == nil && f.sinceLine <= 1 && !f.lineNumbers {
+		return logs
+	}
+
+	lines := strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+	width := len(strconv.Itoa(len(lines)))
+	keep := f.selectLines(lines)
+	withContext := f.before > 0 || f.after > 0
+	kept := make([]string, 0, len(lines))
+	last := -1
+	for i, line := range lines {
+		if !keep[i] {
+			continue
+		}
+		if withContext && last >= 0 && i > last+1 {
+			kept = append(kept, "--")
+		}
+		last = i
+		if f.lineNumbers {
+			line = fmt.Sprintf("%*d  %s", width, i+1, line)
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// selectLines reports which lines apply keeps: the lines passing the
+// filters, widened by the before and after context. Overlapping windows
+// merge; context never reaches back before sinceLine.
+func (f logFilter) selectLines(lines []string) []bool {
+	matched := make([]bool, len(lines))
+	for i, line := range lines {
+		if i+1 < f.sinceLine {
+			continue
+		}
+		if f.grep != nil && !f.grep.MatchString(line) {
+			continue
+		}
+		if f.exclude != nil && f.exclude.MatchString(line) {
+			continue
+		}
+		matched[i] = true
+	}
+	if f.before == 0 && f.after == 0 {
+		return matched
+	}
+
+	keep := make([]bool, len(lines))
+	first := max(f.sinceLine-1, 0)
+	for i, m := range matched {
+		if !m {
+			continue
+		}
+		for j := max(i-f.before, first); j <= min(i+f.after, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+	return keep
+}
@@ -0,0 +1,468 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestLogFilter_Apply(t *testing.T) {
+	logs := strings.Join([]string{
+		"I0101 starting server",
+		"E0101 failed to connect to etcd",
+		"I0101 GET /healthz 200",
+		"E0101 GET /healthz failed",
+		"W0101 slow request",
+		"E0101 watch error",
+	}, "\n") + "\n"
+
+	tests := []struct {
+		name      string
+		grep      string
+		exclude   string
+		sinceLine int
+		want      []string
+	}{
+		{
+			name: "no filter is set",
+			want: nil,
+		},
+		{
+			name: "grep is set",
+			grep: "^E",
+			want: []string{"E0101 failed to connect to etcd", "E0101 GET /healthz failed", "E0101 watch error"},
+		},
+		{
+			name:    "exclude is set",
+			exclude: "healthz",
+			want:    []string{"I0101 starting server", "E0101 failed to connect to etcd", "W0101 slow request", "E0101 watch error"},
+		},
+		{
+			name:    "grep and exclude both match a line",
+			grep:    "fail|error",
+			exclude: "healthz",
+			want:    []string{"E0101 failed to connect to etcd", "E0101 watch error"},
+		},
+		{
+			name:      "since-line is set",
+			sinceLine: 5,
+			want:      []string{"W0101 slow request", "E0101 watch error"},
+		},
+		{
+			name:      "since-line and grep are set",
+			grep:      "^E",
+			sinceLine: 3,
+			want:      []string{"E0101 GET /healthz failed", "E0101 watch error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newLogFilter(tt.grep, tt.exclude, tt.sinceLine)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := f.apply(logs)
+			want := logs
+			if tt.want != nil {
+				want = strings.Join(tt.want, "\n")
+			}
+			if got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestLogFilter_Context(t *testing.T) {
+	// Matches on lines 2, 4 and 9.
+	logs := strings.Join([]string{"l1", "l2 ERR", "l3", "l4 ERR", "l5", "l6", "l7", "l8", "l9 ERR", "l10"}, "\n") + "\n"
+
+	tests := []struct {
+		name          string
+		before, after int
+		sinceLine     int
+		want          []string
+	}{
+		{
+			name:   "before context is set",
+			before: 1,
+			want:   []string{"l1", "l2 ERR", "l3", "l4 ERR", "--", "l8", "l9 ERR"},
+		},
+		{
+			name:  "after context is set",
+			after: 1,
+			want:  []string{"l2 ERR", "l3", "l4 ERR", "l5", "--", "l9 ERR", "l10"},
+		},
+		{
+			name:   "windows overlap",
+			before: 2,
+			after:  2,
+			want:   []string{"l1", "l2 ERR", "l3", "l4 ERR", "l5", "l6", "l7", "l8", "l9 ERR", "l10"},
+		},
+		{
+			name:   "around context leaves a gap",
+			before: 1,
+			after:  1,
+			want:   []string{"l1", "l2 ERR", "l3", "l4 ERR", "l5", "--", "l8", "l9 ERR", "l10"},
+		},
+		{
+			name:      "since-line is set",
+			before:    2,
+			sinceLine: 4,
+			want:      []string{"l4 ERR", "--", "l7", "l8", "l9 ERR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newLogFilter("ERR", "", tt.sinceLine)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.before, f.after = tt.before, tt.after
+			if got := f.apply(logs); got != strings.Join(tt.want, "\n") {
+				t.Errorf("got:\n%s\nwant:\n%s", got, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestNewLogFilter_InvalidPattern(t *testing.T) {
+	if _, err := newLogFilter("(", "", 0); err == nil || !strings.Contains(err.Error(), "--grep") {
+		t.Errorf("expected --grep error, got %v", err)
+	}
+	if _, err := newLogFilter("", "[", 0); err == nil || !strings.Contains(err.Error(), "--exclude") {
+		t.Errorf("expected --exclude error, got %v", err)
+	}
+}
+
+func TestPrintLogs_LineNumbers(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 10; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	result := map[string]interface{}{"status": "success", "logs": strings.Join(lines, "\n") + "\n"}
+
+	t.Run("line numbers are enabled", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := printLogs(&buf, output.FormatText, result, logFilter{lineNumbers: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(got) != 10 {
+			t.Fatalf("expected 10 lines, got %d:\n%s", len(got), buf.String())
+		}
+		if got[0] != " 1  line 1" || got[9] != "10  line 10" {
+			t.Errorf("unexpected prefixes: first=%q last=%q", got[0], got[9])
+		}
+	})
+
+	t.Run("filtering", func(t *testing.T) {
+		f, err := newLogFilter("line [37]$", "", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.lineNumbers = true
+
+		var buf bytes.Buffer
+		if err := printLogs(&buf, output.FormatText, result, f); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := " 3  line 3\n 7  line 7\n"; buf.String() != want {
+			t.Errorf("got %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("output is JSON", func(t *testing.T) {
+		f, err := newLogFilter("line 1$", "", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.lineNumbers = true
+
+		var buf bytes.Buffer
+		if err := printLogs(&buf, output.FormatJSON, result, f); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if got["logs"] != result["logs"] {
+			t.Errorf("logs were modified in JSON mode: %q", got["logs"])
+		}
+	})
+}
+
+func TestContainerRegex_MatchesTwoOfThree(t *testing.T) {
+	required := map[string]interface{}{
+		"status":               "container_required",
+		"available_containers": []interface{}{"etcd", "etcd-metrics", "healthz"},
+	}
+
+	containers, err := matchingContainers(required, regexp.MustCompile(`^etcd`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"etcd", "etcd-metrics"}; !reflect.DeepEqual(containers, want) {
+		t.Fatalf("containers = %v, want %v", containers, want)
+	}
+
+	var requested []string
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"logs": func(args map[string]interface{}) *workflows.ExecutionResult {
+			name := args["container"].(string)
+			requested = append(requested, name)
+			if args["pod"] != "etcd-0" || args["tail_lines"] != 50 {
+				t.Errorf("shared args not passed through: %v", args)
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"logs": fmt.Sprintf("%s line 1\n%s line 2\n", name, name),
+			}}
+		},
+	}}
+
+	data := map[string]interface{}{"namespace": "ns", "pod": "etcd-0", "tail_lines": 50}
+	blocks, err := fetchContainerLogs(context.Background(), runner, "logs", data, containers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(requested, containers) {
+		t.Errorf("requested containers = %v, want %v", requested, containers)
+	}
+	if _, ok := data["container"]; ok {
+		t.Error("shared args should not be modified")
+	}
+
+	var buf bytes.Buffer
+	if err := printContainerLogs(&buf, output.FormatText, blocks, logFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "[etcd] etcd line 1\n[etcd] etcd line 2\n[etcd-metrics] etcd-metrics line 1\n[etcd-metrics] etcd-metrics line 2\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestContainerRegex_NoMatch(t *testing.T) {
+	required := map[string]interface{}{
+		"status":               "container_required",
+		"available_containers": []interface{}{"etcd", "healthz"},
+	}
+
+	_, err := matchingContainers(required, regexp.MustCompile(`^kube`))
+	if err == nil || !strings.Contains(err.Error(), "available: etcd, healthz") {
+		t.Errorf("expected no-match error listing containers, got %v", err)
+	}
+}
+
+func TestParseLogsResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result map[string]interface{}
+		want   logsResult
+		wantOK bool
+	}{
+		{
+			name: "workflow returns metadata",
+			result: map[string]interface{}{
+				"logs":       "a\nb\n",
+				"container":  "etcd",
+				"truncated":  true,
+				"line_count": float64(2),
+				"bytes":      float64(4),
+			},
+			want:   logsResult{Logs: "a\nb\n", Container: "etcd", Truncated: true, LineCount: 2, Bytes: 4},
+			wantOK: true,
+		},
+		{
+			name:   "only logs are returned",
+			result: map[string]interface{}{"logs": "ready\n"},
+			want:   logsResult{Logs: "ready\n"},
+			wantOK: true,
+		},
+		{
+			name:   "metadata field has the wrong type",
+			result: map[string]interface{}{"logs": "ready\n", "line_count": "many"},
+			want:   logsResult{Logs: "ready\n"},
+			wantOK: true,
+		},
+		{
+			name:   "there are no logs",
+			result: map[string]interface{}{"status": "container_required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLogsResult(tt.result)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseLogsResult() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLogsResult_Summary(t *testing.T) {
+	tests := []struct {
+		name string
+		logs logsResult
+		want string
+	}{
+		{
+			name: "only logs are known",
+			logs: logsResult{Logs: "a\nb\nc\n"},
+			want: "(fetched 6 B, 3 lines)",
+		},
+		{
+			name: "logs are empty",
+			want: "(fetched 0 B, 0 lines)",
+		},
+		{
+			name: "last line has no newline",
+			logs: logsResult{Logs: "a\nb\nc"},
+			want: "(fetched 5 B, 3 lines)",
+		},
+		{
+			name: "logs are large",
+			logs: logsResult{Logs: strings.Repeat("x", 12594) + "\n"},
+			want: "(fetched 12.3 KiB, 1 line)",
+		},
+		{
+			name: "workflow reports counts",
+			logs: logsResult{Logs: "a\n", LineCount: 1000, Bytes: 65536},
+			want: "(fetched 64.0 KiB, 1000 lines)",
+		},
+		{
+			name: "container is known",
+			logs: logsResult{Logs: "ready\n", Container: "etcd"},
+			want: "(fetched 6 B, 1 line from etcd)",
+		},
+		{
+			name: "output was truncated",
+			logs: logsResult{Logs: "a\n", Container: "etcd", Truncated: true, LineCount: 500, Bytes: 2048},
+			want: "(fetched 2.0 KiB, 500 lines from etcd; truncated)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.logs.summary(); got != tt.want {
+				t.Errorf("summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerLogsSummaries(t *testing.T) {
+	blocks := []containerLogs{
+		{Container: "etcd", Result: map[string]interface{}{"logs": "a\nb\n", "truncated": true, "line_count": float64(500), "bytes": float64(2048)}},
+		{Container: "healthz", Result: map[string]interface{}{"logs": "ok\n", "container": "healthz-v2"}},
+		{Container: "metrics", Result: map[string]interface{}{"status": "error"}},
+	}
+	want := []string{
+		"(fetched 2.0 KiB, 500 lines from etcd; truncated)",
+		"(fetched 3 B, 1 line from healthz-v2)",
+	}
+	if got := containerLogsSummaries(blocks); !reflect.DeepEqual(got, want) {
+		t.Errorf("containerLogsSummaries() = %q, want %q", got, want)
+	}
+}
+
+func describedPod(annotations map[string]interface{}, containers ...string) map[string]interface{} {
+	specs := make([]interface{}, len(containers))
+	for i, name := range containers {
+		specs[i] = map[string]interface{}{"name": name}
+	}
+	return map[string]interface{}{
+		"resource": map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "p", "annotations": annotations},
+			"spec":     map[string]interface{}{"containers": specs},
+		},
+	}
+}
+
+func TestPickContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]interface{}
+		want       string
+		wantReason string
+		wantErr    string
+	}{
+		{
+			name:       "pod has one container",
+			data:       describedPod(nil, "etcd"),
+			want:       "etcd",
+			wantReason: "only container",
+		},
+		{
+			name:       "exactly one container is not a sidecar",
+			data:       describedPod(nil, "kube-apiserver", "konnectivity-server", "audit-logs", "kube-rbac-proxy"),
+			want:       "kube-apiserver",
+			wantReason: "only non-sidecar container",
+		},
+		{
+			name:       "default-container annotation is set",
+			data:       describedPod(map[string]interface{}{defaultContainerAnnotation: "etcd-metrics"}, "etcd", "etcd-metrics"),
+			want:       "etcd-metrics",
+			wantReason: "default-container annotation",
+		},
+		{
+			name:    "several app containers remain",
+			data:    describedPod(nil, "etcd", "etcd-metrics", "envoy"),
+			wantErr: "several app containers (etcd, etcd-metrics, envoy)",
+		},
+		{
+			name:    "describe returned no resource",
+			data:    map[string]interface{}{"error": "not found"},
+			wantErr: "no pod p",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason, err := pickContainer(tt.data, "p")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("pickContainer() = %q (%s), want %q (%s)", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAutoSelectContainer(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"gcphcp-describe": func(args map[string]interface{}) *workflows.ExecutionResult {
+			if args["resource_type"] != "pods" || args["name"] != "kube-apiserver-0" || args["namespace"] != "ns" {
+				t.Errorf("unexpected describe args: %v", args)
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: describedPod(nil, "kube-apiserver", "audit-logs")}
+		},
+	}}
+
+	got, _, err := autoSelectContainer(context.Background(), runner, "gcphcp-describe", "ns", "kube-apiserver-0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "kube-apiserver" {
+		t.Errorf("container = %q, want kube-apiserver", got)
+	}
+}
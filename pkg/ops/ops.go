@@ -23,21 +23,36 @@ func NewOpsCmd() *cobra.Command {
 		Long: `Operational commands for debugging, log analysis, and remediation
 of GKE-hosted OpenShift control plane clusters.
 
-Convenience commands (get, logs, describe) run Cloud Workflows under the hood.
+Convenience commands (get, logs, exec, describe) run Cloud Workflows under the hood.
 Use 'ops wf' for direct workflow management.`,
 	}
 
 	cmd.AddCommand(newGetCmd())
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newExecCmd())
 	cmd.AddCommand(newDescribeCmd())
+	cmd.AddCommand(newAnalyzeCmd())
+	cmd.AddCommand(newSnapshotCmd())
+	cmd.AddCommand(newDiffCmd())
 	cmd.AddCommand(newDiagnoseCmd())
 	cmd.AddCommand(newDeleteCmd())
 	cmd.AddCommand(newExpandVolumeCmd())
 	cmd.AddCommand(newEtcdCmd())
 	cmd.AddCommand(newRolloutRestartCmd())
+	cmd.AddCommand(newRestartCmd())
+	cmd.AddCommand(newScaleCmd())
+	cmd.AddCommand(newDoctorCmd())
+	cmd.AddCommand(newWaitCmd())
 	cmd.AddCommand(wf.NewWfCmd())
 	cmd.AddCommand(pam.NewPamCmd())
 	cmd.AddCommand(companion.NewCompanionCmd())
 
 	return cmd
 }
+
+// workflowPrefix returns the --workflow-prefix value, which is prepended to
+// the workflow names used by the convenience commands.
+func workflowPrefix(cmd *cobra.Command) string {
+	prefix, _ := cmd.Flags().GetString("workflow-prefix")
+	return prefix
+}
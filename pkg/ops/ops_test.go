@@ -1,6 +1,7 @@
 package ops
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -79,10 +80,33 @@ func TestNewOpsCmd(t *testing.T) {
 		subcommands[sub.Name()] = true
 	}
 
-	expected := []string{"get", "logs", "describe", "diagnose", "delete", "expand-volume", "etcd", "rollout-restart", "wf", "pam"}
+	expected := []string{"get", "logs", "describe", "diagnose", "delete", "expand-volume", "etcd", "rollout-restart", "doctor", "analyze", "wf", "pam"}
 	for _, name := range expected {
 		if !subcommands[name] {
 			t.Errorf("expected subcommand %q not found", name)
 		}
 	}
 }
+
+func TestNewOpsCmd_OutputFile(t *testing.T) {
+	cmd := NewOpsCmd()
+
+	if cmd.PersistentFlags().Lookup("output-file") != nil {
+		t.Error("-O should be registered by the commands that write through it, not globally")
+	}
+
+	for _, path := range []string{
+		"get", "describe", "logs", "diff", "analyze", "exec", "delete", "restart", "scale",
+		"rollout-restart", "expand-volume", "etcd health", "etcd compact",
+		"wf run", "wf logs", "wf list", "wf status", "wf recent", "wf cancel", "wf resume",
+	} {
+		sub, _, err := cmd.Find(strings.Fields(path))
+		if err != nil || sub == cmd {
+			t.Errorf("command %q not found: %v", path, err)
+			continue
+		}
+		if f := sub.Flag("output-file"); f == nil || f.Shorthand != "O" {
+			t.Errorf("%q does not register -O/--output-file", path)
+		}
+	}
+}
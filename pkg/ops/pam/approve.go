@@ -7,6 +7,7 @@ import (
 	"time"
 
 	pamclient "github.com/ckandag/gcp-hcp-cli/pkg/gcp/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/spf13/cobra"
 )
 
@@ -58,14 +59,14 @@ Examples:
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Approving grant...\n")
+			progress.Printf(cmd, "Approving grant...\n")
 
 			grant, err := client.ApproveGrant(ctx, grantName, reason)
 			if err != nil {
 				return fmt.Errorf("approving grant: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Grant approved: %s (state: %s)\n", grant.ShortName(), grant.State)
+			fmt.Fprintf(progress.Stderr(cmd), "Grant approved: %s (state: %s)\n", grant.ShortName(), grant.State)
 
 			return printGrantResult(os.Stdout, outputFormat, grant)
 		},
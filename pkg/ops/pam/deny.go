@@ -7,6 +7,7 @@ import (
 	"time"
 
 	pamclient "github.com/ckandag/gcp-hcp-cli/pkg/gcp/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/spf13/cobra"
 )
 
@@ -55,14 +56,14 @@ Examples:
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Denying grant...\n")
+			progress.Printf(cmd, "Denying grant...\n")
 
 			grant, err := client.DenyGrant(ctx, grantName, reason)
 			if err != nil {
 				return fmt.Errorf("denying grant: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Grant denied: %s (state: %s)\n", grant.ShortName(), grant.State)
+			fmt.Fprintf(progress.Stderr(cmd), "Grant denied: %s (state: %s)\n", grant.ShortName(), grant.State)
 
 			return printGrantResult(os.Stdout, outputFormat, grant)
 		},
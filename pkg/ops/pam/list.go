@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pamclient "github.com/ckandag/gcp-hcp-cli/pkg/gcp/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -83,7 +84,7 @@ Examples:
 					return fmt.Errorf("searching entitlements: %w", err)
 				}
 				if len(ents) == 0 {
-					fmt.Fprintln(os.Stderr, "No PAM entitlements found for your account.")
+					fmt.Fprintln(progress.Stderr(cmd), "No PAM entitlements found for your account.")
 					return nil
 				}
 				for _, e := range ents {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	pamclient "github.com/ckandag/gcp-hcp-cli/pkg/gcp/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -74,22 +75,22 @@ Examples:
 				}
 			}
 
-			fmt.Fprintf(os.Stderr, "Requesting PAM grant for entitlement: %s\n", pamclient.ShortEntitlementName(entitlementName))
-			fmt.Fprintf(os.Stderr, "Duration: %s  Reason: %s\n", duration, reason)
+			progress.Printf(cmd, "Requesting PAM grant for entitlement: %s\n", pamclient.ShortEntitlementName(entitlementName))
+			progress.Printf(cmd, "Duration: %s  Reason: %s\n", duration, reason)
 
 			grant, err := client.CreateGrant(ctx, entitlementName, duration, reason)
 			if err != nil {
 				return fmt.Errorf("requesting grant: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Grant created: %s (state: %s)\n", grant.ShortName(), grant.State)
+			fmt.Fprintf(progress.Stderr(cmd), "Grant created: %s (state: %s)\n", grant.ShortName(), grant.State)
 
 			if !wait || grant.State != "APPROVAL_AWAITED" {
 				return printGrantResult(os.Stdout, outputFormat, grant)
 			}
 
-			fmt.Fprintf(os.Stderr, "Waiting for approval... (Ctrl+C to cancel)\n")
-			fmt.Fprintf(os.Stderr, "  Check status: gcphcp ops pam status %s\n", grant.Name)
+			progress.Printf(cmd, "Waiting for approval... (Ctrl+C to cancel)\n")
+			progress.Printf(cmd, "  Check status: gcphcp ops pam status %s\n", grant.Name)
 
 			grant, err = client.WaitForGrant(ctx, grant.Name)
 			if err != nil {
@@ -98,13 +99,13 @@ Examples:
 
 			switch grant.State {
 			case "ACTIVE", "ACTIVATED":
-				fmt.Fprintf(os.Stderr, "Grant approved and active!\n")
+				fmt.Fprintf(progress.Stderr(cmd), "Grant approved and active!\n")
 			case "DENIED":
-				fmt.Fprintf(os.Stderr, "Grant was denied.\n")
+				fmt.Fprintf(progress.Stderr(cmd), "Grant was denied.\n")
 			case "EXPIRED":
-				fmt.Fprintf(os.Stderr, "Grant expired before approval.\n")
+				fmt.Fprintf(progress.Stderr(cmd), "Grant expired before approval.\n")
 			default:
-				fmt.Fprintf(os.Stderr, "Grant state: %s\n", grant.State)
+				fmt.Fprintf(progress.Stderr(cmd), "Grant state: %s\n", grant.State)
 			}
 
 			return printGrantResult(os.Stdout, outputFormat, grant)
@@ -160,11 +161,11 @@ func discoverEntitlement(ctx context.Context, client *pamclient.Client) (string,
 			"  Check with your administrator.")
 	}
 	if len(entitlements) > 1 {
-		fmt.Fprintf(os.Stderr, "Multiple entitlements available:\n")
+		var list strings.Builder
 		for _, e := range entitlements {
-			fmt.Fprintf(os.Stderr, "  - %s (max: %s)\n", pamclient.ShortEntitlementName(e.Name), e.MaxDuration)
+			fmt.Fprintf(&list, "\n  - %s (max: %s)", pamclient.ShortEntitlementName(e.Name), e.MaxDuration)
 		}
-		return "", fmt.Errorf("multiple entitlements found; specify one as an argument")
+		return "", fmt.Errorf("multiple entitlements found; specify one as an argument:%s", list.String())
 	}
 	return entitlements[0].Name, nil
 }
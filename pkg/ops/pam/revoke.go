@@ -7,6 +7,7 @@ import (
 	"time"
 
 	pamclient "github.com/ckandag/gcp-hcp-cli/pkg/gcp/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/spf13/cobra"
 )
 
@@ -57,14 +58,14 @@ Examples:
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Revoking grant...\n")
+			progress.Printf(cmd, "Revoking grant...\n")
 
 			grant, err := client.RevokeGrant(ctx, grantName, reason)
 			if err != nil {
 				return fmt.Errorf("revoking grant: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Grant revoked: %s (state: %s)\n", grant.ShortName(), grant.State)
+			fmt.Fprintf(progress.Stderr(cmd), "Grant revoked: %s (state: %s)\n", grant.ShortName(), grant.State)
 
 			return printGrantResult(os.Stdout, outputFormat, grant)
 		},
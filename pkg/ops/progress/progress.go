@@ -0,0 +1,182 @@
+// Package progress writes informational status lines such as
+// "Getting pods (ns: foo)" to stderr, and drops them under --quiet so
+// automation logs only carry results and real errors. With --log-format json
+// the lines are emitted as structured JSON log records instead.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a context whose commands send their stderr messages to
+// logger instead of writing plain text.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger stored by ContextWithLogger, or nil.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if ctx == nil {
+		return nil
+	}
+	logger, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+	return logger
+}
+
+// NewJSONLogger returns a logger that writes one JSON object per message to
+// w, e.g. {"time":"...","level":"info","msg":"Getting pods","workflow":"get"}.
+func NewJSONLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey {
+				a.Value = slog.StringValue(strings.ToLower(a.Value.String()))
+			}
+			return a
+		},
+	}))
+}
+
+// With adds attributes, such as the workflow name, to the JSON records of
+// cmd's later messages. It does nothing in text mode.
+func With(cmd *cobra.Command, args ...any) {
+	if logger := LoggerFromContext(cmd.Context()); logger != nil {
+		cmd.SetContext(ContextWithLogger(cmd.Context(), logger.With(args...)))
+	}
+}
+
+// Quiet reports whether --quiet was set for cmd.
+func Quiet(cmd *cobra.Command) bool {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	return quiet
+}
+
+// Writer returns the destination for cmd's progress output: Stderr(cmd), or
+// io.Discard under --quiet.
+func Writer(cmd *cobra.Command) io.Writer {
+	if Quiet(cmd) {
+		return io.Discard
+	}
+	return Stderr(cmd)
+}
+
+// Stderr returns the destination for messages that --quiet keeps, such as an
+// execution ID: cmd's stderr, or an info-level log writer in JSON mode.
+func Stderr(cmd *cobra.Command) io.Writer {
+	return levelWriter(cmd, slog.LevelInfo)
+}
+
+// Terminal returns cmd's stderr when progress output goes there as plain
+// text to an interactive terminal, for output such as spinners that rewrite
+// a line in place. It returns nil under --quiet, in JSON mode, or when stderr
+// is redirected.
+func Terminal(cmd *cobra.Command) io.Writer {
+	if Quiet(cmd) || LoggerFromContext(cmd.Context()) != nil {
+		return nil
+	}
+	if f, ok := cmd.ErrOrStderr().(*os.File); ok && IsTerminal(f) {
+		return f
+	}
+	return nil
+}
+
+// IsTerminal reports whether f is an interactive terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Printf writes a progress message to Writer(cmd).
+func Printf(cmd *cobra.Command, format string, args ...interface{}) {
+	fmt.Fprintf(Writer(cmd), format, args...)
+}
+
+// Warnf writes a warning to cmd's stderr, or logs it at warn level in JSON
+// mode. Warnings are kept under --quiet.
+func Warnf(cmd *cobra.Command, format string, args ...interface{}) {
+	fmt.Fprintf(WarnWriter(cmd), format, args...)
+}
+
+// WarnWriter returns the destination Warnf writes to, for helpers that take
+// an io.Writer for their warnings.
+func WarnWriter(cmd *cobra.Command) io.Writer {
+	return levelWriter(cmd, slog.LevelWarn)
+}
+
+// DebugWriter returns the destination for -v request logging: cmd's stderr
+// with each message prefixed "[debug] ", or a debug-level log writer in JSON
+// mode.
+func DebugWriter(cmd *cobra.Command) io.Writer {
+	if logger := LoggerFromContext(cmd.Context()); logger != nil {
+		return &logWriter{ctx: cmd.Context(), logger: logger, level: slog.LevelDebug}
+	}
+	return &prefixWriter{w: cmd.ErrOrStderr(), prefix: "[debug] "}
+}
+
+func levelWriter(cmd *cobra.Command, level slog.Level) io.Writer {
+	if logger := LoggerFromContext(cmd.Context()); logger != nil {
+		return &logWriter{ctx: cmd.Context(), logger: logger, level: level}
+	}
+	return cmd.ErrOrStderr()
+}
+
+// logWriter turns each write into one log record, with surrounding blank
+// lines and indentation trimmed. Writes with no text are dropped.
+type logWriter struct {
+	ctx    context.Context
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	if msg := strings.TrimSpace(string(p)); msg != "" {
+		w.logger.Log(w.ctx, w.level, msg)
+	}
+	return len(p), nil
+}
+
+// prefixWriter writes prefix before each write.
+type prefixWriter struct {
+	w      io.Writer
+	prefix string
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.prefix); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
+// SetLogFormat applies --log-format to cmd: "text" (or empty) keeps plain
+// stderr lines, "json" switches cmd's messages to JSON log records.
+func SetLogFormat(cmd *cobra.Command, format string) error {
+	switch format {
+	case "", "text":
+		return nil
+	case "json":
+		cmd.SetContext(ContextWithLogger(cmd.Context(), NewJSONLogger(cmd.ErrOrStderr())))
+		return nil
+	default:
+		return fmt.Errorf("invalid --log-format %q (supported: text, json)", format)
+	}
+}
+
+// PrintError writes a command's final error to w, as an error-level JSON
+// record when format is "json".
+func PrintError(w io.Writer, format string, err error) {
+	if format == "json" {
+		NewJSONLogger(w).Error(err.Error())
+		return
+	}
+	fmt.Fprintln(w, err)
+}
@@ -0,0 +1,199 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// captureStderr returns what fn writes to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = orig }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestPrintf(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "--quiet is not set",
+			want: "Getting pods (ns: foo)\n",
+		},
+		{
+			name: "--quiet is set",
+			args: []string{"--quiet"},
+		},
+		{
+			name: "-q is set",
+			args: []string{"-q"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "root"}
+			root.PersistentFlags().BoolP("quiet", "q", false, "")
+			root.AddCommand(&cobra.Command{
+				Use: "get",
+				Run: func(cmd *cobra.Command, _ []string) {
+					Printf(cmd, "Getting pods (ns: %s)\n", "foo")
+				},
+			})
+			root.SetArgs(append([]string{"get"}, tt.args...))
+
+			got := captureStderr(t, func() {
+				if err := root.Execute(); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+			if got != tt.want {
+				t.Errorf("stderr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintf_JSONLogFormat(t *testing.T) {
+	root := &cobra.Command{
+		Use: "root",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return SetLogFormat(cmd, "json")
+		},
+	}
+	root.PersistentFlags().BoolP("quiet", "q", false, "")
+	root.AddCommand(&cobra.Command{
+		Use: "get",
+		Run: func(cmd *cobra.Command, _ []string) {
+			With(cmd, "workflow", "get")
+			Printf(cmd, "Getting pods (ns: %s)\n", "foo")
+			Printf(cmd, "\n")
+			Warnf(cmd, "Warning: getting pods in namespace %s: denied\n", "bar")
+		},
+	})
+	root.SetArgs([]string{"get"})
+
+	got := captureStderr(t, func() {
+		if err := root.Execute(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d: %q", len(lines), got)
+	}
+	want := []map[string]string{
+		{"level": "info", "msg": "Getting pods (ns: foo)", "workflow": "get"},
+		{"level": "warn", "msg": "Warning: getting pods in namespace bar: denied", "workflow": "get"},
+	}
+	for i, line := range lines {
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		for k, v := range want[i] {
+			if rec[k] != v {
+				t.Errorf("line %d: %s = %v, want %q", i, k, rec[k], v)
+			}
+		}
+		if _, ok := rec["time"]; !ok {
+			t.Errorf("line %d: missing time", i)
+		}
+	}
+}
+
+func TestSetLogFormat_Invalid(t *testing.T) {
+	if err := SetLogFormat(&cobra.Command{}, "xml"); err == nil || !strings.Contains(err.Error(), "supported: text, json") {
+		t.Errorf("expected an unsupported format error, got %v", err)
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	var buf bytes.Buffer
+	PrintError(&buf, "json", errors.New("--project is required"))
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil || rec["level"] != "error" || rec["msg"] != "--project is required" {
+		t.Errorf("unexpected error record %q", buf.String())
+	}
+
+	buf.Reset()
+	PrintError(&buf, "text", errors.New("--project is required"))
+	if buf.String() != "--project is required\n" {
+		t.Errorf("unexpected text error %q", buf.String())
+	}
+}
+
+func TestDebugWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		logFormat string
+		check     func(t *testing.T, got string)
+	}{
+		{
+			name:      "text",
+			logFormat: "text",
+			check: func(t *testing.T, got string) {
+				if got != "[debug] executing get\n" {
+					t.Errorf("got %q", got)
+				}
+			},
+		},
+		{
+			name:      "json",
+			logFormat: "json",
+			check: func(t *testing.T, got string) {
+				var rec map[string]interface{}
+				if err := json.Unmarshal([]byte(got), &rec); err != nil {
+					t.Fatalf("not a JSON record: %q", got)
+				}
+				if rec["level"] != "debug" || rec["msg"] != "executing get" {
+					t.Errorf("unexpected record %v", rec)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{
+				Use: "root",
+				PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+					return SetLogFormat(cmd, tt.logFormat)
+				},
+				Run: func(cmd *cobra.Command, _ []string) {
+					io.WriteString(DebugWriter(cmd), "executing get\n")
+				},
+			}
+			root.SetArgs([]string{})
+
+			tt.check(t, captureStderr(t, func() {
+				if err := root.Execute(); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}))
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// restartWorkflow patches the pod template's restartedAt annotation of a
+// workload, like kubectl rollout restart.
+const restartWorkflow = "restart"
+
+// restartableTypes are the workload types restart accepts.
+var restartableTypes = []string{"deployments", "statefulsets", "daemonsets"}
+
+func newRestartCmd() *cobra.Command {
+	var (
+		namespace string
+		confirm   bool
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restart <type>/<name>",
+		Short: "Trigger a rollout restart of a workload via Cloud Workflows",
+		Long: `Trigger a rollout restart of a deployment, statefulset or daemonset.
+The restart workflow sets the pod template annotation
+kubectl.kubernetes.io/restartedAt server-side, so the controller replaces
+the pods gradually.
+
+Restarting replaces running pods, so --confirm is required.
+
+Examples:
+  # Restart a deployment
+  gcphcp ops restart deploy/operator -n hypershift --confirm
+
+  # Restart a statefulset
+  gcphcp ops restart statefulsets/etcd -n clusters-abc123 --confirm
+
+  # Show the workflow call without running it
+  gcphcp ops restart ds/konnectivity-agent -n kube-system --dry-run`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resourceType, resourceName, err := parseRestartTarget(args[0])
+			if err != nil {
+				return err
+			}
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if namespace == "" {
+				return fmt.Errorf("--namespace is required")
+			}
+
+			data := restartArgs(resourceType, resourceName, namespace)
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				return printDryRun(cmd.OutOrStdout(), restartWorkflow, data)
+			}
+			if !confirm {
+				return fmt.Errorf("restarting %s/%s (ns: %s) replaces its pods; re-run with --confirm", resourceType, resourceName, namespace)
+			}
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			if err := checkPAMGate(ctx, client, restartWorkflow, cmd, progress.Stderr(cmd)); err != nil {
+				return err
+			}
+
+			progress.Printf(cmd, "Restarting %s %s (ns: %s)\n", resourceType, resourceName, namespace)
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			return runRestart(ctx, client, data, resourceType, resourceName, output.ParseFormat(outputFormat), out)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	_ = cmd.MarkFlagRequired("namespace")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm the restart; required because running pods are replaced")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+// runRestart runs the restart workflow and writes the raw result (JSON) or
+// a one-line summary to w. A FAILED execution or an error status in the
+// result fails the command.
+func runRestart(ctx context.Context, runner workflowRunner, data map[string]interface{}, resourceType, name string, format output.Format, w io.Writer) error {
+	_, result, err := runner.Run(ctx, restartWorkflow, data)
+	if err != nil {
+		return workflowRunError(err)
+	}
+	if result.State == "FAILED" {
+		return wf.WorkflowFailure(w, format, result.Error)
+	}
+
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, result.Result)
+	}
+
+	if output.GetString(result.Result, "status") == "error" {
+		errMsg := output.GetString(result.Result, "error")
+		return fmt.Errorf("failed to restart %s/%s: %s", resourceType, name, errMsg)
+	}
+
+	fmt.Fprintln(w, restartSummary(resourceType, name, result.Result))
+	return nil
+}
+
+// parseRestartTarget splits a "<type>/<name>" argument such as
+// "deploy/operator", expanding type aliases.
+func parseRestartTarget(arg string) (resourceType, name string, err error) {
+	return parseWorkloadTarget(arg, "restart", restartableTypes)
+}
+
+// parseWorkloadTarget splits a "<type>/<name>" argument, expanding type
+// aliases, and checks that verb supports the type.
+func parseWorkloadTarget(arg, verb string, supported []string) (resourceType, name string, err error) {
+	resourceType, name, ok := strings.Cut(arg, "/")
+	if !ok || resourceType == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid target %q: expected <type>/<name>, e.g. deploy/operator", arg)
+	}
+	if expanded, ok := resourceTypeExpand[resourceType]; ok {
+		resourceType = expanded
+	}
+	if !slices.Contains(supported, resourceType) {
+		return "", "", fmt.Errorf("cannot %s %s: supported types are %s", verb, resourceType, strings.Join(supported, ", "))
+	}
+	return resourceType, name, nil
+}
+
+// restartArgs builds the restart workflow arguments.
+func restartArgs(resourceType, name, namespace string) map[string]interface{} {
+	return map[string]interface{}{
+		"resource_type": resourceType,
+		"name":          name,
+		"namespace":     namespace,
+	}
+}
+
+// restartSummary is the confirmation line for a finished restart, with the
+// workload's generation after the patch when the workflow returns it.
+func restartSummary(resourceType, name string, result map[string]interface{}) string {
+	summary := fmt.Sprintf("%s \"%s\" restarted", resourceType, name)
+	if gen, ok := result["generation"]; ok {
+		summary += fmt.Sprintf(" (generation: %v)", gen)
+	}
+	return summary
+}
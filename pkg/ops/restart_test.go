@@ -0,0 +1,98 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestParseRestartTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		wantType string
+		wantName string
+		wantErr  string
+	}{
+		{"type is an alias", "deploy/operator", "deployments", "operator", ""},
+		{"type is plural", "statefulsets/etcd", "statefulsets", "etcd", ""},
+		{"type is a daemonset alias", "ds/konnectivity-agent", "daemonsets", "konnectivity-agent", ""},
+		{"there is no slash", "operator", "", "", "expected <type>/<name>"},
+		{"name is empty", "deploy/", "", "", "expected <type>/<name>"},
+		{"name has a slash", "deploy/a/b", "", "", "expected <type>/<name>"},
+		{"type cannot be restarted", "po/my-pod", "", "", "cannot restart pods"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotName, err := parseRestartTarget(tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotType != tt.wantType || gotName != tt.wantName {
+				t.Errorf("got %s/%s, want %s/%s", gotType, gotName, tt.wantType, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestRestartArgs(t *testing.T) {
+	got := restartArgs("deployments", "operator", "hypershift")
+	want := map[string]interface{}{
+		"resource_type": "deployments",
+		"name":          "operator",
+		"namespace":     "hypershift",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRestartSummary(t *testing.T) {
+	got := restartSummary("deployments", "operator", map[string]interface{}{"status": "ok", "generation": float64(7)})
+	if want := `deployments "operator" restarted (generation: 7)`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got = restartSummary("deployments", "operator", map[string]interface{}{"status": "ok"})
+	if want := `deployments "operator" restarted`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRestartCmd_RequiresConfirm(t *testing.T) {
+	cmd := newRestartCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"deploy/operator", "-n", "hypershift"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--confirm") {
+		t.Errorf("expected a --confirm error, got %v", err)
+	}
+}
+
+func TestRunRestart_Failed(t *testing.T) {
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		restartWorkflow: func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "FAILED", Error: "patch denied"}
+		},
+	}}
+	var out bytes.Buffer
+	err := runRestart(context.Background(), runner, restartArgs("deployments", "operator", "hypershift"), "deployments", "operator", output.FormatText, &out)
+	if err == nil || !strings.Contains(err.Error(), "patch denied") {
+		t.Fatalf("expected the workflow failure, got %v", err)
+	}
+	if strings.Contains(out.String(), "restarted") {
+		t.Errorf("a failed restart should not be reported as done: %q", out.String())
+	}
+}
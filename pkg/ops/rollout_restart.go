@@ -1,12 +1,12 @@
 package ops
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -48,6 +48,7 @@ Examples:
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -65,7 +66,7 @@ Examples:
 				"name":          resourceName,
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -74,20 +75,26 @@ Examples:
 			}
 			defer client.Close()
 
-			if err := checkPAMGate(ctx, client, "rollout", cmd, os.Stderr); err != nil {
+			if err := checkPAMGate(ctx, client, "rollout", cmd, progress.Stderr(cmd)); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "Rolling restart %s %s (ns: %s)\n", resourceType, resourceName, namespace)
+			progress.Printf(cmd, "Rolling restart %s %s (ns: %s)\n", resourceType, resourceName, namespace)
 
 			_, result, err := client.Run(ctx, "rollout", data)
 			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+				return workflowRunError(err)
 			}
 
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
 			format := output.ParseFormat(outputFormat)
 			if format == output.FormatJSON {
-				return output.PrintJSON(os.Stdout, result.Result)
+				return output.PrintJSON(out, result.Result)
 			}
 
 			status := output.GetString(result.Result, "status")
@@ -97,7 +104,7 @@ Examples:
 			}
 
 			restartedAt := output.GetString(result.Result, "restarted_at")
-			fmt.Fprintf(os.Stdout, "%s \"%s\" rollout restart triggered (restarted_at: %s)\n", resourceType, resourceName, restartedAt)
+			fmt.Fprintf(out, "%s \"%s\" rollout restart triggered (restarted_at: %s)\n", resourceType, resourceName, restartedAt)
 			return nil
 		},
 	}
@@ -105,6 +112,7 @@ Examples:
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
 	_ = cmd.MarkFlagRequired("namespace")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 
 	return cmd
 }
@@ -0,0 +1,201 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// scaleWorkflow sets spec.replicas of a workload.
+const scaleWorkflow = "scale"
+
+// scalableTypes are the workload types scale accepts.
+var scalableTypes = []string{"deployments", "statefulsets", "replicasets"}
+
+// scalePreconditionFailed is the result status of the scale workflow when
+// --current-replicas did not match; nothing was changed.
+const scalePreconditionFailed = "precondition_failed"
+
+func newScaleCmd() *cobra.Command {
+	var (
+		namespace       string
+		replicas        int
+		currentReplicas int
+		timeout         time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scale <name | type/name> --replicas N",
+		Short: "Set the replica count of a workload via Cloud Workflows",
+		Long: `Set the replica count of a deployment, statefulset or replicaset.
+A bare name is a deployment.
+
+With --current-replicas, the workflow only scales if the workload currently
+has that many replicas, like kubectl scale --current-replicas.
+
+Examples:
+  # Scale a deployment to 3 replicas
+  gcphcp ops scale operator --replicas 3 -n hypershift
+
+  # Scale a statefulset down, only if it still has 3 replicas
+  gcphcp ops scale sts/etcd --replicas 0 --current-replicas 3 -n clusters-abc123`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resourceType, resourceName, err := parseScaleTarget(args[0])
+			if err != nil {
+				return err
+			}
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if namespace == "" {
+				return fmt.Errorf("--namespace is required")
+			}
+			if !cmd.Flags().Changed("replicas") {
+				return fmt.Errorf("--replicas is required")
+			}
+			data, err := scaleArgs(resourceType, resourceName, namespace, replicas, currentReplicas)
+			if err != nil {
+				return err
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				return printDryRun(cmd.OutOrStdout(), scaleWorkflow, data)
+			}
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			if err := checkPAMGate(ctx, client, scaleWorkflow, cmd, progress.Stderr(cmd)); err != nil {
+				return err
+			}
+
+			progress.Printf(cmd, "Scaling %s %s to %d replicas (ns: %s)\n", resourceType, resourceName, replicas, namespace)
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			return runScale(ctx, client, data, resourceType, resourceName, replicas, currentReplicas, output.ParseFormat(outputFormat), out)
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	_ = cmd.MarkFlagRequired("namespace")
+	cmd.Flags().IntVar(&replicas, "replicas", 0, "New replica count (required, 0 or more)")
+	cmd.Flags().IntVar(&currentReplicas, "current-replicas", -1, "Only scale if the current replica count matches; -1 skips the check")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+// runScale runs the scale workflow and writes the raw result (JSON) or the
+// scaleOutcome summary to w. A FAILED execution, an error status or an
+// unmet --current-replicas precondition fails the command before anything
+// but the JSON failure object is written.
+func runScale(ctx context.Context, runner workflowRunner, data map[string]interface{}, resourceType, name string, replicas, currentReplicas int, format output.Format, w io.Writer) error {
+	_, result, err := runner.Run(ctx, scaleWorkflow, data)
+	if err != nil {
+		return workflowRunError(err)
+	}
+	if result.State == "FAILED" {
+		return wf.WorkflowFailure(w, format, result.Error)
+	}
+
+	summary, err := scaleOutcome(resourceType, name, replicas, currentReplicas, result.Result)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, result.Result)
+	}
+	fmt.Fprintln(w, summary)
+	return nil
+}
+
+// parseScaleTarget parses a scale target: "<type>/<name>", or a bare name
+// for a deployment.
+func parseScaleTarget(arg string) (resourceType, name string, err error) {
+	if !strings.Contains(arg, "/") {
+		arg = "deployments/" + arg
+	}
+	return parseWorkloadTarget(arg, "scale", scalableTypes)
+}
+
+// scaleArgs builds the scale workflow arguments. replicas must not be
+// negative; currentReplicas is -1 for no precondition and is only sent when
+// set.
+func scaleArgs(resourceType, name, namespace string, replicas, currentReplicas int) (map[string]interface{}, error) {
+	if replicas < 0 {
+		return nil, fmt.Errorf("--replicas must be 0 or more, got %d", replicas)
+	}
+	if currentReplicas < -1 {
+		return nil, fmt.Errorf("--current-replicas must be 0 or more (or -1 to skip the check), got %d", currentReplicas)
+	}
+	data := map[string]interface{}{
+		"resource_type": resourceType,
+		"name":          name,
+		"namespace":     namespace,
+		"replicas":      replicas,
+	}
+	if currentReplicas >= 0 {
+		data["current_replicas"] = currentReplicas
+	}
+	return data, nil
+}
+
+// scaleOutcome turns the scale workflow result into the line printed on
+// success, e.g. `deployments "operator" scaled from 2 to 3 replicas`, or an
+// error when the workflow failed or the --current-replicas precondition did
+// not hold.
+func scaleOutcome(resourceType, name string, replicas, currentReplicas int, result map[string]interface{}) (string, error) {
+	previous, hasPrevious := result["previous_replicas"]
+
+	switch output.GetString(result, "status") {
+	case "error":
+		return "", fmt.Errorf("failed to scale %s/%s: %s", resourceType, name, output.GetString(result, "error"))
+	case scalePreconditionFailed:
+		if hasPrevious {
+			return "", fmt.Errorf("not scaling %s/%s: it has %v replicas, not the expected %d (--current-replicas)", resourceType, name, previous, currentReplicas)
+		}
+		return "", fmt.Errorf("not scaling %s/%s: its replica count is not the expected %d (--current-replicas)", resourceType, name, currentReplicas)
+	}
+
+	current := interface{}(replicas)
+	if v, ok := result["replicas"]; ok {
+		current = v
+	}
+	if !hasPrevious {
+		return fmt.Sprintf("%s \"%s\" scaled to %v replicas", resourceType, name, current), nil
+	}
+	return fmt.Sprintf("%s \"%s\" scaled from %v to %v replicas", resourceType, name, previous, current), nil
+}
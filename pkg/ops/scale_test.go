@@ -0,0 +1,166 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestParseScaleTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		wantType string
+		wantErr  string
+	}{
+		{"name is bare", "operator", "deployments", ""},
+		{"type is an alias", "sts/etcd", "statefulsets", ""},
+		{"type cannot be scaled", "ds/agent", "", "cannot scale daemonsets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, _, err := parseScaleTarget(tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotType != tt.wantType {
+				t.Errorf("got type %q, want %q", gotType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestScaleArgs(t *testing.T) {
+	tests := []struct {
+		name            string
+		replicas        int
+		currentReplicas int
+		wantCurrent     interface{}
+		wantErr         string
+	}{
+		{"replicas is negative", -1, -1, nil, "--replicas must be 0 or more"},
+		{"current-replicas is below -1", 2, -2, nil, "--current-replicas must be 0 or more"},
+		{"there is no precondition", 0, -1, nil, ""},
+		{"there is a precondition", 3, 2, 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := scaleArgs("deployments", "operator", "hypershift", tt.replicas, tt.currentReplicas)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if data["replicas"] != tt.replicas {
+				t.Errorf("replicas = %v, want %d", data["replicas"], tt.replicas)
+			}
+			got, ok := data["current_replicas"]
+			if tt.wantCurrent == nil {
+				if ok {
+					t.Errorf("expected no current_replicas, got %v", got)
+				}
+			} else if got != tt.wantCurrent {
+				t.Errorf("current_replicas = %v, want %v", got, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestScaleOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  map[string]interface{}
+		want    string
+		wantErr string
+	}{
+		{
+			"workflow scaled",
+			map[string]interface{}{"status": "ok", "previous_replicas": float64(2), "replicas": float64(3)},
+			`deployments "operator" scaled from 2 to 3 replicas`, "",
+		},
+		{
+			"previous count is missing",
+			map[string]interface{}{"status": "ok"},
+			`deployments "operator" scaled to 3 replicas`, "",
+		},
+		{
+			"precondition failed",
+			map[string]interface{}{"status": "precondition_failed", "previous_replicas": float64(5)},
+			"", "it has 5 replicas, not the expected 2",
+		},
+		{
+			"workflow errored",
+			map[string]interface{}{"status": "error", "error": "deployments.apps \"operator\" not found"},
+			"", "failed to scale deployments/operator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scaleOutcome("deployments", "operator", 3, 2, tt.result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunScale_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *workflows.ExecutionResult
+		format  output.Format
+		wantErr string
+	}{
+		{
+			name:    "failed execution",
+			result:  &workflows.ExecutionResult{State: "FAILED", Error: "patch denied"},
+			format:  output.FormatText,
+			wantErr: "patch denied",
+		},
+		{
+			name:    "precondition failed with json output",
+			result:  &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"status": "precondition_failed", "previous_replicas": float64(5)}},
+			format:  output.FormatJSON,
+			wantErr: "not the expected 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+				scaleWorkflow: func(map[string]interface{}) *workflows.ExecutionResult { return tt.result },
+			}}
+			data, _ := scaleArgs("deployments", "operator", "hypershift", 3, 2)
+			var out bytes.Buffer
+			err := runScale(context.Background(), runner, data, "deployments", "operator", 3, 2, tt.format, &out)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if strings.Contains(out.String(), "scaled") {
+				t.Errorf("a failed scale should not be reported as done: %q", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Package selector validates and normalizes Kubernetes label selectors
+// before they are sent to workflows as label_selector.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	nameRE   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	prefixRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	setRE    = regexp.MustCompile(`^(\S+)\s+(in|notin)(\s.*|\(.*)?$`)
+)
+
+// Parse validates a label selector and returns it in normalized form.
+// Supported clauses, joined by commas (AND):
+//
+//	key=value, key==value, key!=value   equality
+//	key in (a,b), key notin (a,b)       set-based
+//	key, !key                           existence
+//
+// Whitespace is trimmed, "==" becomes "=", and set values are sorted and
+// de-duplicated. An invalid clause is reported by position and text.
+func Parse(s string) (string, error) {
+	clauses, err := splitClauses(s)
+	if err != nil {
+		return "", err
+	}
+
+	normalized := make([]string, 0, len(clauses))
+	for i, clause := range clauses {
+		n, err := parseClause(clause)
+		if err != nil {
+			return "", fmt.Errorf("invalid label selector clause %d %q: %w", i+1, clause, err)
+		}
+		normalized = append(normalized, n)
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+// splitClauses splits s on commas that are not inside a parenthesized set.
+func splitClauses(s string) ([]string, error) {
+	var (
+		clauses []string
+		depth   int
+		start   int
+	)
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid label selector %q: nested parentheses", s)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", s)
+			}
+		case ',':
+			if depth == 0 {
+				clauses = append(clauses, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", s)
+	}
+	clauses = append(clauses, strings.TrimSpace(s[start:]))
+	return clauses, nil
+}
+
+func parseClause(clause string) (string, error) {
+	if clause == "" {
+		return "", fmt.Errorf("empty clause")
+	}
+
+	if key, ok := strings.CutPrefix(clause, "!"); ok {
+		key = strings.TrimSpace(key)
+		if err := validateKey(key); err != nil {
+			return "", err
+		}
+		return "!" + key, nil
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if key, value, ok := strings.Cut(clause, op); ok {
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(value)
+			if err := validateKey(key); err != nil {
+				return "", err
+			}
+			if err := validateValue(value); err != nil {
+				return "", err
+			}
+			if op == "==" {
+				op = "="
+			}
+			return key + op + value, nil
+		}
+	}
+
+	if m := setRE.FindStringSubmatch(clause); m != nil {
+		key, op := m[1], m[2]
+		if err := validateKey(key); err != nil {
+			return "", err
+		}
+		values, err := parseSet(strings.TrimSpace(m[3]))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s (%s)", key, op, strings.Join(values, ",")), nil
+	}
+
+	if strings.ContainsAny(clause, " ()") {
+		return "", fmt.Errorf("expected key=value, key!=value, key in (...), key notin (...), key, or !key")
+	}
+	if err := validateKey(clause); err != nil {
+		return "", err
+	}
+	return clause, nil
+}
+
+// parseSet parses "(a, b)" into sorted, de-duplicated values.
+func parseSet(set string) ([]string, error) {
+	if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+		return nil, fmt.Errorf("set values must be in parentheses, e.g. (a,b)")
+	}
+	inner := strings.TrimSpace(set[1 : len(set)-1])
+	if inner == "" {
+		return nil, fmt.Errorf("set must contain at least one value")
+	}
+
+	seen := make(map[string]bool)
+	var values []string
+	for _, v := range strings.Split(inner, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("empty value in set")
+		}
+		if err := validateValue(v); err != nil {
+			return nil, err
+		}
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+// validateKey checks a label key: an optional DNS subdomain prefix and "/",
+// followed by a name of at most 63 characters.
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	name := key
+	if prefix, n, ok := strings.Cut(key, "/"); ok {
+		if prefix == "" || len(prefix) > 253 || !prefixRE.MatchString(prefix) {
+			return fmt.Errorf("invalid key prefix %q: must be a DNS subdomain", prefix)
+		}
+		name = n
+	}
+	if len(name) > 63 || !nameRE.MatchString(name) {
+		return fmt.Errorf("invalid key %q: must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", key)
+	}
+	return nil
+}
+
+// validateValue checks a label value, which may be empty.
+func validateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !nameRE.MatchString(value) {
+		return fmt.Errorf("invalid value %q: must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", value)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{
+			name:     "equality clause",
+			selector: "app=nginx",
+			want:     "app=nginx",
+		},
+		{
+			name:     "double-equals clause",
+			selector: "app==nginx",
+			want:     "app=nginx",
+		},
+		{
+			name:     "inequality clause",
+			selector: "tier != cache",
+			want:     "tier!=cache",
+		},
+		{
+			name:     "empty value",
+			selector: "app=",
+			want:     "app=",
+		},
+		{
+			name:     "in set",
+			selector: "env in (staging, prod, staging)",
+			want:     "env in (prod,staging)",
+		},
+		{
+			name:     "notin set",
+			selector: "tier notin(cache)",
+			want:     "tier notin (cache)",
+		},
+		{
+			name:     "existence clause",
+			selector: "app",
+			want:     "app",
+		},
+		{
+			name:     "non-existence clause",
+			selector: "! app",
+			want:     "!app",
+		},
+		{
+			name:     "prefixed key",
+			selector: "app.kubernetes.io/name=etcd",
+			want:     "app.kubernetes.io/name=etcd",
+		},
+		{
+			name:     "several clauses",
+			selector: " app=nginx , env in (prod,staging), !canary ",
+			want:     "app=nginx,env in (prod,staging),!canary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		wantErr  string
+	}{
+		{
+			name:     "clause is empty",
+			selector: "app=nginx,,env=prod",
+			wantErr:  `clause 2 "": empty clause`,
+		},
+		{
+			name:     "set has no parentheses",
+			selector: "env in prod",
+			wantErr:  `clause 1 "env in prod": set values must be in parentheses`,
+		},
+		{
+			name:     "set is empty",
+			selector: "env in ()",
+			wantErr:  "at least one value",
+		},
+		{
+			name:     "parentheses are unbalanced",
+			selector: "env in (prod,staging",
+			wantErr:  "unbalanced parentheses",
+		},
+		{
+			name:     "key has invalid characters",
+			selector: "app=nginx,bad key=1",
+			wantErr:  `clause 2 "bad key=1": invalid key "bad key"`,
+		},
+		{
+			name:     "value has invalid characters",
+			selector: "app=ng*nx",
+			wantErr:  `invalid value "ng*nx"`,
+		},
+		{
+			name:     "key prefix is not a DNS subdomain",
+			selector: "Bad_Prefix/name=x",
+			wantErr:  "invalid key prefix",
+		},
+		{
+			name:     "operator is unknown",
+			selector: "env like (prod)",
+			wantErr:  "expected key=value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.selector)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package ops
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// workflowRunner is the subset of *workflows.Client used by snapshot, so tests
+// can substitute canned workflow results.
+type workflowRunner interface {
+	Run(ctx context.Context, workflowName string, args map[string]interface{}) (string, *workflows.ExecutionResult, error)
+}
+
+// snapshotFile is one file in a snapshot bundle.
+type snapshotFile struct {
+	name string
+	data []byte
+}
+
+// snapshotRequest identifies the pod to capture and where it runs.
+type snapshotRequest struct {
+	project   string
+	region    string
+	namespace string
+	pod       string
+	container string
+	tailLines int
+	// workflowPrefix is prepended to the describe, logs and get workflow
+	// names (--workflow-prefix).
+	workflowPrefix string
+}
+
+func newSnapshotCmd() *cobra.Command {
+	var (
+		namespace string
+		container string
+		outPath   string
+		tailLines int
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snapshot <pod-name>",
+		Short: "Bundle describe, logs and events for a pod into one artifact",
+		Long: `Capture the state of a pod for an incident report. Runs the describe,
+logs (current and previous) and get events workflows and writes the results
+into a directory, or a gzipped tarball when --out ends in .tar.gz or .tgz.
+
+Files written:
+  describe.txt        describe output for the pod
+  logs.txt            current container logs
+  logs-previous.txt   logs from the previous container instance
+  events.txt          events involving the pod
+  meta.json           project, region, pod and capture time
+
+A workflow that fails does not abort the snapshot; its error is written to the
+corresponding file and listed in meta.json.
+
+Examples:
+  # Snapshot a crashlooping pod into ./snapshot
+  gcphcp ops snapshot kube-apiserver-abc123 -n clusters-test
+
+  # Write a tarball for attaching to a ticket
+  gcphcp ops snapshot etcd-0 -n clusters-test -c etcd --out etcd-0.tar.gz`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+			if namespace == "" {
+				return fmt.Errorf("--namespace is required for snapshot")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			for _, wf := range []string{"describe", "logs", "get"} {
+				if err := checkPAMGate(ctx, client, workflowPrefix(cmd)+wf, cmd, progress.Stderr(cmd)); err != nil {
+					return err
+				}
+			}
+
+			progress.Printf(cmd, "Capturing snapshot of %s in %s\n", args[0], namespace)
+
+			files, err := collectSnapshot(ctx, client, snapshotRequest{
+				project:   project,
+				region:    region,
+				namespace: namespace,
+				pod:       args[0],
+				container: container,
+				tailLines: tailLines,
+
+				workflowPrefix: workflowPrefix(cmd),
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := writeSnapshot(outPath, files); err != nil {
+				return err
+			}
+			progress.Printf(cmd, "Snapshot written to %s\n", outPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace (required)")
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Container name for logs")
+	cmd.Flags().StringVar(&outPath, "out", "./snapshot", "Output directory, or a .tar.gz/.tgz file")
+	cmd.Flags().IntVar(&tailLines, "tail", 500, "Number of log lines to capture")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for all workflows")
+
+	return cmd
+}
+
+// collectSnapshot runs the describe, logs and get workflows for a pod and
+// renders each result the same way the corresponding command would.
+func collectSnapshot(ctx context.Context, runner workflowRunner, req snapshotRequest) ([]snapshotFile, error) {
+	var errs []string
+	run := func(workflowName string, data map[string]interface{}) (map[string]interface{}, error) {
+		_, result, err := runner.Run(ctx, req.workflowPrefix+workflowName, data)
+		if err != nil {
+			return nil, err
+		}
+		if result.State == "FAILED" {
+			return nil, wf.WorkflowFailure(io.Discard, output.FormatText, result.Error)
+		}
+		return result.Result, nil
+	}
+	record := func(name string, err error) []byte {
+		errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		return []byte(fmt.Sprintf("error: %v\n", err))
+	}
+
+	var files []snapshotFile
+
+	// describe.txt
+	var describe bytes.Buffer
+	if result, err := run("describe", map[string]interface{}{
+		"resource_type": "pods",
+		"name":          req.pod,
+		"namespace":     req.namespace,
+	}); err != nil {
+		describe.Write(record("describe.txt", err))
+	} else {
+		printDescribeText(&describe, result, "pods", describeOptions{showAnnotations: true})
+	}
+	files = append(files, snapshotFile{name: "describe.txt", data: describe.Bytes()})
+
+	// logs.txt and logs-previous.txt
+	for _, previous := range []bool{false, true} {
+		name := "logs.txt"
+		data := map[string]interface{}{
+			"namespace":  req.namespace,
+			"pod":        req.pod,
+			"tail_lines": req.tailLines,
+		}
+		if req.container != "" {
+			data["container"] = req.container
+		}
+		if previous {
+			name = "logs-previous.txt"
+			data["previous"] = true
+		}
+
+		var logs bytes.Buffer
+		result, err := run("logs", data)
+		if err == nil {
+			usage := fmt.Sprintf("gcphcp ops snapshot %s -n %s -c <container>", req.pod, req.namespace)
+			err = containerRequired(&logs, result, req.pod, usage)
+		}
+		switch {
+		case err != nil:
+			logs.Write(record(name, err))
+		case result["status"] == "error":
+			logs.Write(record(name, fmt.Errorf("%v", result["error"])))
+		default:
+			if text, ok := result["logs"]; ok {
+				fmt.Fprintln(&logs, text)
+			}
+		}
+		files = append(files, snapshotFile{name: name, data: logs.Bytes()})
+	}
+
+	// events.txt
+	var events bytes.Buffer
+	if result, err := run("get", map[string]interface{}{
+		"resource_type": "events",
+		"namespace":     req.namespace,
+	}); err != nil {
+		events.Write(record("events.txt", err))
+	} else {
+		filtered := map[string]interface{}{"items": podEvents(result, req.pod)}
+		if err := output.PrintResourceTable(&events, filtered, "events"); err != nil {
+			return nil, fmt.Errorf("rendering events: %w", err)
+		}
+	}
+	files = append(files, snapshotFile{name: "events.txt", data: events.Bytes()})
+
+	// meta.json
+	meta := map[string]interface{}{
+		"project":     req.project,
+		"region":      req.region,
+		"namespace":   req.namespace,
+		"pod":         req.pod,
+		"captured_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	if req.container != "" {
+		meta["container"] = req.container
+	}
+	if len(errs) > 0 {
+		meta["errors"] = errs
+	}
+	metaJSON, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding meta.json: %w", err)
+	}
+	files = append(files, snapshotFile{name: "meta.json", data: append(metaJSON, '\n')})
+
+	return files, nil
+}
+
+// podEvents returns the events in a get-events result that involve pod.
+func podEvents(result map[string]interface{}, pod string) []interface{} {
+	items, _ := result["items"].([]interface{})
+	var matched []interface{}
+	for _, item := range items {
+		involved := output.AsMap(output.AsMap(item)["involvedObject"])
+		if output.GetString(involved, "name") == pod && output.GetString(involved, "kind") == "Pod" {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// writeSnapshot writes files into the directory at out, or into a gzipped
+// tarball when out ends in .tar.gz or .tgz.
+func writeSnapshot(out string, files []snapshotFile) error {
+	if strings.HasSuffix(out, ".tar.gz") || strings.HasSuffix(out, ".tgz") {
+		return writeSnapshotTarball(out, files)
+	}
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(out, f.name), f.data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func writeSnapshotTarball(out string, files []snapshotFile) error {
+	if dir := filepath.Dir(out); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating snapshot directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("creating snapshot tarball: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	modTime := time.Now()
+	for _, sf := range files {
+		hdr := &tar.Header{
+			Name:    sf.name,
+			Mode:    0644,
+			Size:    int64(len(sf.data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", sf.name, err)
+		}
+		if _, err := tw.Write(sf.data); err != nil {
+			return fmt.Errorf("writing %s: %w", sf.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("finalizing snapshot tarball: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("finalizing snapshot tarball: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,234 @@
+package ops
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+// fakeRunner returns canned results keyed by workflow name and records calls.
+type fakeRunner struct {
+	results map[string]func(args map[string]interface{}) *workflows.ExecutionResult
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, name string, args map[string]interface{}) (string, *workflows.ExecutionResult, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, name)
+	f.mu.Unlock()
+	fn, ok := f.results[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected workflow %q", name)
+	}
+	return "executions/" + name, fn(args), nil
+}
+
+func newSnapshotFakeRunner() *fakeRunner {
+	return &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"describe": func(map[string]interface{}) *workflows.ExecutionResult {
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"resource": map[string]interface{}{
+					"metadata": map[string]interface{}{"name": "etcd-0", "namespace": "ns"},
+					"status":   map[string]interface{}{"phase": "Running"},
+				},
+			}}
+		},
+		"logs": func(args map[string]interface{}) *workflows.ExecutionResult {
+			logs := "current log line"
+			if args["previous"] == true {
+				logs = "previous log line"
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"status": "success",
+				"logs":   logs,
+			}}
+		},
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			event := func(obj, reason string) interface{} {
+				return map[string]interface{}{
+					"type":           "Warning",
+					"reason":         reason,
+					"message":        reason + " message",
+					"involvedObject": map[string]interface{}{"kind": "Pod", "name": obj},
+				}
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{event("etcd-0", "BackOff"), event("other-pod", "Unrelated")},
+			}}
+		},
+	}}
+}
+
+func TestCollectSnapshot_WritesAllFiles(t *testing.T) {
+	runner := newSnapshotFakeRunner()
+	files, err := collectSnapshot(context.Background(), runner, snapshotRequest{
+		project: "p", region: "r", namespace: "ns", pod: "etcd-0", tailLines: 100,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	if err := writeSnapshot(dir, files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+		return string(b)
+	}
+
+	if got := read("describe.txt"); !strings.Contains(got, "etcd-0") {
+		t.Errorf("describe.txt missing pod name:\n%s", got)
+	}
+	if got := read("logs.txt"); !strings.Contains(got, "current log line") {
+		t.Errorf("logs.txt = %q", got)
+	}
+	if got := read("logs-previous.txt"); !strings.Contains(got, "previous log line") {
+		t.Errorf("logs-previous.txt = %q", got)
+	}
+	events := read("events.txt")
+	if !strings.Contains(events, "BackOff") || strings.Contains(events, "Unrelated") {
+		t.Errorf("events.txt should only list events for the pod:\n%s", events)
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal([]byte(read("meta.json")), &meta); err != nil {
+		t.Fatalf("meta.json is not valid JSON: %v", err)
+	}
+	for key, want := range map[string]string{"project": "p", "region": "r", "namespace": "ns", "pod": "etcd-0"} {
+		if meta[key] != want {
+			t.Errorf("meta[%q] = %v, want %q", key, meta[key], want)
+		}
+	}
+	if _, ok := meta["captured_at"]; !ok {
+		t.Error("meta.json missing captured_at")
+	}
+	if _, ok := meta["errors"]; ok {
+		t.Errorf("expected no errors in meta.json, got %v", meta["errors"])
+	}
+}
+
+func TestCollectSnapshot_RecordsFailures(t *testing.T) {
+	runner := newSnapshotFakeRunner()
+	runner.results["describe"] = func(map[string]interface{}) *workflows.ExecutionResult {
+		return &workflows.ExecutionResult{State: "FAILED", Error: "pod not found"}
+	}
+
+	files, err := collectSnapshot(context.Background(), runner, snapshotRequest{namespace: "ns", pod: "etcd-0"})
+	if err != nil {
+		t.Fatalf("a failed workflow should not abort the snapshot: %v", err)
+	}
+
+	byName := map[string]string{}
+	for _, f := range files {
+		byName[f.name] = string(f.data)
+	}
+	if !strings.Contains(byName["describe.txt"], "pod not found") {
+		t.Errorf("describe.txt should record the failure, got %q", byName["describe.txt"])
+	}
+	if !strings.Contains(byName["meta.json"], "describe.txt: workflow failed") {
+		t.Errorf("meta.json should list the failure, got %s", byName["meta.json"])
+	}
+}
+
+func TestCollectSnapshot_FailureStep(t *testing.T) {
+	runner := newSnapshotFakeRunner()
+	runner.results["describe"] = func(map[string]interface{}) *workflows.ExecutionResult {
+		return &workflows.ExecutionResult{State: "FAILED", Error: "RuntimeError: \"pod not found\"\nin step \"fetch\", routine \"main\", line: 3"}
+	}
+
+	files, err := collectSnapshot(context.Background(), runner, snapshotRequest{namespace: "ns", pod: "etcd-0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, f := range files {
+		if f.name == "describe.txt" {
+			if want := `workflow failed in step "fetch": pod not found`; !strings.Contains(string(f.data), want) {
+				t.Errorf("describe.txt = %q, want it to contain %q", f.data, want)
+			}
+			return
+		}
+	}
+	t.Fatal("describe.txt missing from snapshot")
+}
+
+func TestWriteSnapshot_Tarball(t *testing.T) {
+	files := []snapshotFile{
+		{name: "describe.txt", data: []byte("d")},
+		{name: "meta.json", data: []byte("{}")},
+	}
+	out := filepath.Join(t.TempDir(), "snap.tar.gz")
+	if err := writeSnapshot(out, files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("not a gzip file: %v", err)
+	}
+	tr := tar.NewReader(gz)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading tarball: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "describe.txt,meta.json" {
+		t.Errorf("tarball entries = %v", names)
+	}
+}
+
+func TestCollectSnapshot_WorkflowPrefix(t *testing.T) {
+	runner := newSnapshotFakeRunner()
+	prefixed := map[string]func(map[string]interface{}) *workflows.ExecutionResult{}
+	for name, fn := range runner.results {
+		prefixed["gcphcp-"+name] = fn
+	}
+	runner.results = prefixed
+
+	files, err := collectSnapshot(context.Background(), runner, snapshotRequest{namespace: "ns", pod: "etcd-0", workflowPrefix: "gcphcp-"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range files {
+		if strings.Contains(string(f.data), "unexpected workflow") {
+			t.Errorf("%s called an unprefixed workflow: %s", f.name, f.data)
+		}
+	}
+	want := []string{"gcphcp-describe", "gcphcp-logs", "gcphcp-logs", "gcphcp-get"}
+	if !reflect.DeepEqual(runner.calls, want) {
+		t.Errorf("calls = %v, want %v", runner.calls, want)
+	}
+}
@@ -0,0 +1,264 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newWaitCmd() *cobra.Command {
+	var (
+		namespace string
+		waitFor   string
+		timeout   time.Duration
+		interval  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wait <type>/<name> --for=<predicate>",
+		Short: "Wait until a Kubernetes resource reaches a condition",
+		Long: `Poll a resource with the get workflow until it satisfies a predicate,
+like kubectl wait.
+
+--for accepts:
+  condition=<type>[=<status>]    a status condition, True unless given
+  jsonpath=<path>=<value>        a field, e.g. jsonpath={.status.phase}=Running
+
+Examples:
+  # Wait for a pod to become Ready
+  gcphcp ops wait pod/etcd-0 -n clusters-abc123 --for=condition=Ready
+
+  # Wait for a deployment rollout to finish
+  gcphcp ops wait deploy/operator -n hypershift --for=condition=Available --timeout 10m
+
+  # Wait on a field value
+  gcphcp ops wait hc/my-hc -n clusters --for='jsonpath={.status.version.history[0].state}=Completed'
+
+Exit codes:
+  0  the predicate was met
+  1  error (invalid flags, workflow failure, ...)
+  2  --timeout reached before the predicate was met`,
+
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resourceType, name, err := parseWaitTarget(args[0])
+			if err != nil {
+				return err
+			}
+			pred, err := parseWaitFor(waitFor)
+			if err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			workflowName := workflowPrefix(cmd) + "get"
+			progress.With(cmd, "workflow", workflowName)
+
+			data := map[string]interface{}{
+				"resource_type": resourceType,
+				"name":          name,
+			}
+			if namespace != "" {
+				data["namespace"] = namespace
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				return printDryRun(cmd.OutOrStdout(), workflowName, data)
+			}
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			if err := checkPAMGate(ctx, client, workflowName, cmd, progress.Stderr(cmd)); err != nil {
+				return err
+			}
+
+			target := resourceType + "/" + name
+			progress.Printf(cmd, "Waiting for %s %s (timeout %s)\n", target, pred, timeout)
+			elapsed, err := waitForResource(ctx, client, workflowName, data, pred, interval, progress.Writer(cmd))
+			if err != nil {
+				if exitErr, ok := err.(*ExitError); ok {
+					if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						exitErr.Msg = fmt.Sprintf("timed out after %s waiting for %s %s: %s", timeout, target, pred, exitErr.Msg)
+					} else {
+						exitErr.Msg = fmt.Sprintf("stopped waiting for %s %s: %s", target, pred, exitErr.Msg)
+					}
+				}
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s met after %s\n", target, pred, elapsed.Round(time.Second))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Kubernetes namespace")
+	cmd.Flags().StringVar(&waitFor, "for", "", "Predicate to wait for: condition=<type>[=<status>] or jsonpath=<path>=<value> (required)")
+	_ = cmd.MarkFlagRequired("for")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for the predicate")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between polls")
+	addDryRunFlag(cmd)
+
+	return cmd
+}
+
+// parseWaitTarget splits a "<type>/<name>" argument such as "pod/etcd-0",
+// expanding type aliases.
+func parseWaitTarget(arg string) (resourceType, name string, err error) {
+	resourceType, name, ok := strings.Cut(arg, "/")
+	if !ok || resourceType == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid target %q: expected <type>/<name>, e.g. pod/etcd-0", arg)
+	}
+	if expanded, ok := resourceTypeExpand[resourceType]; ok {
+		resourceType = expanded
+	}
+	return resourceType, name, nil
+}
+
+// waitPredicate is a parsed --for value: either a status condition or a
+// field compared with an expected value.
+type waitPredicate struct {
+	// condition is the condition type for condition=<type>[=<status>].
+	condition string
+	// path is the field path for jsonpath=<path>=<value>.
+	path string
+	// want is the expected condition status or field value.
+	want string
+}
+
+// parseWaitFor parses a --for value. The jsonpath form accepts the path
+// with or without braces, e.g. {.status.phase}=Running or
+// .status.phase=Running.
+func parseWaitFor(s string) (waitPredicate, error) {
+	kind, rest, _ := strings.Cut(s, "=")
+	switch kind {
+	case "condition":
+		cond, status, hasStatus := strings.Cut(rest, "=")
+		if cond == "" {
+			return waitPredicate{}, fmt.Errorf("invalid --for %q: expected condition=<type>, e.g. condition=Ready", s)
+		}
+		if !hasStatus {
+			status = "True"
+		}
+		return waitPredicate{condition: cond, want: status}, nil
+	case "jsonpath":
+		i := strings.LastIndex(rest, "=")
+		if i <= 0 {
+			return waitPredicate{}, fmt.Errorf("invalid --for %q: expected jsonpath=<path>=<value>, e.g. jsonpath={.status.phase}=Running", s)
+		}
+		path := strings.TrimSuffix(strings.TrimPrefix(rest[:i], "{"), "}")
+		if err := output.ValidateFieldPath(path); err != nil {
+			return waitPredicate{}, fmt.Errorf("invalid --for %q: %w", s, err)
+		}
+		return waitPredicate{path: path, want: rest[i+1:]}, nil
+	}
+	return waitPredicate{}, fmt.Errorf("invalid --for %q: expected condition=<type> or jsonpath=<path>=<value>", s)
+}
+
+func (p waitPredicate) String() string {
+	if p.condition != "" {
+		return fmt.Sprintf("condition %s=%s", p.condition, p.want)
+	}
+	return fmt.Sprintf("%s=%s", p.path, p.want)
+}
+
+// check reports whether resource satisfies the predicate, and describes
+// what was observed, e.g. "Ready=False" or ".status.phase=Pending".
+func (p waitPredicate) check(resource map[string]interface{}) (met bool, observed string) {
+	if p.condition != "" {
+		status := output.ConditionStatus(output.AsMap(resource["status"]), p.condition)
+		return strings.EqualFold(status, p.want), fmt.Sprintf("%s=%s", p.condition, status)
+	}
+
+	v, ok := output.LookupPath(resource, p.path)
+	if !ok {
+		return false, p.path + " not set"
+	}
+	var value string
+	switch v := v.(type) {
+	case string:
+		value = v
+	case map[string]interface{}, []interface{}:
+		raw, _ := json.Marshal(v)
+		value = string(raw)
+	default:
+		value = fmt.Sprintf("%v", v)
+	}
+	return value == p.want, fmt.Sprintf("%s=%s", p.path, value)
+}
+
+// waitForResource runs the get workflow every interval until the single
+// resource it returns satisfies pred, and returns how long that took. When
+// ctx ends first it returns an ExitError with ExitWaitTimeout naming the
+// last observed state. Each observed change is written to w.
+func waitForResource(ctx context.Context, runner workflowRunner, workflowName string, data map[string]interface{}, pred waitPredicate, interval time.Duration, w io.Writer) (time.Duration, error) {
+	start := time.Now()
+	lastObserved := "no response yet"
+	timedOut := func() error {
+		return &ExitError{Code: ExitWaitTimeout, Msg: "last observed " + lastObserved}
+	}
+
+	for polls := 0; ; polls++ {
+		if polls > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, timedOut()
+			case <-time.After(interval):
+			}
+		}
+
+		_, result, err := runner.Run(ctx, workflowName, data)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, timedOut()
+			}
+			return 0, workflowRunError(err)
+		}
+		if result.State == "FAILED" {
+			return 0, fmt.Errorf("get workflow failed: %s", result.Error)
+		}
+
+		items, _ := output.ResourceItems(result.Result)
+		if len(items) != 1 {
+			observed := fmt.Sprintf("%d resources", len(items))
+			if observed != lastObserved {
+				fmt.Fprintf(w, "Observed %s\n", observed)
+			}
+			lastObserved = observed
+			continue
+		}
+		met, observed := pred.check(output.AsMap(items[0]))
+		if met {
+			return time.Since(start), nil
+		}
+		if observed != lastObserved {
+			fmt.Fprintf(w, "Observed %s\n", observed)
+		}
+		lastObserved = observed
+	}
+}
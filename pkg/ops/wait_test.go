@@ -0,0 +1,202 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func TestParseWaitFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    waitPredicate
+		wantErr bool
+	}{
+		{
+			name: "condition is given",
+			in:   "condition=Ready",
+			want: waitPredicate{condition: "Ready", want: "True"},
+		},
+		{
+			name: "condition status is given",
+			in:   "condition=Progressing=False",
+			want: waitPredicate{condition: "Progressing", want: "False"},
+		},
+		{
+			name: "braced jsonpath is given",
+			in:   "jsonpath={.status.phase}=Running",
+			want: waitPredicate{path: ".status.phase", want: "Running"},
+		},
+		{
+			name:    "jsonpath has no value",
+			in:      "jsonpath={.status.phase}",
+			wantErr: true,
+		},
+		{
+			name:    "kind is unknown",
+			in:      "delete",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWaitFor(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseWaitFor(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func waitPod(phase, ready string) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": "etcd-0"},
+		"status": map[string]interface{}{
+			"phase": phase,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": ready},
+			},
+		},
+	}
+}
+
+func TestWaitPredicate_Check(t *testing.T) {
+	tests := []struct {
+		name         string
+		pred         string
+		resource     map[string]interface{}
+		wantMet      bool
+		wantObserved string
+	}{
+		{
+			name:         "condition is True",
+			pred:         "condition=Ready",
+			resource:     waitPod("Running", "True"),
+			wantMet:      true,
+			wantObserved: "Ready=True",
+		},
+		{
+			name:         "condition is False",
+			pred:         "condition=Ready",
+			resource:     waitPod("Running", "False"),
+			wantObserved: "Ready=False",
+		},
+		{
+			name:         "condition is absent",
+			pred:         "condition=Available",
+			resource:     waitPod("Running", "True"),
+			wantObserved: "Available=Unknown",
+		},
+		{
+			name:         "field has the value",
+			pred:         "jsonpath={.status.phase}=Running",
+			resource:     waitPod("Running", "False"),
+			wantMet:      true,
+			wantObserved: ".status.phase=Running",
+		},
+		{
+			name:         "field is missing",
+			pred:         "jsonpath=.status.podIP=10.0.0.1",
+			resource:     waitPod("Pending", "False"),
+			wantObserved: ".status.podIP not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pred, err := parseWaitFor(tt.pred)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			met, observed := pred.check(tt.resource)
+			if met != tt.wantMet || observed != tt.wantObserved {
+				t.Errorf("check() = %v, %q; want %v, %q", met, observed, tt.wantMet, tt.wantObserved)
+			}
+		})
+	}
+}
+
+// podSequence returns a get result function that serves pods in order,
+// repeating the last one.
+func podSequence(pods ...map[string]interface{}) func(map[string]interface{}) *workflows.ExecutionResult {
+	n := 0
+	return func(map[string]interface{}) *workflows.ExecutionResult {
+		pod := pods[len(pods)-1]
+		if n < len(pods) {
+			pod = pods[n]
+		}
+		n++
+		return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"resource": pod}}
+	}
+}
+
+func TestWaitForResource(t *testing.T) {
+	data := map[string]interface{}{"resource_type": "pods", "name": "etcd-0"}
+
+	t.Run("condition becomes True", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"get": podSequence(waitPod("Pending", "False"), waitPod("Running", "True")),
+		}}
+		pred, _ := parseWaitFor("condition=Ready")
+		var stderr bytes.Buffer
+
+		if _, err := waitForResource(context.Background(), runner, "get", data, pred, time.Millisecond, &stderr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(runner.calls) != 2 {
+			t.Errorf("polled %d times, want 2", len(runner.calls))
+		}
+		if !strings.Contains(stderr.String(), "Observed Ready=False") {
+			t.Errorf("stderr = %q", stderr.String())
+		}
+	})
+
+	t.Run("field reaches the value", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"get": podSequence(waitPod("Pending", "False"), waitPod("Pending", "False"), waitPod("Running", "False")),
+		}}
+		pred, _ := parseWaitFor("jsonpath={.status.phase}=Running")
+
+		if _, err := waitForResource(context.Background(), runner, "get", data, pred, time.Millisecond, &bytes.Buffer{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(runner.calls) != 3 {
+			t.Errorf("polled %d times, want 3", len(runner.calls))
+		}
+	})
+
+	t.Run("timeout is reached", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+			"get": podSequence(waitPod("Pending", "False")),
+		}}
+		pred, _ := parseWaitFor("condition=Ready")
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := waitForResource(ctx, runner, "get", data, pred, time.Millisecond, &bytes.Buffer{})
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != ExitWaitTimeout {
+			t.Fatalf("expected a wait timeout, got %v", err)
+		}
+		if !strings.Contains(exitErr.Msg, "last observed Ready=False") {
+			t.Errorf("message = %q", exitErr.Msg)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package ops
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/wf"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// maxWatchDuration bounds a --watch session; --timeout applies to each poll.
+const maxWatchDuration = 24 * time.Hour
+
+// watchOptions controls how watchGet polls and prints.
+type watchOptions struct {
+	interval    time.Duration
+	pollTimeout time.Duration
+	onlyChanges bool
+	// chunked writes each poll as one compact JSON line stamped with
+	// _observed_at instead of calling render (--chunked-output).
+	chunked bool
+	// budget, when positive, ends the watch after this long in total
+	// (--watch-timeout).
+	budget time.Duration
+	// until, when set, is checked on every poll; the watch ends as soon as
+	// it returns nil. If the watch ends first, its last error is returned
+	// with ExitWaitTimeout.
+	until func(result map[string]interface{}) error
+	// filter, when set, trims each poll's result in place before anything
+	// else sees it (--namespace-prefix).
+	filter func(result map[string]interface{})
+	format output.Format
+	now    func() time.Time
+}
+
+// watchGet runs the get workflow every interval and renders each result
+// until ctx is done, opts.budget runs out, or opts.until is satisfied. With
+// onlyChanges, a poll whose items match the previous poll writes a
+// timestamped "no change" heartbeat to stderr instead of re-printing the
+// same output.
+func watchGet(ctx context.Context, runner workflowRunner, workflowName string, data map[string]interface{}, w, stderr io.Writer, opts watchOptions, render func(io.Writer, map[string]interface{}) error) error {
+	now := opts.now
+	if now == nil {
+		now = time.Now
+	}
+	if opts.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.budget)
+		defer cancel()
+	}
+
+	// notMet is the last opts.until error; it decides how an ended watch
+	// exits.
+	var notMet error
+	ended := func() error {
+		if notMet == nil {
+			return nil
+		}
+		if opts.budget > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return &ExitError{Code: ExitWaitTimeout, Msg: fmt.Sprintf("--watch-timeout %s reached: %v", opts.budget, notMet)}
+		}
+		return &ExitError{Code: ExitWaitTimeout, Msg: fmt.Sprintf("watch stopped: %v", notMet)}
+	}
+
+	var lastDigest string
+	for polls := 0; ; polls++ {
+		if polls > 0 {
+			select {
+			case <-ctx.Done():
+				return ended()
+			case <-time.After(opts.interval):
+			}
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, opts.pollTimeout)
+		_, result, err := runner.Run(pollCtx, workflowName, data)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ended()
+			}
+			return workflowRunError(err)
+		}
+		if result.State == "FAILED" {
+			return wf.WorkflowFailure(w, opts.format, result.Error)
+		}
+		if opts.filter != nil {
+			opts.filter(result.Result)
+		}
+		if opts.until != nil {
+			notMet = opts.until(result.Result)
+		}
+
+		if opts.onlyChanges {
+			items, _ := output.ResourceItems(result.Result)
+			digest := itemSetDigest(items)
+			if polls > 0 && digest == lastDigest {
+				fmt.Fprintf(stderr, "%s no change\n", now().Format(time.TimeOnly))
+				continue
+			}
+			lastDigest = digest
+		}
+
+		if opts.chunked {
+			if err := printWatchChunk(w, result.Result, now()); err != nil {
+				return err
+			}
+		} else {
+			if polls > 0 && opts.format == output.FormatText {
+				fmt.Fprintf(w, "\n--- %s ---\n", now().Format(time.TimeOnly))
+			}
+			if err := render(w, result.Result); err != nil {
+				return err
+			}
+		}
+		if opts.until != nil && notMet == nil {
+			return nil
+		}
+	}
+}
+
+// observedAtField is the key --chunked-output adds to each poll's result.
+const observedAtField = "_observed_at"
+
+// printWatchChunk writes one poll's result as a single compact JSON line
+// with the poll time under observedAtField, for NDJSON consumers.
+func printWatchChunk(w io.Writer, result map[string]interface{}, observedAt time.Time) error {
+	chunk := make(map[string]interface{}, len(result)+1)
+	for k, v := range result {
+		chunk[k] = v
+	}
+	chunk[observedAtField] = observedAt.UTC().Format(time.RFC3339Nano)
+	return output.PrintJSONCompact(w, chunk)
+}
+
+// itemSetDigest hashes the normalized form of items. The order of items does
+// not affect the digest.
+func itemSetDigest(items []interface{}) string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = watchItemKey(item)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// watchItemKey normalizes an item to the fields that matter for change
+// detection: namespace/name, resourceVersion and status.
+func watchItemKey(item interface{}) string {
+	obj := output.AsMap(item)
+	meta := output.AsMap(obj["metadata"])
+	// json.Marshal sorts map keys, so equal statuses encode identically.
+	status, _ := json.Marshal(obj["status"])
+	return fmt.Sprintf("%s/%s\x00%s\x00%s",
+		output.GetString(meta, "namespace"),
+		output.GetString(meta, "name"),
+		output.GetString(meta, "resourceVersion"),
+		status)
+}
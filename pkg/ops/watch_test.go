@@ -0,0 +1,240 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func watchPod(name, rv, phase string) interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name, "namespace": "ns", "resourceVersion": rv},
+		"status":   map[string]interface{}{"phase": phase},
+	}
+}
+
+func TestWatchGet_OnlyChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := [][]interface{}{
+		{watchPod("a", "1", "Running")},
+		{watchPod("a", "1", "Running")},
+		{watchPod("a", "2", "Failed")},
+	}
+	var n int
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			items := polls[n]
+			n++
+			if n == len(polls) {
+				cancel()
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"items": items}}
+		},
+	}}
+
+	var out, stderr bytes.Buffer
+	opts := watchOptions{
+		interval:    time.Millisecond,
+		pollTimeout: time.Second,
+		onlyChanges: true,
+		format:      output.FormatText,
+		now:         func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+	var renders int
+	err := watchGet(ctx, runner, "get", map[string]interface{}{"resource_type": "pods"}, &out, &stderr, opts,
+		func(io.Writer, map[string]interface{}) error {
+			renders++
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if renders != 2 {
+		t.Errorf("rendered %d times, want 2", renders)
+	}
+	if got := strings.Count(stderr.String(), "03:04:05 no change"); got != 1 {
+		t.Errorf("got %d heartbeats, want 1 in %q", got, stderr.String())
+	}
+	if !strings.Contains(out.String(), "--- 03:04:05 ---") {
+		t.Errorf("expected a timestamped separator before the re-print, got %q", out.String())
+	}
+}
+
+func TestWatchGet_Chunked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var n int
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			n++
+			if n == 3 {
+				cancel()
+			}
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{watchPod("a", fmt.Sprint(n), "Running")},
+			}}
+		},
+	}}
+
+	clock := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var out bytes.Buffer
+	opts := watchOptions{
+		interval:    time.Millisecond,
+		pollTimeout: time.Second,
+		chunked:     true,
+		format:      output.FormatJSON,
+		now: func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		},
+	}
+	err := watchGet(ctx, runner, "get", map[string]interface{}{"resource_type": "pods"}, &out, io.Discard, opts,
+		func(io.Writer, map[string]interface{}) error {
+			t.Error("render should not be called with chunked output")
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want one per poll:\n%s", len(lines), out.String())
+	}
+	for i, line := range lines {
+		var chunk map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		want := time.Date(2026, 1, 2, 3, 4, 6+i, 0, time.UTC).Format(time.RFC3339Nano)
+		if chunk[observedAtField] != want {
+			t.Errorf("line %d: %s = %v, want %s", i+1, observedAtField, chunk[observedAtField], want)
+		}
+		if items, _ := chunk["items"].([]interface{}); len(items) != 1 {
+			t.Errorf("line %d: expected the poll's items, got %v", i+1, chunk)
+		}
+	}
+}
+
+func TestValidateWatch(t *testing.T) {
+	if err := validateWatch(false, false, false, false, "", 5*time.Second, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateWatch(false, true, false, false, "", 5*time.Second, 0); err == nil || !strings.Contains(err.Error(), "--all-namespaces") {
+		t.Errorf("expected --all-namespaces error, got %v", err)
+	}
+	if err := validateWatch(false, false, false, false, "", 0, 0); err == nil {
+		t.Error("expected error for a zero interval")
+	}
+	if err := validateWatch(false, false, false, true, "", 5*time.Second, 0); err == nil || !strings.Contains(err.Error(), "--watch-timeout") {
+		t.Errorf("expected --fail-on-not-ready to require --watch-timeout, got %v", err)
+	}
+	if err := validateWatch(false, false, false, true, "", 5*time.Second, time.Minute); err != nil {
+		t.Errorf("unexpected error with a watch budget: %v", err)
+	}
+}
+
+func TestWatchGet_Budget(t *testing.T) {
+	pods := func(phases ...string) func(map[string]interface{}) *workflows.ExecutionResult {
+		n := 0
+		return func(map[string]interface{}) *workflows.ExecutionResult {
+			phase := phases[min(n, len(phases)-1)]
+			n++
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{watchPod("a", fmt.Sprint(n), phase)},
+			}}
+		}
+	}
+	opts := func(until func(map[string]interface{}) error) watchOptions {
+		return watchOptions{
+			interval:    time.Millisecond,
+			pollTimeout: time.Second,
+			budget:      50 * time.Millisecond,
+			until:       until,
+			format:      output.FormatText,
+		}
+	}
+	render := func(io.Writer, map[string]interface{}) error { return nil }
+
+	t.Run("pods never become ready", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{"get": pods("Pending")}}
+		err := watchGet(context.Background(), runner, "get", nil, io.Discard, io.Discard, opts(podHealthError), render)
+
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != ExitWaitTimeout {
+			t.Fatalf("expected an ExitError with code %d, got %v", ExitWaitTimeout, err)
+		}
+		if !strings.Contains(exitErr.Msg, "--watch-timeout 50ms reached") || !strings.Contains(exitErr.Msg, "not ready: a") {
+			t.Errorf("unexpected message %q", exitErr.Msg)
+		}
+	})
+
+	t.Run("pods become ready within the budget", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{"get": pods("Pending", "Succeeded")}}
+		if err := watchGet(context.Background(), runner, "get", nil, io.Discard, io.Discard, opts(podHealthError), render); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(runner.calls) != 2 {
+			t.Errorf("polled %d times, want 2", len(runner.calls))
+		}
+	})
+
+	t.Run("no condition is set", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{"get": pods("Pending")}}
+		if err := watchGet(context.Background(), runner, "get", nil, io.Discard, io.Discard, opts(nil), render); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestWatchGet_Filter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runner := &fakeRunner{results: map[string]func(map[string]interface{}) *workflows.ExecutionResult{
+		"get": func(map[string]interface{}) *workflows.ExecutionResult {
+			cancel()
+			return &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{
+				"items": []interface{}{nsPod("clusters-abc", "kas"), nsPod("hypershift", "operator")},
+			}}
+		},
+	}}
+
+	var seen []interface{}
+	var out bytes.Buffer
+	opts := watchOptions{
+		interval:    time.Millisecond,
+		pollTimeout: time.Second,
+		chunked:     true,
+		format:      output.FormatJSON,
+		filter:      func(result map[string]interface{}) { filterNamespacePrefix(result, "clusters-") },
+		until: func(result map[string]interface{}) error {
+			seen, _ = output.ResourceItems(result)
+			return nil
+		},
+	}
+	err := watchGet(ctx, runner, "get", map[string]interface{}{"resource_type": "pods"}, &out, io.Discard, opts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := itemNames(seen); !reflect.DeepEqual(got, []string{"clusters-abc/kas"}) {
+		t.Errorf("until saw %v, want only the prefixed namespace", got)
+	}
+	if strings.Contains(out.String(), "operator") {
+		t.Errorf("chunked output should be filtered: %s", out.String())
+	}
+}
@@ -0,0 +1,136 @@
+package wf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newCancelCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "cancel <workflow> <execution-id>",
+		Short: "Cancel a running workflow execution",
+		Long: `Cancel a running workflow execution. The execution ID may be abbreviated
+to any unique prefix of a recent execution.
+
+Cancelling an execution that already finished is not an error; cancelled
+is false and state is its final state. With -o json, prints
+{"execution", "cancelled", "state"} for scripts.
+
+Examples:
+  # Cancel an execution
+  gcphcp ops wf cancel approval-flow abc123
+
+  # Machine-readable result
+  gcphcp ops wf cancel approval-flow abc123 -o json`,
+
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowName := args[0]
+			execID := args[1]
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			execID, err = client.ResolveExecution(ctx, workflowName, execID)
+			if err != nil {
+				return fmt.Errorf("resolving execution: %w", err)
+			}
+
+			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
+				project, region, workflowName, execID)
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			return runCancel(ctx, client, execName, output.ParseFormat(outputFormat), out, progress.Stderr(cmd))
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+
+	return cmd
+}
+
+// executionCanceller cancels and reads executions. *workflows.Client
+// implements it.
+type executionCanceller interface {
+	CancelExecution(ctx context.Context, executionName string) (*workflows.ExecutionResult, error)
+	GetExecution(ctx context.Context, executionName string) (*workflows.ExecutionResult, error)
+}
+
+// runCancel cancels execName and reports the outcome: a JSON cancelResult on
+// w with -o json, otherwise a line on stderr. When the cancel call fails
+// because the execution already finished, its final state is reported
+// instead of the error.
+func runCancel(ctx context.Context, client executionCanceller, execName string, format output.Format, w, stderr io.Writer) error {
+	var res cancelResult
+	result, err := client.CancelExecution(ctx, execName)
+	if err != nil {
+		current, getErr := client.GetExecution(ctx, execName)
+		if getErr != nil || current.State == "ACTIVE" || current.State == "QUEUED" {
+			return fmt.Errorf("cancelling execution: %w", err)
+		}
+		res = cancelResult{Execution: execName, State: current.State}
+	} else {
+		res = newCancelResult(execName, result)
+	}
+
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, res)
+	}
+
+	_, execID := workflows.ParseExecutionName(execName)
+	if res.Cancelled {
+		fmt.Fprintf(stderr, "Execution %s cancelled (state: %s).\n", execID, res.State)
+	} else {
+		fmt.Fprintf(stderr, "Execution %s already finished (state: %s); nothing to cancel.\n", execID, res.State)
+	}
+	return nil
+}
+
+// cancelResult is the machine-readable outcome of wf cancel.
+type cancelResult struct {
+	Execution string `json:"execution"`
+	Cancelled bool   `json:"cancelled"`
+	State     string `json:"state"`
+}
+
+func newCancelResult(execName string, result *workflows.ExecutionResult) cancelResult {
+	return cancelResult{
+		Execution: execName,
+		Cancelled: result.State == "CANCELLED",
+		State:     result.State,
+	}
+}
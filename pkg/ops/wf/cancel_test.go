@@ -0,0 +1,98 @@
+package wf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// fakeCanceller cancels an ACTIVE execution and rejects the cancel of one
+// in any other state, as the Executions API does.
+type fakeCanceller struct {
+	state string
+}
+
+func (f *fakeCanceller) CancelExecution(_ context.Context, name string) (*workflows.ExecutionResult, error) {
+	if f.state != "ACTIVE" {
+		return nil, errors.New("rpc error: code = FailedPrecondition desc = execution is not active")
+	}
+	f.state = "CANCELLED"
+	return &workflows.ExecutionResult{Name: name, State: f.state}, nil
+}
+
+func (f *fakeCanceller) GetExecution(_ context.Context, name string) (*workflows.ExecutionResult, error) {
+	return &workflows.ExecutionResult{Name: name, State: f.state}, nil
+}
+
+const cancelExecName = "projects/p/locations/r/workflows/approval-flow/executions/abc123"
+
+func TestRunCancel(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         string
+		wantCancelled bool
+		wantState     string
+		wantStderr    string
+	}{
+		{"active", "ACTIVE", true, "CANCELLED", "Execution abc123 cancelled (state: CANCELLED)."},
+		{"succeeded", "SUCCEEDED", false, "SUCCEEDED", "Execution abc123 already finished (state: SUCCEEDED); nothing to cancel."},
+		{"already cancelled", "CANCELLED", false, "CANCELLED", "already finished (state: CANCELLED)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" json", func(t *testing.T) {
+			var out, stderr bytes.Buffer
+			if err := runCancel(context.Background(), &fakeCanceller{state: tt.state}, cancelExecName, output.FormatJSON, &out, &stderr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got cancelResult
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", out.String(), err)
+			}
+			want := cancelResult{Execution: cancelExecName, Cancelled: tt.wantCancelled, State: tt.wantState}
+			if got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+		t.Run(tt.name+" text", func(t *testing.T) {
+			var out, stderr bytes.Buffer
+			if err := runCancel(context.Background(), &fakeCanceller{state: tt.state}, cancelExecName, output.FormatText, &out, &stderr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("text mode should not write to stdout, got %q", out.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunCancel_Error(t *testing.T) {
+	// A cancel that fails while the execution is still running is an error.
+	var out, stderr bytes.Buffer
+	err := runCancel(context.Background(), stuckCanceller{}, cancelExecName, output.FormatJSON, &out, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("expected the cancel error, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("no result should be printed on error, got %q", out.String())
+	}
+}
+
+type stuckCanceller struct{}
+
+func (stuckCanceller) CancelExecution(context.Context, string) (*workflows.ExecutionResult, error) {
+	return nil, errors.New("permission denied")
+}
+
+func (stuckCanceller) GetExecution(_ context.Context, name string) (*workflows.ExecutionResult, error) {
+	return &workflows.ExecutionResult{Name: name, State: "ACTIVE"}, nil
+}
@@ -0,0 +1,136 @@
+package wf
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/cache"
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionTimeout bounds the workflow list call made on <TAB>.
+	completionTimeout = 3 * time.Second
+	// completionCacheTTL is how long listed workflow names are reused.
+	completionCacheTTL = time.Minute
+)
+
+// workflowLister lists deployed workflows. *workflows.Client implements it.
+type workflowLister interface {
+	List(ctx context.Context) ([]workflows.WorkflowInfo, error)
+	Close() error
+}
+
+// newWorkflowLister creates the lister used for completion; replaced in
+// tests.
+var newWorkflowLister = func(ctx context.Context, project, region string) (workflowLister, error) {
+	return workflows.NewClient(ctx, project, region)
+}
+
+// completeWorkflowNames is a ValidArgsFunction that completes the workflow
+// name argument with the workflows deployed in the selected project and
+// region. Names are cached under the config directory for
+// completionCacheTTL; any error yields no completions.
+func completeWorkflowNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	project, region := completionTarget(cmd)
+	if project == "" || region == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var c *cache.Cache
+	configPath, _ := cmd.Flags().GetString("config")
+	if dir := config.ResolveDir(configPath); dir != "" {
+		c = cache.New(cache.DefaultDir(dir), completionCacheTTL)
+	}
+	names, err := cachedWorkflowNames(cmd.Context(), c, project, region)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			matches = append(matches, name)
+		}
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionTarget returns the project and region to complete against.
+// Completion skips the root's pre-run hook, so values missing from the
+// flags and environment are read from the config file here.
+func completionTarget(cmd *cobra.Command) (project, region string) {
+	project, _ = cmd.Flags().GetString("project")
+	region, _ = cmd.Flags().GetString("region")
+	if project != "" && region != "" {
+		return project, region
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	contextName, _ := cmd.Flags().GetString("context")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return project, region
+	}
+	if cfg, err = cfg.ForContext(contextName); err != nil {
+		return project, region
+	}
+	if project == "" {
+		project = cfg.Project
+	}
+	if region == "" {
+		region = cfg.Region
+	}
+	return project, region
+}
+
+// cachedWorkflowNames returns the names of the workflows in project/region,
+// from c when it holds a fresh list and otherwise from the API, storing the
+// result in c. A nil c disables caching.
+func cachedWorkflowNames(ctx context.Context, c *cache.Cache, project, region string) ([]string, error) {
+	key := cache.Key("workflow-names", project, region)
+	if c != nil {
+		if raw, ok := c.Get(key); ok {
+			var names []string
+			if err := json.Unmarshal(raw, &names); err == nil {
+				return names, nil
+			}
+		}
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	client, err := newWorkflowLister(ctx, project, region)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	list, err := client.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(list))
+	for i, wf := range list {
+		names[i] = wf.Name
+	}
+
+	if c != nil {
+		if raw, err := json.Marshal(names); err == nil {
+			_ = c.Put(key, raw)
+		}
+	}
+	return names, nil
+}
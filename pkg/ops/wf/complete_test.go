@@ -0,0 +1,153 @@
+package wf
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/cache"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/spf13/cobra"
+)
+
+type fakeWorkflowLister struct {
+	names []string
+	err   error
+	calls *int
+}
+
+func (f fakeWorkflowLister) List(context.Context) ([]workflows.WorkflowInfo, error) {
+	*f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	infos := make([]workflows.WorkflowInfo, len(f.names))
+	for i, name := range f.names {
+		infos[i] = workflows.WorkflowInfo{Name: name}
+	}
+	return infos, nil
+}
+
+func (f fakeWorkflowLister) Close() error { return nil }
+
+// useFakeLister replaces newWorkflowLister for the test and returns a
+// counter of List calls.
+func useFakeLister(t *testing.T, names []string, err error) *int {
+	t.Helper()
+	calls := new(int)
+	orig := newWorkflowLister
+	newWorkflowLister = func(context.Context, string, string) (workflowLister, error) {
+		return fakeWorkflowLister{names: names, err: err, calls: calls}, nil
+	}
+	t.Cleanup(func() { newWorkflowLister = orig })
+	return calls
+}
+
+func TestCachedWorkflowNames_Freshness(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	t.Run("cached list is fresh", func(t *testing.T) {
+		calls := useFakeLister(t, []string{"get", "describe"}, nil)
+		c := cache.New(dir, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			names, err := cachedWorkflowNames(context.Background(), c, "p", "r")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(names, []string{"get", "describe"}) {
+				t.Errorf("names = %v", names)
+			}
+		}
+		if *calls != 1 {
+			t.Errorf("List called %d times, want 1", *calls)
+		}
+	})
+
+	t.Run("cached list has expired", func(t *testing.T) {
+		calls := useFakeLister(t, []string{"get", "logs"}, nil)
+		c := cache.New(dir, time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		names, err := cachedWorkflowNames(context.Background(), c, "p", "r")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *calls != 1 || !reflect.DeepEqual(names, []string{"get", "logs"}) {
+			t.Errorf("got %v after %d List calls, want a fresh list", names, *calls)
+		}
+	})
+
+	t.Run("another region is asked for", func(t *testing.T) {
+		calls := useFakeLister(t, []string{"get"}, nil)
+		c := cache.New(dir, time.Minute)
+
+		if _, err := cachedWorkflowNames(context.Background(), c, "p", "other-region"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("List called %d times, want 1", *calls)
+		}
+	})
+}
+
+func newCompletionCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cmd := &cobra.Command{Use: "run"}
+	cmd.Flags().String("project", "", "")
+	cmd.Flags().String("region", "", "")
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("context", "", "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestCompleteWorkflowNames(t *testing.T) {
+	t.Run("API lists workflows", func(t *testing.T) {
+		useFakeLister(t, []string{"get", "describe", "gcphcp-get"}, nil)
+		cmd := newCompletionCmd(t, "--project", "p", "--region", "r")
+
+		got, directive := completeWorkflowNames(cmd, nil, "g")
+		if !reflect.DeepEqual(got, []string{"get", "gcphcp-get"}) {
+			t.Errorf("completions = %v", got)
+		}
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("directive = %v", directive)
+		}
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		useFakeLister(t, nil, errors.New("permission denied"))
+		cmd := newCompletionCmd(t, "--project", "p", "--region", "r")
+
+		if got, _ := completeWorkflowNames(cmd, nil, ""); len(got) != 0 {
+			t.Errorf("expected no completions, got %v", got)
+		}
+	})
+
+	t.Run("project is unknown", func(t *testing.T) {
+		calls := useFakeLister(t, []string{"get"}, nil)
+		cmd := newCompletionCmd(t, "--region", "r")
+
+		if got, _ := completeWorkflowNames(cmd, nil, ""); len(got) != 0 || *calls != 0 {
+			t.Errorf("got %v after %d List calls, want nothing", got, *calls)
+		}
+	})
+
+	t.Run("workflow is already given", func(t *testing.T) {
+		calls := useFakeLister(t, []string{"get"}, nil)
+		cmd := newCompletionCmd(t, "--project", "p", "--region", "r")
+
+		if got, _ := completeWorkflowNames(cmd, []string{"get"}, ""); len(got) != 0 || *calls != 0 {
+			t.Errorf("got %v after %d List calls, want nothing", got, *calls)
+		}
+	})
+}
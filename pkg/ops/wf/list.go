@@ -3,11 +3,16 @@ package wf
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +20,12 @@ func newListCmd() *cobra.Command {
 	var (
 		timeout time.Duration
 		limit   int
+		metrics bool
+		since   string
+		until   string
+
+		allRegions bool
+		regions    []string
 	)
 
 	cmd := &cobra.Command{
@@ -34,22 +45,92 @@ Examples:
   gcphcp ops wf list get --limit 5
 
   # JSON output
-  gcphcp ops wf list get -o json`,
+  gcphcp ops wf list get -o json
+
+  # Executions started in the last 2 hours (of the newest --limit)
+  gcphcp ops wf list get --limit 200 --since 2h
+
+  # Executions started in an incident window
+  gcphcp ops wf list get --limit 200 --since 2025-06-01T10:00:00Z --until 2025-06-01T12:00:00Z
+
+  # Prometheus text-format statistics for the last 100 executions
+  gcphcp ops wf list get --limit 100 --metrics
+
+  # Workflows, or executions, in every region at once (adds a REGION column)
+  gcphcp ops wf list --all-regions
+  gcphcp ops wf list get --all-regions --regions us-central1,europe-west1`,
 
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
 			}
-			if region == "" {
+			if region == "" && !allRegions {
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			if metrics && len(args) == 0 {
+				return fmt.Errorf("--metrics requires a workflow name")
+			}
+
+			window, err := newTimeWindow(since, until, time.Now())
+			if err != nil {
+				return err
+			}
+			if !window.isZero() && len(args) == 0 {
+				return fmt.Errorf("--since and --until require a workflow name")
+			}
+			if allRegions && metrics {
+				return fmt.Errorf("--metrics cannot be used with --all-regions")
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			if allRegions {
+				regionList, err := resolveRegions(regions)
+				if err != nil {
+					return err
+				}
+
+				ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
+				defer cancel()
+
+				newClient := func(ctx context.Context, region string) (regionClient, error) {
+					return workflows.NewClient(ctx, project, region)
+				}
+				format := output.ParseFormat(outputFormat)
+				if len(args) == 1 {
+					rows, failures := listExecutionsAllRegions(ctx, regionList, newClient, args[0], limit, window)
+					if interrupt.Interrupted(ctx) {
+						return fmt.Errorf("listing executions: %w", context.Cause(ctx))
+					}
+					printRegionFailures(progress.WarnWriter(cmd), failures)
+					if len(failures) == len(regionList) {
+						return fmt.Errorf("listing executions failed in every region")
+					}
+					return printRegionExecutions(out, args[0], rows, format)
+				}
+				rows, failures := listWorkflowsAllRegions(ctx, regionList, newClient)
+				if interrupt.Interrupted(ctx) {
+					return fmt.Errorf("listing workflows: %w", context.Cause(ctx))
+				}
+				printRegionFailures(progress.WarnWriter(cmd), failures)
+				if len(failures) == len(regionList) {
+					return fmt.Errorf("listing workflows failed in every region")
+				}
+				return printRegionWorkflows(out, rows, format)
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -59,19 +140,25 @@ Examples:
 			defer client.Close()
 
 			if len(args) == 1 {
-				return listExecutions(ctx, client, args[0], limit, outputFormat)
+				return listExecutions(ctx, client, out, args[0], limit, outputFormat, metrics, window)
 			}
-			return listWorkflows(ctx, client, outputFormat)
+			return listWorkflows(ctx, client, out, outputFormat)
 		},
 	}
 
 	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of executions to show")
+	cmd.Flags().StringVar(&since, "since", "", "Only executions started at or after this time (duration like 2h, or RFC3339)")
+	cmd.Flags().StringVar(&until, "until", "", "Only executions started before this time (duration like 30m, or RFC3339)")
+	cmd.Flags().BoolVar(&allRegions, "all-regions", false, "List across --regions concurrently instead of only --region, adding a REGION column")
+	cmd.Flags().StringSliceVar(&regions, "regions", envRegions(), "Regions queried by --all-regions (env: GCPHCP_REGIONS; default: "+strings.Join(defaultRegions, ",")+")")
+	cmd.Flags().BoolVar(&metrics, "metrics", false, "Print execution statistics in Prometheus text format instead of the table")
 
 	return cmd
 }
 
-func listWorkflows(ctx context.Context, client *workflows.Client, outputFormat string) error {
+func listWorkflows(ctx context.Context, client *workflows.Client, w io.Writer, outputFormat string) error {
 	wfs, err := client.List(ctx)
 	if err != nil {
 		return fmt.Errorf("listing workflows: %w", err)
@@ -79,15 +166,15 @@ func listWorkflows(ctx context.Context, client *workflows.Client, outputFormat s
 
 	format := output.ParseFormat(outputFormat)
 	if format == output.FormatJSON {
-		return output.PrintJSON(os.Stdout, wfs)
+		return output.PrintJSON(w, wfs)
 	}
 
 	if len(wfs) == 0 {
-		fmt.Fprintln(os.Stdout, "No workflows found.")
+		fmt.Fprintln(w, "No workflows found.")
 		return nil
 	}
 
-	t := output.NewTable(os.Stdout, "NAME", "STATE", "REVISION", "UPDATED")
+	t := output.NewTable(w, "NAME", "STATE", "REVISION", "UPDATED")
 	for _, wf := range wfs {
 		updated := wf.UpdateTime.Format(time.RFC3339)
 		t.AddRow(wf.Name, wf.State, wf.RevisionID, updated)
@@ -95,23 +182,43 @@ func listWorkflows(ctx context.Context, client *workflows.Client, outputFormat s
 	return t.Flush()
 }
 
-func listExecutions(ctx context.Context, client *workflows.Client, workflow string, limit int, outputFormat string) error {
+// executionMetrics counts executions by state and returns the durations of
+// those that completed.
+func executionMetrics(execs []workflows.ExecutionInfo) (map[string]int, []time.Duration) {
+	counts := map[string]int{}
+	var durations []time.Duration
+	for _, e := range execs {
+		counts[e.State]++
+		if !e.EndTime.IsZero() && !e.StartTime.IsZero() {
+			durations = append(durations, e.EndTime.Sub(e.StartTime))
+		}
+	}
+	return counts, durations
+}
+
+func listExecutions(ctx context.Context, client *workflows.Client, w io.Writer, workflow string, limit int, outputFormat string, metrics bool, window timeWindow) error {
 	execs, err := client.ListExecutions(ctx, workflow, limit)
 	if err != nil {
 		return fmt.Errorf("listing executions: %w", err)
 	}
+	execs = window.filter(execs)
+
+	if metrics {
+		counts, durations := executionMetrics(execs)
+		return output.PrintExecutionMetrics(w, workflow, counts, durations)
+	}
 
 	format := output.ParseFormat(outputFormat)
 	if format == output.FormatJSON {
-		return output.PrintJSON(os.Stdout, execs)
+		return output.PrintJSON(w, execs)
 	}
 
 	if len(execs) == 0 {
-		fmt.Fprintf(os.Stdout, "No executions found for workflow '%s'.\n", workflow)
+		fmt.Fprintf(w, "No executions found for workflow '%s'.\n", workflow)
 		return nil
 	}
 
-	t := output.NewTable(os.Stdout, "ID", "STATE", "STARTED", "DURATION")
+	t := output.NewTable(w, "ID", "STATE", "STARTED", "DURATION")
 	for _, e := range execs {
 		started := output.Age(e.StartTime.Format(time.RFC3339)) + " ago"
 		duration := e.Duration
@@ -120,5 +227,138 @@ func listExecutions(ctx context.Context, client *workflows.Client, workflow stri
 		}
 		t.AddRow(e.ID, e.State, started, duration)
 	}
-	return t.Flush()
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%s\n", executionSummary(execs))
+	return nil
+}
+
+// timeWindow selects executions by start time. since is inclusive and until
+// is exclusive; a zero bound is open.
+type timeWindow struct {
+	since time.Time
+	until time.Time
+}
+
+// newTimeWindow parses the --since and --until flags relative to now.
+func newTimeWindow(since, until string, now time.Time) (timeWindow, error) {
+	var w timeWindow
+	var err error
+	if since != "" {
+		if w.since, err = parseTimeOrDuration(since, now); err != nil {
+			return w, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		if w.until, err = parseTimeOrDuration(until, now); err != nil {
+			return w, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if !w.since.IsZero() && !w.until.IsZero() && !w.since.Before(w.until) {
+		return w, fmt.Errorf("--since (%s) must be before --until (%s)", w.since.Format(time.RFC3339), w.until.Format(time.RFC3339))
+	}
+	return w, nil
+}
+
+func (w timeWindow) isZero() bool {
+	return w.since.IsZero() && w.until.IsZero()
+}
+
+// contains reports whether t is at or after since and before until.
+func (w timeWindow) contains(t time.Time) bool {
+	if !w.since.IsZero() && t.Before(w.since) {
+		return false
+	}
+	if !w.until.IsZero() && !t.Before(w.until) {
+		return false
+	}
+	return true
+}
+
+// filter returns the executions whose StartTime is in the window.
+func (w timeWindow) filter(execs []workflows.ExecutionInfo) []workflows.ExecutionInfo {
+	if w.isZero() {
+		return execs
+	}
+	kept := make([]workflows.ExecutionInfo, 0, len(execs))
+	for _, e := range execs {
+		if w.contains(e.StartTime) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// parseTimeOrDuration parses s as an RFC3339 timestamp, or as a duration
+// (e.g. 2h, 90m) meaning that long before now.
+func parseTimeOrDuration(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration (e.g. 2h) nor an RFC3339 time (e.g. 2025-06-01T10:00:00Z)", s)
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("duration %q must not be negative", s)
+	}
+	return now.Add(-d), nil
+}
+
+// summaryStateOrder fixes the order of well-known states in the summary
+// footer; any other state follows alphabetically.
+var summaryStateOrder = []string{"SUCCEEDED", "FAILED", "CANCELLED", "ACTIVE", "QUEUED"}
+
+// executionSummary returns a one-line footer with execution counts by state
+// and the average/median duration of completed executions, e.g.
+// "5 executions: 3 SUCCEEDED, 1 FAILED, 1 ACTIVE (completed: avg 12.5s, median 10s)".
+func executionSummary(execs []workflows.ExecutionInfo) string {
+	counts, durations := executionMetrics(execs)
+
+	var states []string
+	for _, s := range summaryStateOrder {
+		if counts[s] > 0 {
+			states = append(states, s)
+		}
+	}
+	var others []string
+	for s := range counts {
+		if !slices.Contains(summaryStateOrder, s) {
+			others = append(others, s)
+		}
+	}
+	sort.Strings(others)
+	states = append(states, others...)
+
+	parts := make([]string, len(states))
+	for i, s := range states {
+		parts[i] = fmt.Sprintf("%d %s", counts[s], s)
+	}
+
+	noun := "executions"
+	if len(execs) == 1 {
+		noun = "execution"
+	}
+	summary := fmt.Sprintf("%d %s: %s", len(execs), noun, strings.Join(parts, ", "))
+
+	if len(durations) > 0 {
+		slices.Sort(durations)
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		avg := total / time.Duration(len(durations))
+
+		mid := len(durations) / 2
+		median := durations[mid]
+		if len(durations)%2 == 0 {
+			median = (durations[mid-1] + durations[mid]) / 2
+		}
+
+		summary += fmt.Sprintf(" (completed: avg %s, median %s)",
+			avg.Round(time.Millisecond), median.Round(time.Millisecond))
+	}
+	return summary
 }
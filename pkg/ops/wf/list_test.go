@@ -0,0 +1,175 @@
+package wf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func TestExecutionSummary(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	done := func(state string, d time.Duration) workflows.ExecutionInfo {
+		return workflows.ExecutionInfo{State: state, StartTime: start, EndTime: start.Add(d)}
+	}
+
+	tests := []struct {
+		name  string
+		execs []workflows.ExecutionInfo
+		want  string
+	}{
+		{
+			name: "executions are mixed",
+			execs: []workflows.ExecutionInfo{
+				{State: "ACTIVE", StartTime: start},
+				done("FAILED", 4*time.Second),
+				done("SUCCEEDED", 10*time.Second),
+				done("SUCCEEDED", 2*time.Second),
+				done("SUCCEEDED", 30*time.Second),
+			},
+			want: "5 executions: 3 SUCCEEDED, 1 FAILED, 1 ACTIVE (completed: avg 11.5s, median 7s)",
+		},
+		{
+			name:  "only one execution is running",
+			execs: []workflows.ExecutionInfo{{State: "ACTIVE", StartTime: start}},
+			want:  "1 execution: 1 ACTIVE",
+		},
+		{
+			name: "state is unknown",
+			execs: []workflows.ExecutionInfo{
+				{State: "UNAVAILABLE"},
+				done("SUCCEEDED", 3*time.Second),
+			},
+			want: "2 executions: 1 SUCCEEDED, 1 UNAVAILABLE (completed: avg 3s, median 3s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := executionSummary(tt.execs); got != tt.want {
+				t.Errorf("executionSummary() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeOrDuration(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "duration",
+			input: "2h",
+			want:  time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "compound duration",
+			input: "1h30m",
+			want:  time.Date(2025, 6, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339 time",
+			input: "2025-05-31T08:15:00Z",
+			want:  time.Date(2025, 5, 31, 8, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339 time with an offset",
+			input: "2025-06-01T14:00:00+02:00",
+			want:  time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "negative duration",
+			input:   "-1h",
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			input:   "yesterday",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeOrDuration(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeWindow_Boundaries(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	w, err := newTimeWindow("2025-06-01T10:00:00Z", "1h", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	at := func(h, m int) workflows.ExecutionInfo {
+		return workflows.ExecutionInfo{ID: fmt.Sprintf("%02d:%02d", h, m), StartTime: time.Date(2025, 6, 1, h, m, 0, 0, time.UTC)}
+	}
+	execs := []workflows.ExecutionInfo{at(9, 59), at(10, 0), at(10, 30), at(11, 0), at(11, 30)}
+
+	var got []string
+	for _, e := range w.filter(execs) {
+		got = append(got, e.ID)
+	}
+	// --since is inclusive, --until (11:00) is exclusive.
+	if want := []string{"10:00", "10:30"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNewTimeWindow_Errors(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := newTimeWindow("1h", "2h", now); err == nil {
+		t.Error("expected an error when --since is after --until")
+	}
+	if _, err := newTimeWindow("soon", "", now); err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected --since parse error, got %v", err)
+	}
+
+	w, err := newTimeWindow("", "", now)
+	if err != nil || !w.isZero() {
+		t.Errorf("expected an open window, got %+v, %v", w, err)
+	}
+	if execs := []workflows.ExecutionInfo{{ID: "a"}}; len(w.filter(execs)) != 1 {
+		t.Error("an open window should keep every execution")
+	}
+}
+
+func TestExecutionMetrics(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	execs := []workflows.ExecutionInfo{
+		{ID: "a", State: "SUCCEEDED", StartTime: start, EndTime: start.Add(3 * time.Second)},
+		{ID: "b", State: "FAILED", StartTime: start, EndTime: start.Add(1500 * time.Millisecond)},
+		{ID: "c", State: "ACTIVE", StartTime: start},
+	}
+
+	counts, durations := executionMetrics(execs)
+	if want := map[string]int{"SUCCEEDED": 1, "FAILED": 1, "ACTIVE": 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+	if want := []time.Duration{3 * time.Second, 1500 * time.Millisecond}; !reflect.DeepEqual(durations, want) {
+		t.Errorf("durations = %v, want %v", durations, want)
+	}
+}
@@ -0,0 +1,129 @@
+package wf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newLogsCmd() *cobra.Command {
+	var (
+		timeout time.Duration
+		since   time.Duration
+		limit   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs <workflow> <execution-id>",
+		Short: "Show Cloud Logging entries for a workflow execution",
+		Long: `Show the Cloud Logging entries written by a workflow execution, oldest
+first. Failed executions often log the step that failed and why. With
+--limit, the newest entries are shown.
+
+The execution ID may be abbreviated to any unique prefix of a recent execution.
+Requires the Cloud Logging API and roles/logging.viewer.
+
+Examples:
+  # Logs of an execution
+  gcphcp ops wf logs get abc123-def456
+
+  # Only the last hour, at most 50 entries
+  gcphcp ops wf logs get abc123 --since 1h --limit 50
+
+  # One JSON object per entry
+  gcphcp ops wf logs get abc123 -o json`,
+
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowName := args[0]
+			execID := args[1]
+
+			project, _ := cmd.Flags().GetString("project")
+			region, _ := cmd.Flags().GetString("region")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			if project == "" {
+				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
+			}
+			if region == "" {
+				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			client, err := workflows.NewClient(ctx, project, region)
+			if err != nil {
+				return fmt.Errorf("creating client: %w", err)
+			}
+			defer client.Close()
+
+			execID, err = client.ResolveExecution(ctx, workflowName, execID)
+			if err != nil {
+				return fmt.Errorf("resolving execution: %w", err)
+			}
+
+			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
+				project, region, workflowName, execID)
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			printer := newLogEntryPrinter(out, output.ParseFormat(outputFormat))
+			opts := workflows.ExecutionLogOptions{Since: since, Limit: limit}
+			if err := client.StreamExecutionLogs(ctx, execName, opts, printer.print); err != nil {
+				return err
+			}
+			return printer.finish()
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Maximum time to wait for API responses")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this (e.g. 1h); 0 shows all")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of entries to show (0 for no limit)")
+
+	return cmd
+}
+
+// logEntryPrinter writes execution log entries as they arrive, one line
+// each, or one compact JSON object per line with -o json or -o jsonl.
+type logEntryPrinter struct {
+	w      io.Writer
+	format output.Format
+	count  int
+}
+
+func newLogEntryPrinter(w io.Writer, format output.Format) *logEntryPrinter {
+	return &logEntryPrinter{w: w, format: format}
+}
+
+func (p *logEntryPrinter) json() bool {
+	return p.format == output.FormatJSON || p.format == output.FormatJSONL
+}
+
+func (p *logEntryPrinter) print(e workflows.ExecutionLogEntry) error {
+	p.count++
+	if p.json() {
+		return output.PrintJSONCompact(p.w, e)
+	}
+	_, err := fmt.Fprintln(p.w, e.String())
+	return err
+}
+
+// finish reports when no entries were printed. JSON output stays empty.
+func (p *logEntryPrinter) finish() error {
+	if p.count == 0 && !p.json() {
+		fmt.Fprintln(p.w, "No log entries found.")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package wf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func TestLogEntryPrinter(t *testing.T) {
+	entry := workflows.ExecutionLogEntry{
+		Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Severity:  "ERROR",
+		Message:   "step failed",
+	}
+
+	tests := []struct {
+		name    string
+		format  output.Format
+		entries []workflows.ExecutionLogEntry
+		want    string
+	}{
+		{
+			name:    "text",
+			format:  output.FormatText,
+			entries: []workflows.ExecutionLogEntry{entry},
+			want:    "2025-01-01 12:00:00  ERROR    step failed\n",
+		},
+		{
+			name:   "text empty",
+			format: output.FormatText,
+			want:   "No log entries found.\n",
+		},
+		{
+			name:    "json",
+			format:  output.FormatJSON,
+			entries: []workflows.ExecutionLogEntry{entry, entry},
+			want: `{"timestamp":"2025-01-01T12:00:00Z","severity":"ERROR","message":"step failed"}` + "\n" +
+				`{"timestamp":"2025-01-01T12:00:00Z","severity":"ERROR","message":"step failed"}` + "\n",
+		},
+		{
+			name:   "json empty",
+			format: output.FormatJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			p := newLogEntryPrinter(&buf, tt.format)
+			for _, e := range tt.entries {
+				if err := p.print(e); err != nil {
+					t.Fatalf("print: %v", err)
+				}
+			}
+			if err := p.finish(); err != nil {
+				t.Fatalf("finish: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
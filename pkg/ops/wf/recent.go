@@ -0,0 +1,132 @@
+package wf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/journal"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func newRecentCmd() *cobra.Command {
+	var (
+		limit    int
+		workflow string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "recent",
+		Short: "List workflow executions recently started from this machine",
+		Long: `List the executions recently started with "wf run", newest first.
+
+Each run is recorded in a local journal beside the config file, so an
+execution can be found again after detaching with Ctrl+C or starting it
+with --async. Refer to an entry as @N in "wf status", where @1 is the most
+recent.
+
+Examples:
+  # List recent executions
+  gcphcp ops wf recent
+
+  # Only executions of the 'get' workflow
+  gcphcp ops wf recent --workflow get
+
+  # Check the status of the most recent execution
+  gcphcp ops wf status @1`,
+
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			configPath, _ := cmd.Flags().GetString("config")
+			entries, err := listJournal(configPath)
+			if err != nil {
+				return err
+			}
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			return printRecent(out, entries, workflow, limit, output.ParseFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of executions to show")
+	cmd.Flags().StringVar(&workflow, "workflow", "", "Only show executions of this workflow")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
+
+	return cmd
+}
+
+// listJournal returns the local execution journal kept beside the config
+// file resolved from configPath, newest first.
+func listJournal(configPath string) ([]journal.Entry, error) {
+	dir := config.ResolveDir(configPath)
+	if dir == "" {
+		return nil, fmt.Errorf("cannot locate the config directory for the execution journal")
+	}
+	return journal.List(journal.DefaultPath(dir))
+}
+
+// printRecent writes up to limit journal entries, optionally only those of
+// workflow. Entries keep their @N position in the full journal so the
+// numbers work with "wf status @N".
+func printRecent(w io.Writer, entries []journal.Entry, workflow string, limit int, format output.Format) error {
+	type numbered struct {
+		Ref string `json:"ref"`
+		journal.Entry
+	}
+	var shown []numbered
+	for i, e := range entries {
+		if workflow != "" && e.Workflow != workflow {
+			continue
+		}
+		if limit > 0 && len(shown) >= limit {
+			break
+		}
+		shown = append(shown, numbered{Ref: fmt.Sprintf("@%d", i+1), Entry: e})
+	}
+
+	if format == output.FormatJSON {
+		if shown == nil {
+			shown = []numbered{}
+		}
+		return output.PrintJSON(w, shown)
+	}
+
+	if len(shown) == 0 {
+		fmt.Fprintln(w, "No recent executions.")
+		return nil
+	}
+
+	t := output.NewTable(w, "REF", "STARTED", "WORKFLOW", "EXECUTION_ID", "PROJECT", "REGION")
+	for _, e := range shown {
+		t.AddRow(e.Ref, e.StartTime.Format("2006-01-02 15:04:05"), e.Workflow, e.ExecutionID, e.Project, e.Region)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\nCheck one with: gcphcp ops wf status %s\n", shown[0].Ref)
+	return nil
+}
+
+// journalRef returns the journal entry named by an @N reference, where @1
+// is the most recent execution.
+func journalRef(entries []journal.Entry, ref string) (journal.Entry, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(ref, "@"))
+	if !strings.HasPrefix(ref, "@") || err != nil || n < 1 {
+		return journal.Entry{}, fmt.Errorf("invalid execution reference %q: expected @N, e.g. @1 for the most recent (see \"wf recent\")", ref)
+	}
+	if n > len(entries) {
+		return journal.Entry{}, fmt.Errorf("no execution %s: the journal has %d (see \"wf recent\")", ref, len(entries))
+	}
+	return entries[n-1], nil
+}
@@ -0,0 +1,167 @@
+package wf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/journal"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func testJournal() []journal.Entry {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []journal.Entry{
+		{Workflow: "describe", ExecutionID: "ccc", Project: "p", Region: "us-central1", StartTime: start.Add(2 * time.Minute)},
+		{Workflow: "get", ExecutionID: "bbb", Project: "p", Region: "us-central1", StartTime: start.Add(time.Minute)},
+		{Workflow: "get", ExecutionID: "aaa", Project: "p", Region: "us-central1", StartTime: start},
+	}
+}
+
+func TestPrintRecent(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow string
+		limit    int
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:  "no filter is set",
+			limit: 10,
+			want:  []string{"@1", "ccc", "@3", "aaa", "2025-01-01 12:02:00", "gcphcp ops wf status @1"},
+		},
+		{
+			name:     "--workflow is set",
+			workflow: "get",
+			limit:    10,
+			want:     []string{"@2", "bbb", "@3", "aaa", "gcphcp ops wf status @2"},
+			notWant:  []string{"ccc"},
+		},
+		{
+			name:    "--limit is set",
+			limit:   1,
+			want:    []string{"@1", "ccc"},
+			notWant: []string{"bbb", "aaa"},
+		},
+		{
+			name:     "nothing matches",
+			workflow: "restart",
+			limit:    10,
+			want:     []string{"No recent executions."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printRecent(&buf, testJournal(), tt.workflow, tt.limit, output.FormatText); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPrintRecent_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printRecent(&buf, testJournal(), "get", 0, output.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if len(got) != 2 || got[0]["ref"] != "@2" || got[0]["execution_id"] != "bbb" || got[0]["workflow"] != "get" {
+		t.Errorf("unexpected JSON: %v", got)
+	}
+}
+
+func TestJournalRef(t *testing.T) {
+	entries := testJournal()
+
+	got, err := journalRef(entries, "@2")
+	if err != nil || got.ExecutionID != "bbb" {
+		t.Errorf("journalRef(@2) = %+v, %v; want bbb", got, err)
+	}
+	for _, ref := range []string{"get", "@0", "@x", "@4"} {
+		if _, err := journalRef(entries, ref); err == nil {
+			t.Errorf("expected an error for %q", ref)
+		}
+	}
+}
+
+func TestRunWorkflow_RecordsExecution(t *testing.T) {
+	var recorded string
+	w := runWriters{progress: &bytes.Buffer{}, status: &bytes.Buffer{}, record: func(execName string) error {
+		recorded = execName
+		return nil
+	}}
+	runner := &fakeExecutionRunner{}
+	if _, err := runWorkflow(context.Background(), runner, "get", nil, nil, true, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorded != "projects/p/locations/r/workflows/get/executions/abc-123" {
+		t.Errorf("recorded %q", recorded)
+	}
+}
+
+func TestRunWorkflow_RecordFailureWarns(t *testing.T) {
+	var warn bytes.Buffer
+	w := runWriters{progress: io.Discard, status: io.Discard, warn: &warn, record: func(string) error {
+		return errors.New("recording execution: disk full")
+	}}
+	if _, err := runWorkflow(context.Background(), &fakeExecutionRunner{}, "get", nil, nil, true, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := warn.String(); got != "Warning: recording execution: disk full\n" {
+		t.Errorf("warn = %q", got)
+	}
+}
+
+func TestJournalRecorder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+
+	record := journalRecorder(filepath.Join(dir, "config.yaml"), "p", "us-central1", map[string]interface{}{"resource_type": "nodes"})
+	if record == nil {
+		t.Fatal("expected a recorder")
+	}
+	if err := record("projects/p/locations/us-central1/workflows/get/executions/abc-123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := journal.List(journal.DefaultPath(dir))
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Workflow != "get" || entries[0].ExecutionID != "abc-123" || entries[0].Region != "us-central1" || entries[0].ArgsHash == "" {
+		t.Errorf("unexpected journal: %+v", entries)
+	}
+
+	listed, err := listJournal(filepath.Join(dir, "config.yaml"))
+	if err != nil || len(listed) != 1 {
+		t.Errorf("listJournal() beside the config = %+v, %v; want the recorded entry", listed, err)
+	}
+	if _, err := os.Stat(journal.DefaultPath(config.DefaultConfigDir())); !os.IsNotExist(err) {
+		t.Errorf("journal written under the default config dir: %v", err)
+	}
+}
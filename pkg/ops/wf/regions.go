@@ -0,0 +1,237 @@
+package wf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// defaultRegions are queried by --all-regions when neither --regions nor
+// GCPHCP_REGIONS names any.
+var defaultRegions = []string{
+	"us-central1", "us-east1", "us-east4", "us-west1",
+	"europe-west1", "europe-west4",
+	"asia-northeast1", "asia-southeast1",
+}
+
+// maxRegionConcurrency bounds how many regions --all-regions queries at once.
+const maxRegionConcurrency = 4
+
+// regionClient is the part of *workflows.Client that the --all-regions
+// listing uses.
+type regionClient interface {
+	List(ctx context.Context) ([]workflows.WorkflowInfo, error)
+	ListExecutions(ctx context.Context, workflow string, limit int) ([]workflows.ExecutionInfo, error)
+	Close() error
+}
+
+// newRegionClient opens a client for one region.
+type newRegionClient func(ctx context.Context, region string) (regionClient, error)
+
+// regionFailure records a region whose client or call failed during an
+// --all-regions listing. The other regions' rows are still shown.
+type regionFailure struct {
+	region string
+	err    error
+}
+
+// resolveRegions returns the regions --all-regions queries: the --regions
+// list if set, else the defaults, each normalized and de-duplicated.
+func resolveRegions(flagRegions []string) ([]string, error) {
+	if len(flagRegions) == 0 {
+		flagRegions = defaultRegions
+	}
+	var regions []string
+	seen := map[string]bool{}
+	for _, r := range flagRegions {
+		region, err := config.NormalizeRegion(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --regions: %w", err)
+		}
+		if !seen[region] {
+			seen[region] = true
+			regions = append(regions, region)
+		}
+	}
+	return regions, nil
+}
+
+// envRegions returns the comma-separated GCPHCP_REGIONS list, if set.
+func envRegions() []string {
+	var regions []string
+	for _, r := range strings.Split(os.Getenv("GCPHCP_REGIONS"), ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+// forEachRegion opens a client per region and calls fn with it, with at most
+// maxConcurrency regions in flight. A region whose client or call fails is
+// returned in failures, in region order, rather than aborting the others.
+// Once ctx is done no further regions are started, and forEachRegion returns
+// after the ones in flight have finished.
+func forEachRegion(ctx context.Context, regions []string, maxConcurrency int, newClient newRegionClient, fn func(i int, c regionClient) error) []regionFailure {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	errs := make([]error, len(regions))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		select {
+		case sem <- struct{}{}:
+			if ctx.Err() != nil {
+				<-sem
+				errs[i] = context.Cause(ctx)
+				continue
+			}
+		case <-ctx.Done():
+			errs[i] = context.Cause(ctx)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			client, err := newClient(ctx, region)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer client.Close()
+			errs[i] = fn(i, client)
+		}()
+	}
+	wg.Wait()
+
+	var failures []regionFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, regionFailure{region: regions[i], err: err})
+		}
+	}
+	return failures
+}
+
+// regionWorkflow is a workflow found by an --all-regions listing.
+type regionWorkflow struct {
+	Region string `json:"region"`
+	workflows.WorkflowInfo
+}
+
+// regionExecution is an execution found by an --all-regions listing.
+type regionExecution struct {
+	Region string `json:"region"`
+	workflows.ExecutionInfo
+}
+
+// listWorkflowsAllRegions lists the workflows deployed in every region.
+func listWorkflowsAllRegions(ctx context.Context, regions []string, newClient newRegionClient) ([]regionWorkflow, []regionFailure) {
+	perRegion := make([][]workflows.WorkflowInfo, len(regions))
+	failures := forEachRegion(ctx, regions, maxRegionConcurrency, newClient, func(i int, c regionClient) error {
+		wfs, err := c.List(ctx)
+		perRegion[i] = wfs
+		return err
+	})
+
+	var rows []regionWorkflow
+	for i, wfs := range perRegion {
+		for _, wf := range wfs {
+			rows = append(rows, regionWorkflow{Region: regions[i], WorkflowInfo: wf})
+		}
+	}
+	return rows, failures
+}
+
+// listExecutionsAllRegions lists the newest limit executions of workflow in
+// every region that passes window.
+func listExecutionsAllRegions(ctx context.Context, regions []string, newClient newRegionClient, workflow string, limit int, window timeWindow) ([]regionExecution, []regionFailure) {
+	perRegion := make([][]workflows.ExecutionInfo, len(regions))
+	failures := forEachRegion(ctx, regions, maxRegionConcurrency, newClient, func(i int, c regionClient) error {
+		execs, err := c.ListExecutions(ctx, workflow, limit)
+		perRegion[i] = window.filter(execs)
+		return err
+	})
+
+	var rows []regionExecution
+	for i, execs := range perRegion {
+		for _, e := range execs {
+			rows = append(rows, regionExecution{Region: regions[i], ExecutionInfo: e})
+		}
+	}
+	return rows, failures
+}
+
+// printRegionFailures warns about each region that could not be listed.
+func printRegionFailures(w io.Writer, failures []regionFailure) {
+	for _, f := range failures {
+		fmt.Fprintf(w, "Warning: region %s: %v\n", f.region, f.err)
+	}
+}
+
+// printRegionWorkflows renders an --all-regions workflow listing, with a
+// leading REGION column in the table.
+func printRegionWorkflows(w io.Writer, rows []regionWorkflow, format output.Format) error {
+	if format == output.FormatJSON {
+		if rows == nil {
+			rows = []regionWorkflow{}
+		}
+		return output.PrintJSON(w, rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No workflows found.")
+		return nil
+	}
+
+	t := output.NewTable(w, "REGION", "NAME", "STATE", "REVISION", "UPDATED")
+	for _, r := range rows {
+		t.AddRow(r.Region, r.Name, r.State, r.RevisionID, r.UpdateTime.Format(time.RFC3339))
+	}
+	return t.Flush()
+}
+
+// printRegionExecutions renders an --all-regions execution listing, with a
+// leading REGION column in the table and the summary footer over all regions.
+func printRegionExecutions(w io.Writer, workflow string, rows []regionExecution, format output.Format) error {
+	if format == output.FormatJSON {
+		if rows == nil {
+			rows = []regionExecution{}
+		}
+		return output.PrintJSON(w, rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintf(w, "No executions found for workflow '%s'.\n", workflow)
+		return nil
+	}
+
+	execs := make([]workflows.ExecutionInfo, len(rows))
+	t := output.NewTable(w, "REGION", "ID", "STATE", "STARTED", "DURATION")
+	for i, r := range rows {
+		execs[i] = r.ExecutionInfo
+		duration := r.Duration
+		if duration == "" {
+			duration = "running"
+		}
+		t.AddRow(r.Region, r.ID, r.State, output.Age(r.StartTime.Format(time.RFC3339))+" ago", duration)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%s\n", executionSummary(execs))
+	return nil
+}
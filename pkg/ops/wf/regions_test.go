@@ -0,0 +1,177 @@
+package wf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+type fakeRegionClient struct {
+	region string
+	err    error
+	closed *atomic.Int32
+}
+
+func (f *fakeRegionClient) List(context.Context) ([]workflows.WorkflowInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []workflows.WorkflowInfo{{Name: "get", State: "ACTIVE", RevisionID: "000001-" + f.region}}, nil
+}
+
+func (f *fakeRegionClient) ListExecutions(_ context.Context, workflow string, _ int) ([]workflows.ExecutionInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []workflows.ExecutionInfo{{ID: f.region + "-exec", State: "SUCCEEDED"}}, nil
+}
+
+func (f *fakeRegionClient) Close() error {
+	f.closed.Add(1)
+	return nil
+}
+
+// fakeRegionClients returns a client factory where the regions in failing
+// fail their calls and "broken-region1" fails to open a client at all.
+func fakeRegionClients(closed *atomic.Int32, failing ...string) newRegionClient {
+	return func(_ context.Context, region string) (regionClient, error) {
+		if region == "broken-region1" {
+			return nil, errors.New("creating client: no credentials")
+		}
+		c := &fakeRegionClient{region: region, closed: closed}
+		for _, f := range failing {
+			if f == region {
+				c.err = fmt.Errorf("permission denied")
+			}
+		}
+		return c, nil
+	}
+}
+
+func TestListWorkflowsAllRegions(t *testing.T) {
+	var closed atomic.Int32
+	regions := []string{"us-central1", "europe-west1", "broken-region1", "asia-east1"}
+	rows, failures := listWorkflowsAllRegions(context.Background(), regions, fakeRegionClients(&closed, "europe-west1"))
+
+	var got []string
+	for _, r := range rows {
+		got = append(got, r.Region+"/"+r.Name)
+	}
+	if want := "us-central1/get asia-east1/get"; strings.Join(got, " ") != want {
+		t.Errorf("rows = %v, want %s", got, want)
+	}
+
+	if len(failures) != 2 || failures[0].region != "europe-west1" || failures[1].region != "broken-region1" {
+		t.Fatalf("unexpected failures: %+v", failures)
+	}
+	if closed.Load() != 3 {
+		t.Errorf("closed %d clients, want 3", closed.Load())
+	}
+
+	var stderr bytes.Buffer
+	printRegionFailures(&stderr, failures)
+	if !strings.Contains(stderr.String(), "Warning: region europe-west1: permission denied") {
+		t.Errorf("unexpected warnings: %q", stderr.String())
+	}
+}
+
+func TestForEachRegion_StopsWhenCancelled(t *testing.T) {
+	var closed atomic.Int32
+	stop := errors.New("interrupted")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	var started []string
+	regions := []string{"us-central1", "us-east1", "us-west1"}
+	failures := forEachRegion(ctx, regions, 1, fakeRegionClients(&closed), func(i int, _ regionClient) error {
+		started = append(started, regions[i])
+		cancel(stop)
+		return nil
+	})
+
+	if len(started) != 1 || started[0] != "us-central1" {
+		t.Errorf("started %v, want only us-central1", started)
+	}
+	if len(failures) != 2 || !errors.Is(failures[0].err, stop) || failures[1].region != "us-west1" {
+		t.Errorf("unexpected failures: %+v", failures)
+	}
+	if closed.Load() != 1 {
+		t.Errorf("closed %d clients, want 1", closed.Load())
+	}
+}
+
+func TestListExecutionsAllRegions(t *testing.T) {
+	var closed atomic.Int32
+	rows, failures := listExecutionsAllRegions(context.Background(), []string{"us-east1", "us-west1"},
+		fakeRegionClients(&closed), "get", 10, timeWindow{})
+	if len(failures) != 0 {
+		t.Fatalf("unexpected failures: %+v", failures)
+	}
+	if len(rows) != 2 || rows[0].ID != "us-east1-exec" || rows[1].Region != "us-west1" {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestPrintRegionWorkflows(t *testing.T) {
+	rows := []regionWorkflow{
+		{Region: "us-central1", WorkflowInfo: workflows.WorkflowInfo{Name: "get", State: "ACTIVE", RevisionID: "000003-abc", UpdateTime: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	var buf bytes.Buffer
+	if err := printRegionWorkflows(&buf, rows, output.FormatText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "REGION") || !strings.HasPrefix(lines[1], "us-central1") {
+		t.Errorf("expected a leading REGION column, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := printRegionWorkflows(&buf, rows, output.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"region": "us-central1"`) || !strings.Contains(buf.String(), `"name": "get"`) {
+		t.Errorf("expected region and workflow fields in JSON, got %s", buf.String())
+	}
+}
+
+func TestPrintRegionExecutions(t *testing.T) {
+	rows := []regionExecution{
+		{Region: "us-east1", ExecutionInfo: workflows.ExecutionInfo{ID: "abc", State: "ACTIVE", StartTime: time.Now()}},
+	}
+	var buf bytes.Buffer
+	if err := printRegionExecutions(&buf, "get", rows, output.FormatText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasPrefix(lines[0], "REGION") || !strings.Contains(lines[1], "us-east1") || !strings.Contains(lines[1], "running") {
+		t.Errorf("unexpected table:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "1 execution: 1 ACTIVE") {
+		t.Errorf("expected summary footer, got:\n%s", buf.String())
+	}
+}
+
+func TestResolveRegions(t *testing.T) {
+	got, err := resolveRegions([]string{"US-East1", "us-east1", "europe-west4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "us-east1,europe-west4" {
+		t.Errorf("got %v", got)
+	}
+	if _, err := resolveRegions([]string{"us-east-1"}); err == nil || !strings.Contains(err.Error(), "did you mean us-east1?") {
+		t.Errorf("expected a suggestion, got %v", err)
+	}
+	if got, _ := resolveRegions(nil); len(got) != len(defaultRegions) {
+		t.Errorf("expected the default regions, got %v", got)
+	}
+}
@@ -1,21 +1,26 @@
 package wf
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"strconv"
 	"time"
 
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 func newResumeCmd() *cobra.Command {
 	var (
-		data    string
-		timeout time.Duration
-		wait    bool
+		data          string
+		timeout       time.Duration
+		wait          bool
+		callbackIndex int
+		listCallbacks bool
 	)
 
 	cmd := &cobra.Command{
@@ -25,7 +30,13 @@ func newResumeCmd() *cobra.Command {
 
 Fetches the pending callback URL for the execution and triggers it
 with the provided data. Use 'gcphcp ops wf status' to see if an
-execution has pending callbacks.
+execution has pending callbacks. The execution ID may be abbreviated
+to any unique prefix of a recent execution.
+
+With -o json, prints {"execution", "callback", "callback_triggered", "state"}
+for scripts; with --wait the state is the final one and the result or error
+is included. With --wait, an execution that ends FAILED makes the command
+exit non-zero.
 
 Examples:
   # Resume with approval data
@@ -35,7 +46,11 @@ Examples:
   gcphcp ops wf resume approval-flow abc123-def456
 
   # Resume and wait for completion
-  gcphcp ops wf resume approval-flow abc123-def456 --data '{"approved": true}' --wait`,
+  gcphcp ops wf resume approval-flow abc123-def456 --data '{"approved": true}' --wait
+
+  # Show pending callbacks, then trigger the second one
+  gcphcp ops wf resume approval-flow abc123-def456 --list-callbacks
+  gcphcp ops wf resume approval-flow abc123-def456 --callback-index 1`,
 
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,6 +60,7 @@ Examples:
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -53,18 +69,24 @@ Examples:
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
 
-			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
-				project, region, workflowName, execID)
-
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
-			client, err := workflows.NewClient(ctx, project, region)
+			projectNumber, _ := cmd.Flags().GetString("project-number")
+			client, err := workflows.NewClient(ctx, project, region, workflows.WithProjectNumber(projectNumber))
 			if err != nil {
 				return fmt.Errorf("creating client: %w", err)
 			}
 			defer client.Close()
 
+			execID, err = client.ResolveExecution(ctx, workflowName, execID)
+			if err != nil {
+				return fmt.Errorf("resolving execution: %w", err)
+			}
+
+			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
+				project, region, workflowName, execID)
+
 			result, err := client.GetExecution(ctx, execName)
 			if err != nil {
 				return fmt.Errorf("getting execution status: %w", err)
@@ -82,7 +104,20 @@ Examples:
 				return fmt.Errorf("execution is ACTIVE but has no pending callbacks")
 			}
 
-			cb := callbacks[0]
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			if listCallbacks {
+				return printCallbacks(out, callbacks, output.ParseFormat(outputFormat))
+			}
+
+			cb, err := selectCallback(callbacks, callbackIndex)
+			if err != nil {
+				return err
+			}
 
 			var parsedData map[string]interface{}
 			if data != "" {
@@ -91,33 +126,113 @@ Examples:
 				}
 			}
 
-			fmt.Fprintf(os.Stderr, "Triggering callback: %s %s\n", cb.Method, cb.URL)
+			progress.Printf(cmd, "Triggering callback: %s %s\n", cb.Method, cb.URL)
 
 			if err := client.TriggerCallback(ctx, cb.URL, cb.Method, parsedData); err != nil {
 				return fmt.Errorf("triggering callback: %w", err)
 			}
 
-			fmt.Fprintf(os.Stderr, "Callback triggered. Workflow resuming.\n")
+			progress.Printf(cmd, "Callback triggered. Workflow resuming.\n")
+
+			format := output.ParseFormat(outputFormat)
 
-			if wait {
-				fmt.Fprintf(os.Stderr, "Waiting for execution to complete...\n")
-				result, err := client.WaitForCompletion(ctx, execName)
-				if err != nil {
-					return fmt.Errorf("waiting for execution: %w", err)
+			if !wait {
+				if format == output.FormatJSON {
+					return output.PrintJSON(out, newResumeResult(execName, cb, nil))
 				}
-				return printStatus(result, workflowName, execID, outputFormat)
+				fmt.Fprintf(progress.Stderr(cmd), "\nCheck progress with:\n")
+				fmt.Fprintf(progress.Stderr(cmd), "  gcphcp ops wf status %s %s\n", workflowName, execID)
+				return nil
 			}
 
-			fmt.Fprintf(os.Stderr, "\nCheck progress with:\n")
-			fmt.Fprintf(os.Stderr, "  gcphcp ops wf status %s %s\n", workflowName, execID)
-
-			return nil
+			progress.Printf(cmd, "Waiting for execution to complete...\n")
+			final, err := client.WaitForCompletion(ctx, execName)
+			if err != nil {
+				return fmt.Errorf("waiting for execution: %w", err)
+			}
+			return printResumed(out, execName, cb, final, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVar(&data, "data", "", "JSON data to send with the callback")
+	cmd.Flags().String("project-number", "", "Numeric project number for callback URLs (skips the project ID lookup)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the execution to complete after resuming")
+	cmd.Flags().IntVar(&callbackIndex, "callback-index", 0, "Index of the pending callback to trigger (see --list-callbacks)")
+	cmd.Flags().BoolVar(&listCallbacks, "list-callbacks", false, "List pending callbacks and exit without triggering one")
 
 	return cmd
 }
+
+// selectCallback returns the pending callback at index, with an error naming
+// the valid range when index is out of bounds.
+func selectCallback(callbacks []workflows.CallbackInfo, index int) (workflows.CallbackInfo, error) {
+	if index < 0 || index >= len(callbacks) {
+		return workflows.CallbackInfo{}, fmt.Errorf("--callback-index %d out of range: execution has %d pending callback(s) (valid: 0-%d); use --list-callbacks to see them",
+			index, len(callbacks), len(callbacks)-1)
+	}
+	return callbacks[index], nil
+}
+
+// printCallbacks lists pending callbacks with the index to pass to
+// --callback-index.
+func printCallbacks(w io.Writer, callbacks []workflows.CallbackInfo, format output.Format) error {
+	if format == output.FormatJSON {
+		return output.PrintJSON(w, callbacks)
+	}
+
+	t := output.NewTable(w, "INDEX", "METHOD", "URL")
+	for i, cb := range callbacks {
+		t.AddRow(strconv.Itoa(i), cb.Method, cb.URL)
+	}
+	return t.Flush()
+}
+
+// printResumed writes the outcome of resume --wait: the resume result with
+// -o json, otherwise the final execution status. A FAILED execution is
+// returned as an error, so the command exits non-zero in every mode.
+func printResumed(w io.Writer, execName string, cb workflows.CallbackInfo, final *workflows.ExecutionResult, outputFormat string) error {
+	format := output.ParseFormat(outputFormat)
+	var err error
+	if format == output.FormatJSON {
+		err = output.PrintJSON(w, newResumeResult(execName, cb, final))
+	} else {
+		workflowName, execID := workflows.ParseExecutionName(execName)
+		err = printStatus(w, final, workflowName, execID, outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	if final.State == "FAILED" {
+		return WorkflowFailure(io.Discard, format, final.Error)
+	}
+	return nil
+}
+
+// resumeResult is the machine-readable outcome of wf resume.
+type resumeResult struct {
+	Execution         string                 `json:"execution"`
+	Callback          string                 `json:"callback"`
+	CallbackTriggered bool                   `json:"callback_triggered"`
+	State             string                 `json:"state"`
+	Result            map[string]interface{} `json:"result,omitempty"`
+	Error             string                 `json:"error,omitempty"`
+}
+
+// newResumeResult describes a triggered callback. final is the execution
+// status after --wait, or nil when the command did not wait.
+func newResumeResult(execName string, cb workflows.CallbackInfo, final *workflows.ExecutionResult) resumeResult {
+	r := resumeResult{
+		Execution:         execName,
+		Callback:          cb.Name,
+		CallbackTriggered: true,
+		State:             "ACTIVE",
+	}
+	if final != nil {
+		r.State = final.State
+		r.Result = final.Result
+		r.Error = final.Error
+	}
+	return r
+}
@@ -0,0 +1,170 @@
+package wf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+func testCallbacks() []workflows.CallbackInfo {
+	return []workflows.CallbackInfo{
+		{Name: "exec/callbacks/approve", Method: "POST", URL: "https://example.com/approve"},
+		{Name: "exec/callbacks/reject", Method: "POST", URL: "https://example.com/reject"},
+	}
+}
+
+func TestSelectCallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		wantURL string
+		wantErr string
+	}{
+		{name: "index is 0", index: 0, wantURL: "https://example.com/approve"},
+		{name: "index is 1", index: 1, wantURL: "https://example.com/reject"},
+		{name: "index is past the end", index: 2, wantErr: "out of range: execution has 2 pending callback(s) (valid: 0-1)"},
+		{name: "index is negative", index: -1, wantErr: "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, err := selectCallback(testCallbacks(), tt.index)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cb.URL != tt.wantURL {
+				t.Errorf("URL = %q, want %q", cb.URL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestPrintCallbacks(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printCallbacks(&buf, testCallbacks(), output.FormatText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "0") || !strings.Contains(lines[1], "approve") {
+		t.Errorf("unexpected first row: %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "1") || !strings.Contains(lines[2], "reject") {
+		t.Errorf("unexpected second row: %q", lines[2])
+	}
+}
+
+func TestNewResumeResult_JSONShape(t *testing.T) {
+	execName := "projects/p/locations/r/workflows/approval/executions/abc"
+	cb := testCallbacks()[0]
+
+	t.Run("not waiting", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := output.PrintJSON(&buf, newResumeResult(execName, cb, nil)); err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		want := map[string]interface{}{
+			"execution":          execName,
+			"callback":           "exec/callbacks/approve",
+			"callback_triggered": true,
+			"state":              "ACTIVE",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("waiting", func(t *testing.T) {
+		final := &workflows.ExecutionResult{State: "SUCCEEDED", Result: map[string]interface{}{"approved": true}}
+		var buf bytes.Buffer
+		if err := output.PrintJSON(&buf, newResumeResult(execName, cb, final)); err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if got["state"] != "SUCCEEDED" || got["callback_triggered"] != true {
+			t.Errorf("unexpected result: %v", got)
+		}
+		if res, _ := got["result"].(map[string]interface{}); res["approved"] != true {
+			t.Errorf("expected final result, got %v", got["result"])
+		}
+		if _, ok := got["error"]; ok {
+			t.Errorf("error should be omitted on success: %v", got)
+		}
+	})
+}
+
+func TestPrintResumed(t *testing.T) {
+	execName := "projects/p/locations/r/workflows/approval/executions/abc"
+	cb := testCallbacks()[0]
+
+	tests := []struct {
+		name       string
+		state      string
+		format     string
+		wantOut    string
+		wantFailed bool
+	}{
+		{name: "succeeded, json", state: "SUCCEEDED", format: "json", wantOut: `"state": "SUCCEEDED"`},
+		{name: "failed, json", state: "FAILED", format: "json", wantOut: `"state": "FAILED"`, wantFailed: true},
+		{name: "failed, text", state: "FAILED", format: "text", wantOut: "FAILED", wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final := &workflows.ExecutionResult{Name: execName, State: tt.state}
+			if tt.state == "FAILED" {
+				final.Error = `RuntimeError: "denied"`
+			}
+
+			var buf bytes.Buffer
+			err := printResumed(&buf, execName, cb, final, tt.format)
+
+			var failed *workflows.ExecutionFailedError
+			if got := errors.As(err, &failed); got != tt.wantFailed {
+				t.Fatalf("err = %v, want failure: %v", err, tt.wantFailed)
+			}
+			if tt.wantFailed && failed.Message != "denied" {
+				t.Errorf("failure message = %q", failed.Message)
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output missing %q:\n%s", tt.wantOut, buf.String())
+			}
+			if strings.Count(buf.String(), `"execution"`) > 1 {
+				t.Errorf("expected a single JSON document:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestNewCancelResult(t *testing.T) {
+	got := newCancelResult("executions/abc", &workflows.ExecutionResult{State: "CANCELLED"})
+	if got != (cancelResult{Execution: "executions/abc", Cancelled: true, State: "CANCELLED"}) {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	got = newCancelResult("executions/abc", &workflows.ExecutionResult{State: "SUCCEEDED"})
+	if got.Cancelled {
+		t.Error("an execution that already finished should not report cancelled")
+	}
+}
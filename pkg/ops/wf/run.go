@@ -1,24 +1,41 @@
 package wf
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/ckandag/gcp-hcp-cli/pkg/config"
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/journal"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
 	"github.com/ckandag/gcp-hcp-cli/pkg/ops/pam"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
 	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func newRunCmd() *cobra.Command {
 	var (
-		data    string
-		async   bool
-		timeout time.Duration
+		data        string
+		dataFile    string
+		inputFormat string
+		async       bool
+		timeout     time.Duration
+		labelArgs   []string
+		requestID   string
+		raw         bool
+		resultOnly  bool
 	)
 
 	cmd := &cobra.Command{
@@ -36,16 +53,40 @@ Examples:
   # Run asynchronously (returns immediately)
   gcphcp ops wf run describe --data '{"resource_type": "pods", "name": "etcd-0", "namespace": "hypershift"}' --async
 
+  # Read arguments from a file, or from stdin with "-"
+  gcphcp ops wf run get --data-file args.json
+  echo '{"resource_type": "nodes"}' | gcphcp ops wf run get --data-file -
+
+  # YAML arguments are accepted too
+  gcphcp ops wf run get --data-file args.yaml
+
+  # In CI, pass a JSON object through the environment
+  GCPHCP_WF_DATA='{"resource_type": "nodes"}' gcphcp ops wf run get
+
   # Run with a timeout
-  gcphcp ops wf run get --data '{"resource_type": "nodes"}' --timeout 60s`,
+  gcphcp ops wf run get --data '{"resource_type": "nodes"}' --timeout 60s
+
+  # Print a plain-text or pre-formatted result verbatim
+  gcphcp ops wf run report --raw
+
+  # Only the result: no execution ID, progress, or state lines on stderr
+  gcphcp ops wf run get --data '{"resource_type": "nodes"}' -o json --result-only
+
+  # Tag the execution for correlation in Cloud Logging
+  gcphcp ops wf run restart --data-file args.json --request-id inc-1234 --label team=sre
 
-		Args: cobra.ExactArgs(1),
+Started executions are recorded locally; list them with "gcphcp ops wf recent".`,
+
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeWorkflowNames,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			workflowName := args[0]
+			progress.With(cmd, "workflow", workflowName)
 
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -54,16 +95,21 @@ Examples:
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
 
-			var parsedData map[string]interface{}
-			if data != "" {
-				if err := json.Unmarshal([]byte(data), &parsedData); err != nil {
-					return fmt.Errorf("invalid --data JSON: %w", err)
-				}
-			} else {
-				parsedData = map[string]interface{}{}
+			if resultOnly && async {
+				return fmt.Errorf("--result-only cannot be used with --async")
+			}
+
+			parsedData, err := loadRunData(data, dataFile, inputFormat, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			execLabels, err := parseExecutionLabels(labelArgs, requestID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), timeout, progress.Stderr(cmd))
 			defer cancel()
 
 			client, err := workflows.NewClient(ctx, project, region)
@@ -82,49 +128,365 @@ Examples:
 			}
 			if labels != nil {
 				reason, _ := cmd.Flags().GetString("reason")
-				if err := pam.EnsurePAMGrant(ctx, project, pamEntitlement, reason, labels, os.Stdin, os.Stderr); err != nil {
+				if err := pam.EnsurePAMGrant(ctx, project, pamEntitlement, reason, labels, os.Stdin, progress.Stderr(cmd)); err != nil {
 					return err
 				}
 			}
 
-			fmt.Fprintf(os.Stderr, "Executing workflow: %s\n", workflowName)
-
-			execName, err := client.Execute(ctx, workflowName, parsedData)
-			if err != nil {
-				return fmt.Errorf("executing workflow: %w", err)
+			writers := newRunWriters(cmd, resultOnly)
+			configPath, _ := cmd.Flags().GetString("config")
+			writers.record = journalRecorder(configPath, project, region, parsedData)
+			result, err := runWorkflow(ctx, client, workflowName, parsedData, execLabels, async, writers)
+			if err != nil || result == nil {
+				return err
 			}
 
-			execID := path.Base(execName)
-			fmt.Fprintf(os.Stderr, "Execution: %s\n", execID)
-
-			if async {
-				fmt.Fprintf(os.Stderr, "Workflow started. Check status with:\n")
-				fmt.Fprintf(os.Stderr, "  gcphcp ops wf status %s %s\n", workflowName, execID)
-				return nil
-			}
-
-			fmt.Fprintf(os.Stderr, "Waiting for completion... (Ctrl+C to detach)\n")
-
-			result, err := client.WaitForCompletion(ctx, execName)
+			out, err := output.ResolveOutputWriter(outputFile)
 			if err != nil {
-				return fmt.Errorf("waiting for workflow: %w\n\nCheck status with: gcphcp ops wf status %s %s", err, workflowName, execID)
+				return err
 			}
-
-			fmt.Fprintf(os.Stderr, "State: %s  Duration: %s\n", result.State, result.Duration.Round(time.Millisecond))
+			defer out.Close()
 
 			if result.State == "FAILED" {
-				fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
-				os.Exit(1)
+				return WorkflowFailure(out, output.ParseFormat(outputFormat), result.Error)
 			}
-
-			format := output.ParseFormat(outputFormat)
-			return output.PrintResult(os.Stdout, format, result.Result)
+			return printRunResult(out, result, output.ParseFormat(outputFormat), raw)
 		},
 	}
 
-	cmd.Flags().StringVar(&data, "data", "", "JSON data to pass as workflow arguments")
+	cmd.Flags().StringVar(&data, "data", "", "JSON or YAML data to pass as workflow arguments (default: $"+envRunData+")")
+	cmd.Flags().StringVar(&dataFile, "data-file", "", "File containing JSON or YAML workflow arguments (\"-\" for stdin)")
+	cmd.Flags().StringVar(&inputFormat, "input-format", "auto", "Format of --data/--data-file: json, yaml, or auto")
+	cmd.Flags().StringArrayVar(&labelArgs, "label", nil, "Execution label as key=value (repeatable)")
+	cmd.Flags().StringVar(&requestID, "request-id", "", "Request ID attached as the request-id execution label")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the execution result verbatim instead of re-encoding it as JSON")
+	cmd.Flags().BoolVar(&resultOnly, "result-only", false, "Print only the result: no execution ID, progress, or state lines on stderr (stronger than --quiet)")
 	cmd.Flags().BoolVar(&async, "async", false, "Start workflow and return immediately without waiting")
 	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait for workflow completion")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 
 	return cmd
 }
+
+// executionRunner starts workflow executions and waits for them to finish.
+// *workflows.Client implements it.
+type executionRunner interface {
+	ExecuteWithLabels(ctx context.Context, workflowName string, args map[string]interface{}, labels map[string]string) (string, error)
+	WaitForCompletion(ctx context.Context, executionName string) (*workflows.ExecutionResult, error)
+}
+
+// runWriters are the destinations of wf run's stderr lines. progress gets
+// informational lines and is silenced by --quiet or --result-only; status
+// gets the execution ID, async hint and final state, and is silenced only
+// by --result-only. spinner, when set, is the terminal that shows a
+// per-poll spinner while waiting. record, when set, is called with the
+// execution name as soon as the execution starts.
+type runWriters struct {
+	progress io.Writer
+	status   io.Writer
+	spinner  io.Writer
+	// warn receives warnings, which --quiet and --result-only keep.
+	warn   io.Writer
+	record func(execName string) error
+}
+
+func newRunWriters(cmd *cobra.Command, resultOnly bool) runWriters {
+	if resultOnly {
+		return runWriters{progress: io.Discard, status: io.Discard, warn: progress.WarnWriter(cmd)}
+	}
+	return runWriters{progress: progress.Writer(cmd), status: progress.Stderr(cmd), spinner: progress.Terminal(cmd), warn: progress.WarnWriter(cmd)}
+}
+
+// runWorkflow executes workflowName and, unless async, waits for it to
+// finish and returns its result. An async run returns a nil result.
+func runWorkflow(ctx context.Context, runner executionRunner, workflowName string, data map[string]interface{}, labels map[string]string, async bool, w runWriters) (*workflows.ExecutionResult, error) {
+	fmt.Fprintf(w.progress, "Executing workflow: %s\n", workflowName)
+
+	execName, err := runner.ExecuteWithLabels(ctx, workflowName, data, labels)
+	if err != nil {
+		return nil, fmt.Errorf("executing workflow: %w", err)
+	}
+
+	execID := path.Base(execName)
+	fmt.Fprintf(w.status, "Execution: %s\n", execID)
+	if w.record != nil {
+		if err := w.record(execName); err != nil {
+			fmt.Fprintf(w.warn, "Warning: %v\n", err)
+		}
+	}
+	if len(labels) > 0 {
+		fmt.Fprintf(w.progress, "Labels: %s\n", formatLabels(labels))
+	}
+
+	if async {
+		fmt.Fprintf(w.status, "Workflow started. Check status with:\n")
+		fmt.Fprintf(w.status, "  gcphcp ops wf status %s %s\n", workflowName, execID)
+		return nil, nil
+	}
+
+	fmt.Fprintf(w.progress, "Waiting for completion... (Ctrl+C to detach)\n")
+
+	waitCtx := ctx
+	spinner := &pollSpinner{w: w.spinner}
+	if w.spinner != nil {
+		waitCtx = workflows.ContextWithPollObserver(ctx, spinner.update)
+	}
+	result, err := runner.WaitForCompletion(waitCtx, execName)
+	spinner.clear()
+	if err != nil {
+		if interrupt.Interrupted(ctx) {
+			// The signal handler already printed the status hint.
+			return nil, fmt.Errorf("waiting for workflow: %w", err)
+		}
+		var timeout *workflows.WaitTimeoutError
+		if errors.As(err, &timeout) {
+			return nil, fmt.Errorf("%w\n\nStill running; check: %s", timeout, interrupt.StatusCommand(execName))
+		}
+		return nil, fmt.Errorf("waiting for workflow: %w\n\nCheck status with: gcphcp ops wf status %s %s", err, workflowName, execID)
+	}
+
+	fmt.Fprintf(w.status, "State: %s  Duration: %s\n", result.State, result.Duration.Round(time.Millisecond))
+	return result, nil
+}
+
+// journalRecorder returns a record hook that adds started executions to the
+// local execution journal read by `wf recent`, kept beside the config file
+// resolved from configPath, or nil when there is no config directory.
+func journalRecorder(configPath, project, region string, data map[string]interface{}) func(string) error {
+	dir := config.ResolveDir(configPath)
+	if dir == "" {
+		return nil
+	}
+	argsHash := journal.HashArgs(data)
+	return func(execName string) error {
+		workflow, execID := workflows.ParseExecutionName(execName)
+		err := journal.Append(journal.DefaultPath(dir), journal.Entry{
+			Workflow:    workflow,
+			ExecutionID: execID,
+			Project:     project,
+			Region:      region,
+			StartTime:   time.Now(),
+			ArgsHash:    argsHash,
+		})
+		if err != nil {
+			return fmt.Errorf("recording execution: %w", err)
+		}
+		return nil
+	}
+}
+
+// printRunResult writes the result of a completed execution. With raw, the
+// result string is written as the workflow returned it, except that a
+// result that is a JSON string, such as a rendered report, is decoded so it
+// prints unquoted; otherwise the parsed result is printed in format. JSON
+// output also carries poll_count and poll_duration unless the workflow
+// result already uses those keys.
+func printRunResult(w io.Writer, result *workflows.ExecutionResult, format output.Format, raw bool) error {
+	if !raw {
+		data := result.Result
+		if format == output.FormatJSON && result.PollCount > 0 {
+			data = make(map[string]interface{}, len(result.Result)+2)
+			for k, v := range result.Result {
+				data[k] = v
+			}
+			addPollMetrics(data, result)
+		}
+		return output.PrintResult(w, format, data)
+	}
+
+	text := result.RawResult
+	if text == "" {
+		text, _ = result.Result["raw"].(string)
+	}
+	if strings.HasPrefix(text, `"`) {
+		var decoded string
+		if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+			text = decoded
+		}
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+// envRunData holds wf run's JSON arguments when neither --data nor
+// --data-file is given.
+const envRunData = "GCPHCP_WF_DATA"
+
+// loadRunData returns the workflow arguments from --data or --data-file
+// (where "-" reads stdin), or else from $GCPHCP_WF_DATA, which must be JSON.
+// inputFormat is "json", "yaml", or "auto", which tries JSON first and falls
+// back to YAML. The arguments must be an object; with no source set an
+// empty object is used.
+func loadRunData(data, dataFile, inputFormat string, stdin io.Reader) (map[string]interface{}, error) {
+	if data != "" && dataFile != "" {
+		return nil, fmt.Errorf("--data and --data-file are mutually exclusive")
+	}
+
+	source := "--data"
+	raw := []byte(data)
+	if data == "" && dataFile == "" {
+		if env := os.Getenv(envRunData); env != "" {
+			source = "$" + envRunData
+			raw = []byte(env)
+			inputFormat = "json"
+		}
+	}
+	if dataFile != "" {
+		source = "--data-file"
+		var err error
+		if dataFile == "-" {
+			raw, err = io.ReadAll(stdin)
+		} else {
+			raw, err = os.ReadFile(dataFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading --data-file: %w", err)
+		}
+	}
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	parsed, err := decodeRunData(raw, inputFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", source, err)
+	}
+	obj, ok := parsed.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid %s: workflow arguments must be an object, got %s", source, jsonKind(parsed))
+	}
+	return obj, nil
+}
+
+// decodeRunData decodes raw arguments according to inputFormat.
+func decodeRunData(raw []byte, inputFormat string) (interface{}, error) {
+	var parsed interface{}
+	switch strings.ToLower(inputFormat) {
+	case "json":
+		if err := json.Unmarshal(raw, &parsed); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %w", err)
+		}
+		return parsed, nil
+	case "yaml":
+		if err := yaml.Unmarshal(raw, &parsed); err != nil {
+			return nil, fmt.Errorf("parsing YAML: %w", err)
+		}
+		return normalizeYAML(parsed), nil
+	case "auto", "":
+		if err := json.Unmarshal(raw, &parsed); err == nil {
+			return parsed, nil
+		}
+		if err := yaml.Unmarshal(raw, &parsed); err != nil {
+			return nil, fmt.Errorf("not valid JSON or YAML: %w", err)
+		}
+		return normalizeYAML(parsed), nil
+	default:
+		return nil, fmt.Errorf("unknown --input-format %q (use json, yaml, or auto)", inputFormat)
+	}
+}
+
+// normalizeYAML converts map[interface{}]interface{} values produced by the
+// YAML decoder into map[string]interface{} so the result marshals to JSON.
+func normalizeYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprintf("%v", k)] = normalizeYAML(val)
+		}
+		return m
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = normalizeYAML(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = normalizeYAML(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// jsonKind names the JSON type of a decoded value for error messages.
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// requestIDLabel is the execution label key that carries --request-id.
+const requestIDLabel = "request-id"
+
+// labelKeyRe and labelValueRe follow the Cloud Workflows execution label
+// rules: lowercase letters, digits, underscores and dashes, up to 63
+// characters, with keys starting with a letter.
+var (
+	labelKeyRe   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValueRe = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+// parseExecutionLabels turns --label key=value pairs and --request-id into
+// execution labels, validating keys and values.
+func parseExecutionLabels(pairs []string, requestID string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --label %q: expected key=value", pair)
+		}
+		if !labelKeyRe.MatchString(key) {
+			return nil, fmt.Errorf("invalid --label key %q: must start with a lowercase letter and contain only lowercase letters, digits, '_' or '-' (max 63)", key)
+		}
+		if !labelValueRe.MatchString(value) {
+			return nil, fmt.Errorf("invalid --label value %q: must contain only lowercase letters, digits, '_' or '-' (max 63)", value)
+		}
+		labels[key] = value
+	}
+
+	if requestID != "" {
+		if !labelValueRe.MatchString(requestID) {
+			return nil, fmt.Errorf("invalid --request-id %q: must contain only lowercase letters, digits, '_' or '-' (max 63)", requestID)
+		}
+		if existing, ok := labels[requestIDLabel]; ok && existing != requestID {
+			return nil, fmt.Errorf("--request-id %q conflicts with --label %s=%s", requestID, requestIDLabel, existing)
+		}
+		labels[requestIDLabel] = requestID
+	}
+
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return labels, nil
+}
+
+// formatLabels renders labels as sorted key=value pairs.
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + labels[k]
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,426 @@
+package wf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+func TestLoadRunData_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.json")
+	if err := os.WriteFile(path, []byte(`{"resource_type": "pods", "namespace": "hypershift"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadRunData("", path, "auto", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["resource_type"] != "pods" || got["namespace"] != "hypershift" {
+		t.Errorf("unexpected args: %v", got)
+	}
+}
+
+func TestLoadRunData_Stdin(t *testing.T) {
+	got, err := loadRunData("", "-", "auto", strings.NewReader(`{"resource_type": "nodes"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["resource_type"] != "nodes" {
+		t.Errorf("unexpected args: %v", got)
+	}
+}
+
+func TestLoadRunData_MutuallyExclusive(t *testing.T) {
+	_, err := loadRunData(`{"a": 1}`, "args.json", "auto", strings.NewReader(""))
+	if err == nil {
+		t.Fatal("expected error when both --data and --data-file are set")
+	}
+	if !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadRunData_RequiresObject(t *testing.T) {
+	for _, input := range []string{`[1, 2]`, `"pods"`, `42`} {
+		_, err := loadRunData(input, "", "auto", strings.NewReader(""))
+		if err == nil {
+			t.Errorf("expected error for non-object input %s", input)
+			continue
+		}
+		if !strings.Contains(err.Error(), "must be an object") {
+			t.Errorf("unexpected error for %s: %v", input, err)
+		}
+	}
+}
+
+func TestLoadRunData_Empty(t *testing.T) {
+	got, err := loadRunData("", "", "auto", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("expected empty args, got %v", got)
+	}
+}
+
+func TestLoadRunData_Env(t *testing.T) {
+	t.Run("no flag is set", func(t *testing.T) {
+		t.Setenv(envRunData, `{"resource_type": "pods", "namespace": "hypershift"}`)
+		got, err := loadRunData("", "", "auto", strings.NewReader(""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		runner := &fakeExecutionRunner{result: &workflows.ExecutionResult{State: "SUCCEEDED"}}
+		w := runWriters{progress: &bytes.Buffer{}, status: &bytes.Buffer{}}
+		if _, err := runWorkflow(context.Background(), runner, "get", got, nil, false, w); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := map[string]interface{}{"resource_type": "pods", "namespace": "hypershift"}
+		if !reflect.DeepEqual(runner.args, want) {
+			t.Errorf("client got %v, want %v", runner.args, want)
+		}
+	})
+
+	t.Run("--data or --data-file is set", func(t *testing.T) {
+		t.Setenv(envRunData, `{"resource_type": "nodes"}`)
+		path := filepath.Join(t.TempDir(), "args.json")
+		if err := os.WriteFile(path, []byte(`{"resource_type": "secrets"}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, err := loadRunData(`{"resource_type": "pods"}`, "", "auto", nil); err != nil || got["resource_type"] != "pods" {
+			t.Errorf("--data: got %v, %v; want pods", got, err)
+		}
+		if got, err := loadRunData("", path, "auto", nil); err != nil || got["resource_type"] != "secrets" {
+			t.Errorf("--data-file: got %v, %v; want secrets", got, err)
+		}
+	})
+
+	t.Run("env is not a JSON object", func(t *testing.T) {
+		for _, value := range []string{`[1, 2]`, `resource_type: pods`} {
+			t.Setenv(envRunData, value)
+			_, err := loadRunData("", "", "auto", nil)
+			if err == nil || !strings.Contains(err.Error(), "$"+envRunData) {
+				t.Errorf("%s: expected an error naming %s, got %v", value, envRunData, err)
+			}
+		}
+	})
+}
+
+func TestLoadRunData_YAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "args.yaml")
+	content := `resource_type: pods
+namespace: hypershift
+analyze: true
+options:
+  tail_lines: 50
+  containers:
+    - etcd
+    - etcd-metrics
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"auto", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			got, err := loadRunData("", path, format, strings.NewReader(""))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			gotJSON, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("marshaling args: %v", err)
+			}
+			want := `{"analyze":true,"namespace":"hypershift","options":{"containers":["etcd","etcd-metrics"],"tail_lines":50},"resource_type":"pods"}`
+			if string(gotJSON) != want {
+				t.Errorf("got %s, want %s", gotJSON, want)
+			}
+		})
+	}
+}
+
+func TestLoadRunData_InputFormatJSONRejectsYAML(t *testing.T) {
+	_, err := loadRunData("resource_type: pods", "", "json", strings.NewReader(""))
+	if err == nil {
+		t.Fatal("expected error parsing YAML with --input-format json")
+	}
+}
+
+func TestLoadRunData_UnknownInputFormat(t *testing.T) {
+	_, err := loadRunData(`{"a": 1}`, "", "toml", strings.NewReader(""))
+	if err == nil || !strings.Contains(err.Error(), "unknown --input-format") {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+}
+
+func TestParseExecutionLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		pairs     []string
+		requestID string
+		want      map[string]string
+		wantErr   string
+	}{
+		{
+			name: "nothing is set",
+			want: nil,
+		},
+		{
+			name:      "labels and a request ID are set",
+			pairs:     []string{"team=sre", "ticket=inc-42"},
+			requestID: "3f2a1b9c",
+			want:      map[string]string{"team": "sre", "ticket": "inc-42", "request-id": "3f2a1b9c"},
+		},
+		{
+			name:  "label has an empty value",
+			pairs: []string{"dry_run="},
+			want:  map[string]string{"dry_run": ""},
+		},
+		{
+			name:    "label has no '='",
+			pairs:   []string{"team"},
+			wantErr: "expected key=value",
+		},
+		{
+			name:    "label key is uppercase",
+			pairs:   []string{"Team=sre"},
+			wantErr: "invalid --label key",
+		},
+		{
+			name:    "label value has invalid characters",
+			pairs:   []string{"team=SRE Team"},
+			wantErr: "invalid --label value",
+		},
+		{
+			name:      "request ID is invalid",
+			requestID: "INC/1234",
+			wantErr:   "invalid --request-id",
+		},
+		{
+			name:      "--label request-id conflicts with --request-id",
+			pairs:     []string{"request-id=a"},
+			requestID: "b",
+			wantErr:   "conflicts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExecutionLabels(tt.pairs, tt.requestID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	got := formatLabels(map[string]string{"team": "sre", "request-id": "abc"})
+	if got != "request-id=abc,team=sre" {
+		t.Errorf("formatLabels() = %q", got)
+	}
+}
+
+func TestPrintRunResult(t *testing.T) {
+	report := "NAME     STATUS\netcd-0   Running"
+	nonJSON := &workflows.ExecutionResult{
+		State:     "SUCCEEDED",
+		Result:    map[string]interface{}{"raw": report},
+		RawResult: report,
+	}
+
+	tests := []struct {
+		name   string
+		result *workflows.ExecutionResult
+		raw    bool
+		want   string
+	}{
+		{
+			name:   "--raw is set and the result is not JSON",
+			result: nonJSON,
+			raw:    true,
+			want:   report + "\n",
+		},
+		{
+			name:   "--raw is set and the result is JSON",
+			result: &workflows.ExecutionResult{Result: map[string]interface{}{"b": 1.0, "a": 2.0}, RawResult: `{"b":1,"a":2}`},
+			raw:    true,
+			want:   `{"b":1,"a":2}` + "\n",
+		},
+		{
+			name:   "--raw is set and the result is a JSON string",
+			result: &workflows.ExecutionResult{Result: map[string]interface{}{"raw": `"NAME\tSTATUS\netcd-0\t\"Running\""`}, RawResult: `"NAME\tSTATUS\netcd-0\t\"Running\""`},
+			raw:    true,
+			want:   "NAME\tSTATUS\netcd-0\t\"Running\"\n",
+		},
+		{
+			name:   "only the parsed raw field is available",
+			result: &workflows.ExecutionResult{Result: map[string]interface{}{"raw": "plain text\n"}},
+			raw:    true,
+			want:   "plain text\n",
+		},
+		{
+			name:   "--raw is not set",
+			result: nonJSON,
+			want:   "{\n  \"raw\": \"NAME     STATUS\\netcd-0   Running\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printRunResult(&buf, tt.result, output.FormatText, tt.raw); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintRunResult_PollMetrics(t *testing.T) {
+	result := &workflows.ExecutionResult{
+		State:        "SUCCEEDED",
+		Result:       map[string]interface{}{"pods": 3.0},
+		PollCount:    4,
+		PollDuration: 1500 * time.Millisecond,
+	}
+
+	var buf bytes.Buffer
+	if err := printRunResult(&buf, result, output.FormatJSON, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if got["pods"] != 3.0 || got["poll_count"] != 4.0 || got["poll_duration"] != "1.5s" {
+		t.Errorf("got %v, want pods, poll_count 4 and poll_duration 1.5s", got)
+	}
+	if _, ok := result.Result["poll_count"]; ok {
+		t.Error("printRunResult should not modify the workflow result")
+	}
+}
+
+type fakeExecutionRunner struct {
+	result *workflows.ExecutionResult
+	args   map[string]interface{}
+}
+
+func (f *fakeExecutionRunner) ExecuteWithLabels(_ context.Context, workflowName string, args map[string]interface{}, _ map[string]string) (string, error) {
+	f.args = args
+	return "projects/p/locations/r/workflows/" + workflowName + "/executions/abc-123", nil
+}
+
+func (f *fakeExecutionRunner) WaitForCompletion(context.Context, string) (*workflows.ExecutionResult, error) {
+	return f.result, nil
+}
+
+func TestRunWorkflow_Writers(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		resultOnly bool
+		wantStderr []string
+	}{
+		{
+			name:       "no flag is set",
+			wantStderr: []string{"Executing workflow: get", "Execution: abc-123", "State: SUCCEEDED"},
+		},
+		{
+			name:       "--quiet is set",
+			args:       []string{"--quiet"},
+			wantStderr: []string{"Execution: abc-123", "State: SUCCEEDED"},
+		},
+		{
+			name:       "--result-only is set",
+			resultOnly: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "run"}
+			cmd.Flags().BoolP("quiet", "q", false, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			var stderr, stdout bytes.Buffer
+			cmd.SetErr(&stderr)
+
+			runner := &fakeExecutionRunner{result: &workflows.ExecutionResult{
+				State:  "SUCCEEDED",
+				Result: map[string]interface{}{"count": float64(2)},
+			}}
+			result, err := runWorkflow(context.Background(), runner, "get", nil, nil, false, newRunWriters(cmd, tt.resultOnly))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := printRunResult(&stdout, result, output.FormatJSON, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil || got["count"] != float64(2) {
+				t.Errorf("stdout = %q, want the result JSON", stdout.String())
+			}
+			if len(tt.wantStderr) == 0 && stderr.Len() != 0 {
+				t.Errorf("expected empty stderr, got %q", stderr.String())
+			}
+			for _, want := range tt.wantStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("stderr %q missing %q", stderr.String(), want)
+				}
+			}
+			if len(tt.args) > 0 && strings.Contains(stderr.String(), "Executing workflow") {
+				t.Errorf("expected --quiet to drop progress lines, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestPollSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	s := &pollSpinner{w: &buf}
+
+	s.clear()
+	if buf.Len() != 0 {
+		t.Fatalf("expected clear before any update to write nothing, got %q", buf.String())
+	}
+
+	s.update("ACTIVE", 1200*time.Millisecond)
+	s.update("ACTIVE", 2600*time.Millisecond)
+	if want := "\r\033[K⠋ ACTIVE  1s\r\033[K⠙ ACTIVE  3s"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	s.clear()
+	if buf.String() != "\r\033[K" {
+		t.Errorf("expected clear to erase the line, got %q", buf.String())
+	}
+}
@@ -0,0 +1,33 @@
+package wf
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// spinnerFrames are drawn in turn, one per poll.
+var spinnerFrames = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
+
+// pollSpinner redraws a single "⠙ ACTIVE  12s" line on a terminal each time
+// WaitForCompletion polls the execution (see workflows.ContextWithPollObserver).
+type pollSpinner struct {
+	w     io.Writer
+	frame int
+	drawn bool
+}
+
+// update redraws the line for the latest poll.
+func (s *pollSpinner) update(state string, elapsed time.Duration) {
+	fmt.Fprintf(s.w, "\r\033[K%c %s  %s", spinnerFrames[s.frame%len(spinnerFrames)], state, elapsed.Round(time.Second))
+	s.frame++
+	s.drawn = true
+}
+
+// clear erases the line so the lines printed after the wait start clean.
+func (s *pollSpinner) clear() {
+	if s.drawn {
+		fmt.Fprint(s.w, "\r\033[K")
+		s.drawn = false
+	}
+}
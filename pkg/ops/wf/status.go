@@ -3,49 +3,91 @@ package wf
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/progress"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 func newStatusCmd() *cobra.Command {
 	var (
-		wait    bool
-		timeout time.Duration
+		wait          bool
+		watch         bool
+		watchInterval time.Duration
+		timeout       time.Duration
 	)
 
 	cmd := &cobra.Command{
-		Use:   "status <workflow> <execution-id>",
+		Use:   "status <workflow> <execution-id> | @N",
 		Short: "Check the status of a workflow execution",
 		Long: `Check the status of a workflow execution by its ID.
 
 Use this to check on workflows started with --async, or after detaching
 from a running workflow with Ctrl+C.
 
-Use --wait to block until the execution completes.
+Use --wait to block until the execution completes, or --watch to redraw
+the status, including pending callbacks, every --watch-interval until it
+does. --watch runs until the execution finishes or Ctrl+C unless --timeout
+is set explicitly.
+
+The execution ID may be abbreviated to any unique prefix of a recent execution.
+An execution started from this machine can also be named @N, its position in
+"wf recent"; its project and region are used unless set explicitly.
 
 Examples:
   # Check status of an execution
   gcphcp ops wf status get abc123-def456
 
+  # Use a unique prefix of the execution ID
+  gcphcp ops wf status get abc123
+
+  # Check the most recent execution started with "wf run"
+  gcphcp ops wf status @1
+
   # Wait for an execution to complete
   gcphcp ops wf status get abc123-def456 --wait
 
+  # Follow an execution live until it finishes
+  gcphcp ops wf status get abc123-def456 --watch
+
   # JSON output
   gcphcp ops wf status describe abc123-def456 -o json`,
 
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeWorkflowNames,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			workflowName := args[0]
-			execID := args[1]
-
 			project, _ := cmd.Flags().GetString("project")
 			region, _ := cmd.Flags().GetString("region")
 			outputFormat, _ := cmd.Flags().GetString("output")
+			outputFile, _ := cmd.Flags().GetString("output-file")
+
+			var workflowName, execID string
+			if len(args) == 2 {
+				workflowName, execID = args[0], args[1]
+			} else {
+				configPath, _ := cmd.Flags().GetString("config")
+				entries, err := listJournal(configPath)
+				if err != nil {
+					return err
+				}
+				entry, err := journalRef(entries, args[0])
+				if err != nil {
+					return err
+				}
+				workflowName, execID = entry.Workflow, entry.ExecutionID
+				if !cmd.Flags().Changed("project") && entry.Project != "" {
+					project = entry.Project
+				}
+				if !cmd.Flags().Changed("region") && entry.Region != "" {
+					region = entry.Region
+				}
+			}
 
 			if project == "" {
 				return fmt.Errorf("--project is required (or set GCPHCP_PROJECT)")
@@ -53,51 +95,163 @@ Examples:
 			if region == "" {
 				return fmt.Errorf("--region is required (or set GCPHCP_REGION)")
 			}
+			if wait && watch {
+				return fmt.Errorf("--wait and --watch are mutually exclusive")
+			}
+			if watchInterval <= 0 {
+				return fmt.Errorf("--watch-interval must be positive")
+			}
 
-			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
-				project, region, workflowName, execID)
-
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			ctx, cancel := interrupt.WithTimeout(cmd.Context(), statusTimeout(cmd, timeout, watch), progress.Stderr(cmd))
 			defer cancel()
 
-			client, err := workflows.NewClient(ctx, project, region)
+			projectNumber, _ := cmd.Flags().GetString("project-number")
+			client, err := workflows.NewClient(ctx, project, region, workflows.WithProjectNumber(projectNumber))
 			if err != nil {
 				return fmt.Errorf("creating client: %w", err)
 			}
 			defer client.Close()
 
+			execID, err = client.ResolveExecution(ctx, workflowName, execID)
+			if err != nil {
+				return fmt.Errorf("resolving execution: %w", err)
+			}
+
+			execName := fmt.Sprintf("projects/%s/locations/%s/workflows/%s/executions/%s",
+				project, region, workflowName, execID)
+
+			out, err := output.ResolveOutputWriter(outputFile)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
 			if wait {
-				fmt.Fprintf(os.Stderr, "Waiting for execution %s to complete...\n", execID)
+				progress.Printf(cmd, "Waiting for execution %s to complete...\n", execID)
 				result, err := client.WaitForCompletion(ctx, execName)
 				if err != nil {
 					return fmt.Errorf("waiting for execution: %w", err)
 				}
-				return printStatus(result, workflowName, execID, outputFormat)
+				return printStatus(out, result, workflowName, execID, outputFormat)
 			}
 
-			result, err := client.GetExecution(ctx, execName)
-			if err != nil {
-				return fmt.Errorf("getting execution status: %w", err)
+			if watch {
+				format := output.ParseFormat(outputFormat)
+				f, isFile := cmd.OutOrStdout().(*os.File)
+				redraw := outputFile == "" && isFile && progress.IsTerminal(f) && format == output.FormatText
+				_, err := watchStatus(ctx, client, execName, watchInterval, format, redraw, out,
+					func(w io.Writer, result *workflows.ExecutionResult) error {
+						return printStatus(w, result, workflowName, execID, outputFormat)
+					})
+				return err
 			}
 
-			if result.State == "ACTIVE" {
-				callbacks, cbErr := client.ListCallbacks(ctx, result.Name)
-				if cbErr == nil {
-					result.Callbacks = callbacks
-				}
+			result, err := fetchStatus(ctx, client, execName)
+			if err != nil {
+				return err
 			}
 
-			return printStatus(result, workflowName, execID, outputFormat)
+			return printStatus(out, result, workflowName, execID, outputFormat)
 		},
 	}
 
 	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the execution to complete")
-	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Redraw the status every --watch-interval until the execution completes")
+	cmd.Flags().DurationVar(&watchInterval, "watch-interval", 2*time.Second, "Time between refreshes with --watch")
+	cmd.Flags().String("project-number", "", "Numeric project number for callback URLs (skips the project ID lookup)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Maximum time to wait (with --watch: no limit unless set)")
+	cmd.Flags().StringP("output-file", "O", "", "Write command output to this file instead of stdout")
 
 	return cmd
 }
 
-func printStatus(result *workflows.ExecutionResult, workflowName, execID, outputFormat string) error {
+// statusGetter reads an execution and its pending callbacks.
+// *workflows.Client implements it.
+type statusGetter interface {
+	GetExecution(ctx context.Context, executionName string) (*workflows.ExecutionResult, error)
+	ListCallbacks(ctx context.Context, executionName string) ([]workflows.CallbackInfo, error)
+}
+
+// fetchStatus reads an execution, with its callbacks while it is ACTIVE.
+// A callback listing error is ignored.
+func fetchStatus(ctx context.Context, client statusGetter, execName string) (*workflows.ExecutionResult, error) {
+	result, err := client.GetExecution(ctx, execName)
+	if err != nil {
+		return nil, fmt.Errorf("getting execution status: %w", err)
+	}
+
+	if result.State == "ACTIVE" {
+		callbacks, cbErr := client.ListCallbacks(ctx, result.Name)
+		if cbErr == nil {
+			result.Callbacks = callbacks
+		}
+	}
+	return result, nil
+}
+
+// addPollMetrics adds the number of status polls and the time spent waiting
+// to a JSON object, when result was obtained by waiting. Keys already in
+// data are kept.
+func addPollMetrics(data map[string]interface{}, result *workflows.ExecutionResult) {
+	if result.PollCount == 0 {
+		return
+	}
+	if _, ok := data["poll_count"]; !ok {
+		data["poll_count"] = result.PollCount
+	}
+	if _, ok := data["poll_duration"]; !ok {
+		data["poll_duration"] = result.PollDuration.Round(time.Millisecond).String()
+	}
+}
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// statusTimeout returns the deadline for a status command: --timeout, except
+// that --watch follows the execution with no deadline unless --timeout was
+// set explicitly.
+func statusTimeout(cmd *cobra.Command, timeout time.Duration, watch bool) time.Duration {
+	if watch && !cmd.Flags().Changed("timeout") {
+		return 0
+	}
+	return timeout
+}
+
+// watchStatus renders the execution every interval until it leaves the
+// ACTIVE and QUEUED states, and returns the final result. With redraw, each
+// refresh replaces the previous one on the terminal; otherwise text
+// refreshes are appended under a timestamped separator.
+func watchStatus(ctx context.Context, client statusGetter, execName string, interval time.Duration, format output.Format, redraw bool, w io.Writer, render func(io.Writer, *workflows.ExecutionResult) error) (*workflows.ExecutionResult, error) {
+	for polls := 0; ; polls++ {
+		if polls > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("watching execution: %w", ctx.Err())
+			case <-time.After(interval):
+			}
+		}
+
+		result, err := fetchStatus(ctx, client, execName)
+		if err != nil {
+			return nil, err
+		}
+
+		if redraw {
+			fmt.Fprint(w, clearScreen)
+		} else if polls > 0 && format == output.FormatText {
+			fmt.Fprintf(w, "\n--- %s ---\n", time.Now().Format(time.TimeOnly))
+		}
+		if err := render(w, result); err != nil {
+			return nil, err
+		}
+
+		if result.State != "ACTIVE" && result.State != "QUEUED" {
+			return result, nil
+		}
+	}
+}
+
+func printStatus(w io.Writer, result *workflows.ExecutionResult, workflowName, execID, outputFormat string) error {
 	format := output.ParseFormat(outputFormat)
 
 	if format == output.FormatJSON {
@@ -112,7 +266,8 @@ func printStatus(result *workflows.ExecutionResult, workflowName, execID, output
 		if len(result.Callbacks) > 0 {
 			data["callbacks"] = result.Callbacks
 		}
-		return output.PrintJSON(os.Stdout, data)
+		addPollMetrics(data, result)
+		return output.PrintJSON(w, data)
 	}
 
 	stateDisplay := result.State
@@ -120,36 +275,36 @@ func printStatus(result *workflows.ExecutionResult, workflowName, execID, output
 		stateDisplay = "ACTIVE (waiting on callback)"
 	}
 
-	fmt.Fprintf(os.Stdout, "Workflow:   %s\n", workflowName)
-	fmt.Fprintf(os.Stdout, "State:      %s\n", stateDisplay)
-	fmt.Fprintf(os.Stdout, "Started:    %s (%s ago)\n",
+	fmt.Fprintf(w, "Workflow:   %s\n", workflowName)
+	fmt.Fprintf(w, "State:      %s\n", stateDisplay)
+	fmt.Fprintf(w, "Started:    %s (%s ago)\n",
 		result.StartTime.Format("2006-01-02 15:04:05 UTC"),
 		output.Age(result.StartTime.Format(time.RFC3339)))
 
 	if !result.EndTime.IsZero() {
-		fmt.Fprintf(os.Stdout, "Ended:      %s\n", result.EndTime.Format("2006-01-02 15:04:05 UTC"))
-		fmt.Fprintf(os.Stdout, "Duration:   %s\n", result.Duration.Round(time.Millisecond))
+		fmt.Fprintf(w, "Ended:      %s\n", result.EndTime.Format("2006-01-02 15:04:05 UTC"))
+		fmt.Fprintf(w, "Duration:   %s\n", result.Duration.Round(time.Millisecond))
 	}
 
 	if result.Error != "" {
-		fmt.Fprintf(os.Stdout, "Error:      %s\n", result.Error)
+		fmt.Fprintf(w, "Error:      %s\n", result.Error)
 	}
 
 	if result.Result != nil && result.State == "SUCCEEDED" {
-		fmt.Fprintf(os.Stdout, "Args:       %s\n", buildArgsSummary(result.Result))
+		fmt.Fprintf(w, "Args:       %s\n", buildArgsSummary(result.Result))
 	}
 
 	if len(result.Callbacks) > 0 {
-		fmt.Fprintf(os.Stdout, "\nCallbacks:\n")
+		fmt.Fprintf(w, "\nCallbacks:\n")
 		for _, cb := range result.Callbacks {
-			fmt.Fprintf(os.Stdout, "  %s %s\n", cb.Method, cb.URL)
+			fmt.Fprintf(w, "  %s %s\n", cb.Method, cb.URL)
 		}
-		fmt.Fprintf(os.Stdout, "\nResume with:\n")
-		fmt.Fprintf(os.Stdout, "  gcphcp ops wf resume %s %s --data '{\"approved\": true}'\n", workflowName, execID)
+		fmt.Fprintf(w, "\nResume with:\n")
+		fmt.Fprintf(w, "  gcphcp ops wf resume %s %s --data '{\"approved\": true}'\n", workflowName, execID)
 	}
 
 	if result.State == "SUCCEEDED" || result.State == "FAILED" {
-		fmt.Fprintf(os.Stdout, "\nUse -o json for full result.\n")
+		fmt.Fprintf(w, "\nUse -o json for full result.\n")
 	}
 
 	return nil
@@ -0,0 +1,148 @@
+package wf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// fakeStatusGetter returns the states in turn, one per GetExecution call.
+type fakeStatusGetter struct {
+	states        []string
+	gets          int
+	callbackCalls int
+}
+
+func (f *fakeStatusGetter) GetExecution(_ context.Context, name string) (*workflows.ExecutionResult, error) {
+	if f.gets >= len(f.states) {
+		return nil, errors.New("polled after a terminal state")
+	}
+	state := f.states[f.gets]
+	f.gets++
+	return &workflows.ExecutionResult{Name: name, State: state}, nil
+}
+
+func (f *fakeStatusGetter) ListCallbacks(context.Context, string) ([]workflows.CallbackInfo, error) {
+	f.callbackCalls++
+	return []workflows.CallbackInfo{{Method: "POST", URL: fmt.Sprintf("https://callback/%d", f.callbackCalls)}}, nil
+}
+
+func TestStatusTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want time.Duration
+	}{
+		{name: "default", want: 5 * time.Minute},
+		{name: "explicit", args: []string{"--timeout", "1m"}, want: time.Minute},
+		{name: "watch", args: []string{"--watch"}, want: 0},
+		{name: "watch explicit", args: []string{"--watch", "--timeout", "1h"}, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newStatusCmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			watch, _ := cmd.Flags().GetBool("watch")
+			if got := statusTimeout(cmd, timeout, watch); got != tt.want {
+				t.Errorf("statusTimeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	t.Run("execution succeeds", func(t *testing.T) {
+		getter := &fakeStatusGetter{states: []string{"ACTIVE", "ACTIVE", "SUCCEEDED"}}
+		var buf bytes.Buffer
+		var rendered []string
+		render := func(w io.Writer, r *workflows.ExecutionResult) error {
+			rendered = append(rendered, fmt.Sprintf("%s/%d", r.State, len(r.Callbacks)))
+			fmt.Fprintln(w, r.State)
+			return nil
+		}
+
+		final, err := watchStatus(context.Background(), getter, "executions/abc", time.Millisecond, output.FormatText, false, &buf, render)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if final.State != "SUCCEEDED" || getter.gets != 3 {
+			t.Errorf("final %s after %d polls, want SUCCEEDED after 3", final.State, getter.gets)
+		}
+		if want := "ACTIVE/1 ACTIVE/1 SUCCEEDED/0"; strings.Join(rendered, " ") != want {
+			t.Errorf("rendered %v, want %s", rendered, want)
+		}
+		if getter.callbackCalls != 2 {
+			t.Errorf("callbacks listed %d times, want 2 (ACTIVE only)", getter.callbackCalls)
+		}
+		if n := strings.Count(buf.String(), "\n--- "); n != 2 {
+			t.Errorf("expected 2 refresh separators, got %d:\n%s", n, buf.String())
+		}
+	})
+
+	t.Run("redrawing", func(t *testing.T) {
+		getter := &fakeStatusGetter{states: []string{"QUEUED", "FAILED"}}
+		var buf bytes.Buffer
+		render := func(w io.Writer, r *workflows.ExecutionResult) error { return nil }
+
+		if _, err := watchStatus(context.Background(), getter, "executions/abc", time.Millisecond, output.FormatText, true, &buf, render); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != clearScreen+clearScreen {
+			t.Errorf("output = %q, want two screen clears", buf.String())
+		}
+	})
+
+	t.Run("context ends", func(t *testing.T) {
+		getter := &fakeStatusGetter{states: []string{"ACTIVE", "ACTIVE"}}
+		ctx, cancel := context.WithCancel(context.Background())
+		render := func(io.Writer, *workflows.ExecutionResult) error {
+			cancel()
+			return nil
+		}
+
+		_, err := watchStatus(ctx, getter, "executions/abc", time.Hour, output.FormatText, false, io.Discard, render)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestPrintStatus_PollMetrics(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := &workflows.ExecutionResult{
+		State:        "SUCCEEDED",
+		StartTime:    start,
+		EndTime:      start.Add(time.Minute),
+		PollCount:    2,
+		PollDuration: 2 * time.Second,
+	}
+
+	var buf bytes.Buffer
+	if err := printStatus(&buf, result, "get", "abc", "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"poll_count": 2`) || !strings.Contains(buf.String(), `"poll_duration": "2s"`) {
+		t.Errorf("status JSON missing poll metrics:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	result.PollCount = 0
+	if err := printStatus(&buf, result, "get", "abc", "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "poll_count") {
+		t.Errorf("status JSON should omit poll metrics without polling:\n%s", buf.String())
+	}
+}
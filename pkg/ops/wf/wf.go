@@ -1,5 +1,5 @@
 // Package wf implements the "ops wf" command subtree for direct
-// Cloud Workflow management (run, list, status, resume).
+// Cloud Workflow management (run, list, status, logs, recent, resume, cancel).
 package wf
 
 import (
@@ -14,14 +14,18 @@ func NewWfCmd() *cobra.Command {
 		Long: `Direct Cloud Workflow management commands.
 
 Use these for running arbitrary workflows, checking execution status,
-listing workflows and execution history, and resuming paused workflows.`,
+listing workflows and execution history, reading execution logs, and resuming
+or cancelling executions.`,
 	}
 
 	cmd.AddCommand(newRunCmd())
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newResumeCmd())
+	cmd.AddCommand(newCancelCmd())
 	cmd.AddCommand(newAuditCmd())
+	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newRecentCmd())
 
 	return cmd
 }
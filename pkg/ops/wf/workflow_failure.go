@@ -0,0 +1,23 @@
+package wf
+
+import (
+	"io"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+// WorkflowFailure reports a FAILED execution. The raw error context is parsed
+// into its failing step and message; in JSON mode a structured
+// {"error": {...}} object is also written to w so scripts can consume it.
+// The returned *workflows.ExecutionFailedError makes the command exit
+// non-zero in every mode.
+func WorkflowFailure(w io.Writer, format output.Format, raw string) error {
+	failure := workflows.NewExecutionFailedError(raw)
+	if format == output.FormatJSON {
+		if err := output.PrintJSON(w, map[string]interface{}{"error": failure}); err != nil {
+			return err
+		}
+	}
+	return failure
+}
@@ -0,0 +1,55 @@
+package wf
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/output"
+)
+
+const deniedErrorContext = `RuntimeError: {"code":403,"message":"Resource type secrets is blocked for security."}
+in step "check_resource_type_deny_list", routine "main", line: 48`
+
+func TestWorkflowFailure_Text(t *testing.T) {
+	var buf bytes.Buffer
+	err := WorkflowFailure(&buf, output.FormatText, deniedErrorContext)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	want := `workflow failed in step "check_resource_type_deny_list": Resource type secrets is blocked for security.`
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing on stdout in text mode, got %q", buf.String())
+	}
+}
+
+func TestWorkflowFailure_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WorkflowFailure(&buf, output.FormatJSON, deniedErrorContext); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var parsed struct {
+		Error struct {
+			Step    string `json:"step"`
+			Message string `json:"message"`
+			Raw     string `json:"raw"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if parsed.Error.Step != "check_resource_type_deny_list" {
+		t.Errorf("step = %q", parsed.Error.Step)
+	}
+	if !strings.Contains(parsed.Error.Message, "blocked for security") {
+		t.Errorf("message = %q", parsed.Error.Message)
+	}
+	if parsed.Error.Raw != deniedErrorContext {
+		t.Errorf("raw = %q", parsed.Error.Raw)
+	}
+}
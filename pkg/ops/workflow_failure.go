@@ -0,0 +1,39 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+	"github.com/ckandag/gcp-hcp-cli/pkg/ops/interrupt"
+)
+
+// workflowRunError wraps an error from running a workflow. When the client
+// stopped waiting at the --timeout deadline, the message says the execution
+// is still running and how to check on it; the entry points exit with
+// ExitWaitTimeout for such errors. When the workflow is not deployed, the
+// message names it and where it was looked up.
+func workflowRunError(err error) error {
+	var timeout *workflows.WaitTimeoutError
+	if errors.As(err, &timeout) {
+		return fmt.Errorf("%w\n\nStill running; check: %s", timeout, interrupt.StatusCommand(timeout.ExecutionName))
+	}
+	var notFound *workflows.WorkflowNotFoundError
+	if errors.As(err, &notFound) {
+		return missingWorkflowError{notFound}
+	}
+	return fmt.Errorf("executing workflow: %w", err)
+}
+
+// missingWorkflowError is the message convenience commands give for a
+// workflow that is not deployed. It unwraps to the client error, so
+// errors.Is(err, workflows.ErrWorkflowNotFound) still matches.
+type missingWorkflowError struct {
+	err *workflows.WorkflowNotFoundError
+}
+
+func (e missingWorkflowError) Error() string {
+	return fmt.Sprintf("workflow %q not found in %s/%s; deploy it or use --workflow-prefix", e.err.Workflow, e.err.Project, e.err.Region)
+}
+
+func (e missingWorkflowError) Unwrap() error { return e.err }
@@ -0,0 +1,45 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ckandag/gcp-hcp-cli/pkg/gcp/workflows"
+)
+
+func TestWorkflowRunError_WaitTimeout(t *testing.T) {
+	timeout := &workflows.WaitTimeoutError{
+		ExecutionName: "projects/p/locations/r/workflows/get/executions/abc-123",
+		State:         "ACTIVE",
+	}
+
+	err := workflowRunError(timeout)
+
+	var got *workflows.WaitTimeoutError
+	if !errors.As(err, &got) {
+		t.Fatalf("expected the wait timeout to stay detectable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Still running; check: gcphcp ops wf status get abc-123") {
+		t.Errorf("expected wf status hint, got %q", err)
+	}
+}
+
+func TestWorkflowRunError_Other(t *testing.T) {
+	err := workflowRunError(errors.New("boom"))
+	if err.Error() != "executing workflow: boom" {
+		t.Errorf("got %q", err)
+	}
+}
+
+func TestWorkflowRunError_WorkflowNotFound(t *testing.T) {
+	err := workflowRunError(fmt.Errorf("running: %w", &workflows.WorkflowNotFoundError{Workflow: "get", Project: "p", Region: "r"}))
+
+	if !errors.Is(err, workflows.ErrWorkflowNotFound) {
+		t.Errorf("expected ErrWorkflowNotFound to stay detectable, got %v", err)
+	}
+	if err.Error() != `workflow "get" not found in p/r; deploy it or use --workflow-prefix` {
+		t.Errorf("got %q", err)
+	}
+}
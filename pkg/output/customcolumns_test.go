@@ -0,0 +1,83 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintCustomColumns(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "etcd-0"},
+				"status":   map[string]interface{}{"phase": "Running"},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "kube-apiserver-7d9f"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintCustomColumns(&buf, "NAME:.metadata.name,STATUS:{.status.phase}", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{
+		{"NAME", "STATUS"},
+		{"etcd-0", "Running"},
+		{"kube-apiserver-7d9f", "<none>"},
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("line %d = %q, want %v", i, line, want[i])
+		}
+	}
+}
+
+func TestPrintCustomColumns_MultipleValues(t *testing.T) {
+	data := map[string]interface{}{
+		"resource": map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "etcd-0"},
+			"spec": map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "etcd"},
+				map[string]interface{}{"name": "etcd-metrics"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintCustomColumns(&buf, "POD:.metadata.name,CONTAINERS:.spec.containers[*].name", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "etcd,etcd-metrics") {
+		t.Errorf("expected comma-joined container names, got:\n%s", buf.String())
+	}
+}
+
+func TestValidateCustomColumns(t *testing.T) {
+	for _, spec := range []string{"", "NAME", "NAME:", ":.metadata.name", "NAME:metadata.name", "NAME:.items[x]"} {
+		if err := ValidateCustomColumns(spec); err == nil {
+			t.Errorf("ValidateCustomColumns(%q): expected error", spec)
+		}
+	}
+	if err := ValidateCustomColumns("NAME:.metadata.name, PHASE:.status.phase"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseFormat_CustomColumns(t *testing.T) {
+	for _, s := range []string{"custom-columns=NAME:.metadata.name", "columns=NAME:.metadata.name"} {
+		if got := ParseFormat(s); got != FormatCustomColumns {
+			t.Errorf("ParseFormat(%q) = %q, want %q", s, got, FormatCustomColumns)
+		}
+		if spec, ok := CustomColumnsFromFormat(s); !ok || spec != "NAME:.metadata.name" {
+			t.Errorf("CustomColumnsFromFormat(%q) = %q, %v", s, spec, ok)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// fieldSegments parses a --field path. The leading dot is optional, so
+// status.podIP and .status.podIP are the same; wildcards are rejected since a
+// field names a single value.
+func fieldSegments(path string) ([]jpSegment, error) {
+	if path != "" && path[0] != '.' && path[0] != '[' {
+		path = "." + path
+	}
+	segs, err := parseJSONPathSegments(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(segs) == 0 {
+		return nil, fmt.Errorf("field path %q is empty", path)
+	}
+	for _, s := range segs {
+		if s.wildcard {
+			return nil, fmt.Errorf("field path %q must name a single value; use -o jsonpath for [*]", path)
+		}
+	}
+	return segs, nil
+}
+
+// ValidateFieldPath reports whether path is usable with LookupPath.
+func ValidateFieldPath(path string) error {
+	_, err := fieldSegments(path)
+	return err
+}
+
+// LookupPath resolves a dotted path such as .status.podIP or
+// spec.containers[0].image against data. It reports false if the path is
+// invalid or any part of it is missing.
+func LookupPath(data map[string]interface{}, path string) (interface{}, bool) {
+	segs, err := fieldSegments(path)
+	if err != nil {
+		return nil, false
+	}
+	values := evalJSONPath(segs, data)
+	if len(values) != 1 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+// PrintField writes the value at path in the single resource of a get result,
+// undecorated and newline-terminated, for capture with $(...). Strings are
+// written as-is and objects or arrays as compact JSON. It fails if the result
+// holds more or fewer than one resource, or the field is missing.
+func PrintField(w io.Writer, path string, data map[string]interface{}) error {
+	items, ok := ResourceItems(data)
+	if !ok || len(items) != 1 {
+		return fmt.Errorf("--field needs exactly one resource, got %d; name the resource or narrow the selector", len(items))
+	}
+	v, ok := LookupPath(AsMap(items[0]), path)
+	if !ok {
+		return fmt.Errorf("field %s not found in %s", path, resourceLabel(items[0]))
+	}
+	_, err := io.WriteString(w, jsonPathString(v)+"\n")
+	return err
+}
+
+// resourceLabel names an item in error messages, e.g. "pod etcd-0".
+func resourceLabel(item interface{}) string {
+	obj := AsMap(item)
+	name := GetString(AsMap(obj["metadata"]), "name")
+	if kind := GetString(obj, "kind"); kind != "" {
+		return strings.ToLower(kind) + " " + name
+	}
+	if name == "" {
+		return "the resource"
+	}
+	return name
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func fieldPod(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": name, "labels": map[string]interface{}{"app": "etcd"}},
+		"spec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "etcd", "image": "quay.io/etcd:v3.5"},
+		}},
+		"status": map[string]interface{}{"podIP": "10.0.0.7", "restartCount": float64(3), "ready": true},
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	pod := fieldPod("etcd-0")
+
+	tests := []struct {
+		name   string
+		path   string
+		want   interface{}
+		wantOK bool
+	}{
+		{name: "path is nested", path: ".status.podIP", want: "10.0.0.7", wantOK: true},
+		{name: "leading dot is omitted", path: "status.podIP", want: "10.0.0.7", wantOK: true},
+		{name: "path indexes a list", path: ".spec.containers[0].image", want: "quay.io/etcd:v3.5", wantOK: true},
+		{name: "key is quoted", path: ".metadata.labels['app']", want: "etcd", wantOK: true},
+		{name: "field is missing", path: ".status.hostIP"},
+		{name: "index is out of range", path: ".spec.containers[3].image"},
+		{name: "path has a wildcard", path: ".spec.containers[*].image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LookupPath(pod, tt.path)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("LookupPath(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPrintField(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "single resource is returned",
+			data: map[string]interface{}{"resource": fieldPod("etcd-0")},
+			path: ".status.podIP",
+			want: "10.0.0.7\n",
+		},
+		{
+			name: "value is a number",
+			data: map[string]interface{}{"items": []interface{}{fieldPod("etcd-0")}},
+			path: ".status.restartCount",
+			want: "3\n",
+		},
+		{
+			name: "value is an object",
+			data: map[string]interface{}{"resource": fieldPod("etcd-0")},
+			path: ".metadata.labels",
+			want: `{"app":"etcd"}` + "\n",
+		},
+		{
+			name:    "multiple items are returned",
+			data:    map[string]interface{}{"items": []interface{}{fieldPod("etcd-0"), fieldPod("etcd-1")}},
+			path:    ".status.podIP",
+			wantErr: "--field needs exactly one resource, got 2",
+		},
+		{
+			name:    "field is missing",
+			data:    map[string]interface{}{"resource": fieldPod("etcd-0")},
+			path:    ".status.hostIP",
+			wantErr: "field .status.hostIP not found in pod etcd-0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintField(&buf, tt.path, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFieldPath(t *testing.T) {
+	for _, path := range []string{"", ".", ".items[*].name", ".a[", ".a..b"} {
+		if err := ValidateFieldPath(path); err == nil {
+			t.Errorf("expected error for %q", path)
+		}
+	}
+}
@@ -5,83 +5,392 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"slices"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
+	"unicode/utf8"
 )
 
 // Format represents an output format.
 type Format string
 
 const (
-	FormatText Format = "text"
-	FormatJSON Format = "json"
-	FormatYAML Format = "yaml"
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatJSONL Format = "jsonl"
+	FormatYAML  Format = "yaml"
+	FormatName  Format = "name"
+
+	// FormatGoTemplate is selected by "-o go-template=<template>".
+	FormatGoTemplate Format = "go-template"
+	// FormatJSONPath is selected by "-o jsonpath=<expression>".
+	FormatJSONPath Format = "jsonpath"
+	// FormatCustomColumns is selected by "-o custom-columns=<spec>" or
+	// "-o columns=<spec>".
+	FormatCustomColumns Format = "custom-columns"
 )
 
+// goTemplatePrefix introduces an inline Go template in the output flag.
+const goTemplatePrefix = "go-template="
+
 // ParseFormat parses a string into a Format, defaulting to text.
 func ParseFormat(s string) Format {
+	if strings.HasPrefix(strings.ToLower(s), goTemplatePrefix) {
+		return FormatGoTemplate
+	}
+	if strings.HasPrefix(strings.ToLower(s), jsonPathPrefix) {
+		return FormatJSONPath
+	}
+	if _, ok := CustomColumnsFromFormat(s); ok {
+		return FormatCustomColumns
+	}
 	switch strings.ToLower(s) {
 	case "json":
 		return FormatJSON
+	case "jsonl":
+		return FormatJSONL
 	case "yaml":
 		return FormatYAML
+	case "name":
+		return FormatName
 	default:
 		return FormatText
 	}
 }
 
-// PrintJSON writes data as indented JSON to the writer.
+// TemplateFromFormat extracts the template text from a "go-template=..."
+// output value. It returns false if s does not select a Go template.
+func TemplateFromFormat(s string) (string, bool) {
+	if !strings.HasPrefix(strings.ToLower(s), goTemplatePrefix) {
+		return "", false
+	}
+	return s[len(goTemplatePrefix):], true
+}
+
+// compactJSON makes PrintJSON write compact JSON; set by --compact.
+var compactJSON bool
+
+// SetCompactJSON selects compact (true) or indented (false, the default)
+// output for PrintJSON.
+func SetCompactJSON(compact bool) {
+	compactJSON = compact
+}
+
+// PrintJSON writes data as indented JSON to the writer, or as compact JSON
+// after SetCompactJSON(true).
 func PrintJSON(w io.Writer, data interface{}) error {
+	if compactJSON {
+		return PrintJSONCompact(w, data)
+	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(data)
 }
 
+// PrintJSONCompact writes data as JSON on a single line, without
+// indentation, followed by one newline.
+func PrintJSONCompact(w io.Writer, data interface{}) error {
+	return json.NewEncoder(w).Encode(data)
+}
+
+// PrintJSONL writes data as JSON Lines: one compact object per line for each
+// entry in data["items"], or a single line for a single resource or any
+// other payload.
+func PrintJSONL(w io.Writer, data map[string]interface{}) error {
+	enc := json.NewEncoder(w)
+	if items, ok := data["items"].([]interface{}); ok {
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if resource, ok := data["resource"].(map[string]interface{}); ok {
+		return enc.Encode(resource)
+	}
+	return enc.Encode(data)
+}
+
 // PrintResult formats and prints an execution result based on the output format.
 func PrintResult(w io.Writer, format Format, data interface{}) error {
 	switch format {
 	case FormatJSON:
 		return PrintJSON(w, data)
+	case FormatJSONL:
+		if m, ok := data.(map[string]interface{}); ok {
+			return PrintJSONL(w, m)
+		}
+		return json.NewEncoder(w).Encode(data)
+	case FormatYAML:
+		return PrintYAML(w, data)
 	default:
 		return PrintJSON(w, data)
 	}
 }
 
+// templateFuncs are the helper functions available to PrintTemplate.
+var templateFuncs = template.FuncMap{
+	// age renders a Kubernetes timestamp as a relative age (e.g. 3h5m).
+	"age": func(v interface{}) string {
+		return TransformAge(v)
+	},
+	// default returns def when v is missing or empty.
+	"default": func(def, v interface{}) interface{} {
+		if v == nil {
+			return def
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return def
+		}
+		return v
+	},
+}
+
+// ParseTemplate parses an output template with the helper functions that
+// PrintTemplate provides. Commands call it up front to reject bad templates
+// before running a workflow.
+func ParseTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go-template: %w", err)
+	}
+	return t, nil
+}
+
+// PrintTemplate executes a Go text/template against each item in
+// data["items"] (or the single data["resource"]), writing one line per item.
+func PrintTemplate(w io.Writer, tmpl string, data map[string]interface{}) error {
+	t, err := ParseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	items, ok := data["items"].([]interface{})
+	if !ok {
+		if resource, rOk := data["resource"].(map[string]interface{}); rOk {
+			items = []interface{}{resource}
+		} else {
+			items = []interface{}{data}
+		}
+	}
+
+	for _, item := range items {
+		var buf strings.Builder
+		if err := t.Execute(&buf, item); err != nil {
+			return fmt.Errorf("executing go-template: %w", err)
+		}
+		out := buf.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamThreshold is the number of items above which resource tables are
+// streamed: rows are flushed every streamChunkRows instead of being buffered
+// until the end. This bounds memory for very large lists (nodes, events) at
+// the cost of alignment, since each chunk sizes its columns independently and
+// may not line up with the chunks before it. Smaller tables are buffered in
+// full so their columns stay perfectly aligned.
+const StreamThreshold = 1000
+
+// streamChunkRows is how many rows a streaming table buffers between flushes.
+const streamChunkRows = 250
+
+// Alignment is the horizontal alignment of a table column.
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	// AlignRight right-justifies a column, for numbers such as RESTARTS.
+	AlignRight
+)
+
+// MessageColumnWidth is the default maximum width of a MESSAGE column, so a
+// single long event message does not widen every row of the table.
+const MessageColumnWidth = 100
+
+// defaultMaxWidths are the column widths tables apply by header; see
+// Table.SetMaxWidth.
+var defaultMaxWidths = map[string]int{
+	"MESSAGE": MessageColumnWidth,
+}
+
 // Table provides a simple table writer for text output.
 type Table struct {
 	w       *tabwriter.Writer
 	headers []string
+
+	// maxWidths holds per-column maximum cell widths in runes; 0 means
+	// unlimited. Wider cells are cut and end in "…".
+	maxWidths []int
+
+	// aligns holds per-column alignment; columns past its end are left
+	// aligned. tabwriter's AlignRight flag applies to every cell, so when a
+	// column is right-aligned the table buffers rows and pads those cells
+	// itself before handing them to the tabwriter.
+	aligns []Alignment
+	rows   [][]string
+
+	// flushEvery, when positive, flushes the tabwriter after that many rows.
+	flushEvery int
+	pending    int
 }
 
 // NewTable creates a new table with the given headers.
 func NewTable(w io.Writer, headers ...string) *Table {
+	return NewTableWithAlign(w, nil, headers...)
+}
+
+// NewTableWithAlign creates a new table whose columns are aligned per
+// aligns, e.g. []Alignment{AlignLeft, AlignRight} right-justifies the second
+// column, header included.
+func NewTableWithAlign(w io.Writer, aligns []Alignment, headers ...string) *Table {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-	t := &Table{w: tw, headers: headers}
-	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	t := &Table{w: tw, headers: headers, maxWidths: make([]int, len(headers))}
+	for i, h := range headers {
+		t.maxWidths[i] = defaultMaxWidths[h]
+	}
+	if slices.Contains(aligns, AlignRight) {
+		t.aligns = aligns
+	}
+	t.writeRow(headers)
+	return t
+}
+
+// SetMaxWidth limits the cells of the column titled header to width runes,
+// ellipsizing longer values; 0 lifts the limit. It applies to rows added
+// afterwards.
+func (t *Table) SetMaxWidth(header string, width int) {
+	if i := slices.Index(t.headers, header); i >= 0 {
+		t.maxWidths[i] = width
+	}
+}
+
+// newTableFor creates a table for n rows, streaming it when n exceeds
+// StreamThreshold.
+func newTableFor(w io.Writer, n int, aligns []Alignment, headers ...string) *Table {
+	t := NewTableWithAlign(w, aligns, headers...)
+	if n > StreamThreshold {
+		t.flushEvery = streamChunkRows
+	}
 	return t
 }
 
 // AddRow adds a row to the table.
 func (t *Table) AddRow(values ...string) {
-	fmt.Fprintln(t.w, strings.Join(values, "\t"))
+	values = slices.Clone(values)
+	for i, v := range values {
+		if i < len(t.maxWidths) && t.maxWidths[i] > 0 {
+			values[i] = ellipsize(v, t.maxWidths[i])
+		}
+	}
+	t.writeRow(values)
+	if t.flushEvery > 0 {
+		t.pending++
+		if t.pending >= t.flushEvery {
+			// Write errors are sticky on the underlying writer and are
+			// reported by the final Flush.
+			_ = t.flush()
+			t.pending = 0
+		}
+	}
 }
 
 // Flush writes the table output.
 func (t *Table) Flush() error {
+	return t.flush()
+}
+
+func (t *Table) writeRow(values []string) {
+	if t.aligns != nil {
+		t.rows = append(t.rows, values)
+		return
+	}
+	fmt.Fprintln(t.w, strings.Join(values, "\t"))
+}
+
+// ellipsize cuts s to width runes, ending it in "…" when it is cut.
+func ellipsize(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+// flush pads the buffered rows' right-aligned cells to their column width,
+// writes them, and flushes the tabwriter.
+func (t *Table) flush() error {
+	if len(t.rows) > 0 {
+		widths := make([]int, len(t.aligns))
+		for _, row := range t.rows {
+			for i, cell := range row {
+				if i < len(widths) && t.aligns[i] == AlignRight {
+					widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+				}
+			}
+		}
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				if i < len(widths) && t.aligns[i] == AlignRight {
+					cell = strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + cell
+				}
+				cells[i] = cell
+			}
+			fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+		}
+		t.rows = t.rows[:0]
+	}
 	return t.w.Flush()
 }
 
 // PrintResourceTable formats Kubernetes-style resource data as a table.
-func PrintResourceTable(w io.Writer, data map[string]interface{}, resourceType string) error {
-	items, ok := data["items"].([]interface{})
+// data may be {"items": [...]}, {"resource": {...}}, a full List object
+// (e.g. kind: PodList, at the top level or under "resource"), or a bare
+// array of items; a server-side Table (kind: Table) is rendered with its own
+// columns (see PrintServerTable); anything else is printed as JSON. Each key in
+// labelColumns adds a column with that label's value, like kubectl -L.
+// Lists longer than StreamThreshold are written incrementally.
+func PrintResourceTable(w io.Writer, data interface{}, resourceType string, labelColumns ...string) error {
+	return PrintResourceTableWithOptions(w, data, resourceType, TableOptions{LabelColumns: labelColumns})
+}
+
+// TableOptions adjusts the columns of PrintResourceTableWithOptions.
+type TableOptions struct {
+	// LabelColumns adds a column per label key, like kubectl -L.
+	LabelColumns []string
+	// ShowKind prefixes NAME values with the resource kind (pod/etcd-0), as
+	// kubectl does when several resource types are listed together.
+	ShowKind bool
+	// NoTruncate lifts the default MESSAGE column width (--no-truncate).
+	NoTruncate bool
+}
+
+// PrintResourceTableWithOptions is PrintResourceTable with TableOptions.
+func PrintResourceTableWithOptions(w io.Writer, data interface{}, resourceType string, opts TableOptions) error {
+	cols := resourceColumns{labelKeys: opts.LabelColumns, noTruncate: opts.NoTruncate}
+	if opts.ShowKind {
+		cols.kind = kindOf(resourceType)
+	}
+
+	if table, ok := serverTable(data); ok {
+		return printServerTable(w, table, resourceType, cols)
+	}
+
+	items, list, ok := resourceItems(data)
 	if !ok {
-		if resource, rOk := data["resource"].(map[string]interface{}); rOk {
-			items = []interface{}{resource}
-		} else {
-			return PrintJSON(w, data)
-		}
+		return PrintJSON(w, data)
 	}
 
 	if len(items) == 0 {
@@ -91,57 +400,284 @@ func PrintResourceTable(w io.Writer, data map[string]interface{}, resourceType s
 
 	switch resourceType {
 	case "pods":
-		return printPodsTable(w, items)
+		if err := printPodsTable(w, items, cols); err != nil {
+			return err
+		}
+		printContinueFooter(w, list)
+		return nil
 	case "deployments":
-		return printDeploymentsTable(w, items)
+		return printDeploymentsTable(w, items, cols)
+	case "statefulsets", "sts":
+		return printStatefulSetsTable(w, items, cols)
+	case "daemonsets", "ds":
+		return printDaemonSetsTable(w, items, cols)
+	case "replicasets", "rs":
+		return printReplicaSetsTable(w, items, cols)
 	case "hostedclusters":
-		return printHostedClustersTable(w, items)
+		return printHostedClustersTable(w, items, cols)
+	case "nodepools", "np":
+		return printNodePoolsTable(w, items, cols)
+	case "hostedcontrolplanes", "hcp":
+		return printHostedControlPlanesTable(w, items, cols)
 	case "services", "svc":
-		return printServicesTable(w, items)
+		return printServicesTable(w, items, cols)
 	case "namespaces", "ns":
-		return printNamespacesTable(w, items)
+		return printNamespacesTable(w, items, cols)
 	case "nodes":
-		return printNodesTable(w, items)
+		return printNodesTable(w, items, cols)
 	case "events", "ev":
-		return printEventsTable(w, items)
+		return printEventsTable(w, items, cols)
 	case "configmaps", "cm":
-		return printConfigMapsTable(w, items)
+		return printConfigMapsTable(w, items, cols)
 	case "persistentvolumeclaims", "pvc":
-		return PrintTable(w, items, []Column{
-			{Header: "NAMESPACE", Path: "metadata.namespace"},
-			{Header: "NAME", Path: "metadata.name"},
-			{Header: "STATUS", Path: "status.phase"},
-			{Header: "VOLUME", Path: "spec.volumeName"},
-			{Header: "CAPACITY", Path: "status.capacity.storage"},
-			{Header: "ACCESS MODES", Path: "spec.accessModes", Transform: TransformAccessModes},
-			{Header: "STORAGECLASS", Path: "spec.storageClassName"},
-			{Header: "AGE", Path: "metadata.creationTimestamp", Transform: TransformAge},
-		})
+		return printPVCTable(w, items, cols)
 	case "persistentvolumes", "pv":
-		return PrintTable(w, items, []Column{
-			{Header: "NAME", Path: "metadata.name"},
-			{Header: "CAPACITY", Path: "spec.capacity.storage"},
-			{Header: "ACCESS MODES", Path: "spec.accessModes", Transform: TransformAccessModes},
-			{Header: "RECLAIM POLICY", Path: "spec.persistentVolumeReclaimPolicy"},
-			{Header: "STATUS", Path: "status.phase"},
-			{Header: "CLAIM", Compute: func(item map[string]interface{}, _ []interface{}) string {
-				claimRef := AsMap(item["spec"])
-				cr := AsMap(claimRef["claimRef"])
-				if ns := GetString(cr, "namespace"); ns != "" {
-					return ns + "/" + GetString(cr, "name")
-				}
-				return ""
-			}},
-			{Header: "STORAGECLASS", Path: "spec.storageClassName"},
-			{Header: "AGE", Path: "metadata.creationTimestamp", Transform: TransformAge},
-		})
+		return printPVTable(w, items, cols)
 	default:
-		return printGenericTable(w, items, resourceType)
+		if err := printGenericTable(w, items, resourceType, cols); err != nil {
+			return err
+		}
+		printContinueFooter(w, list)
+		return nil
 	}
 }
 
-func printPodsTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE")
+// ResourceItems returns the items in a get result. It accepts the same
+// shapes as PrintResourceTable and reports false for anything else.
+func ResourceItems(data interface{}) ([]interface{}, bool) {
+	items, _, ok := resourceItems(data)
+	return items, ok
+}
+
+// FilterByNamespacePrefix returns the items whose metadata.namespace starts
+// with prefix, keeping their order. Items without a namespace are dropped.
+func FilterByNamespacePrefix(items []interface{}, prefix string) []interface{} {
+	kept := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		ns := GetString(AsMap(AsMap(item)["metadata"]), "namespace")
+		if ns != "" && strings.HasPrefix(ns, prefix) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// resourceItems normalizes the response shapes workflows return into a list
+// of items. list is the map carrying list metadata such as the continue
+// token; it is empty for bare arrays and single resources.
+func resourceItems(data interface{}) (items []interface{}, list map[string]interface{}, ok bool) {
+	switch v := data.(type) {
+	case []interface{}:
+		return v, map[string]interface{}{}, true
+	case map[string]interface{}:
+		if isList(v) {
+			items, _ := v["items"].([]interface{})
+			return items, v, true
+		}
+		if resource, rOk := v["resource"].(map[string]interface{}); rOk {
+			if isList(resource) {
+				items, _ := resource["items"].([]interface{})
+				return items, resource, true
+			}
+			return []interface{}{resource}, v, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isList reports whether m is a list response: it has an items array, or it
+// is a Kubernetes List object (kind ending in "List") whose items may be null.
+func isList(m map[string]interface{}) bool {
+	if _, ok := m["items"].([]interface{}); ok {
+		return true
+	}
+	_, hasItems := m["items"]
+	return hasItems && strings.HasSuffix(GetString(m, "kind"), "List")
+}
+
+// PrintResourceNames writes one "kind/name" line per item, like kubectl -o name.
+// The kind comes from the item's "kind" field when present, otherwise from the
+// singular form of resourceType. Namespaces are not included.
+func PrintResourceNames(w io.Writer, data map[string]interface{}, resourceType string) error {
+	items, ok := data["items"].([]interface{})
+	if !ok {
+		resource, rOk := data["resource"].(map[string]interface{})
+		if !rOk {
+			return nil
+		}
+		items = []interface{}{resource}
+	}
+
+	for _, raw := range items {
+		item := AsMap(raw)
+		name := GetString(AsMap(item["metadata"]), "name")
+		if name == "" {
+			continue
+		}
+		kind := strings.ToLower(GetString(item, "kind"))
+		if kind == "" {
+			kind = singularKind(resourceType)
+		}
+		fmt.Fprintf(w, "%s/%s\n", kind, name)
+	}
+	return nil
+}
+
+// singularKind converts a plural resource type (e.g. "pods") to the lowercase
+// kind used in kubectl -o name output (e.g. "pod").
+func singularKind(resourceType string) string {
+	switch resourceType {
+	case "endpoints":
+		return resourceType
+	case "ingresses":
+		return "ingress"
+	case "networkpolicies":
+		return "networkpolicy"
+	case "storageclasses":
+		return "storageclass"
+	}
+	return strings.TrimSuffix(resourceType, "s")
+}
+
+// resourceTable is a Table whose rows end with one column per requested
+// label key.
+type resourceTable struct {
+	*Table
+	labelKeys []string
+	// kind, when set, prefixes the NAME column (index nameCol) with "kind/".
+	kind    string
+	nameCol int
+}
+
+// resourceColumns are the per-call column options passed to each resource
+// table renderer.
+type resourceColumns struct {
+	labelKeys []string
+	// kind prefixes NAME values ("pod/etcd-0") when set.
+	kind string
+	// noTruncate lifts the default column widths.
+	noTruncate bool
+}
+
+// nameColumn is the NAME column for PrintTable-based renderers.
+func (c resourceColumns) nameColumn() Column {
+	col := Column{Header: "NAME", Path: "metadata.name"}
+	if c.kind != "" {
+		col.Transform = func(v interface{}) string { return c.kind + "/" + fmt.Sprintf("%v", v) }
+	}
+	return col
+}
+
+func newResourceTable(w io.Writer, n int, cols resourceColumns, headers ...string) *resourceTable {
+	return newAlignedResourceTable(w, n, cols, nil, headers...)
+}
+
+// newAlignedResourceTable is newResourceTable with per-column alignment for
+// headers; label columns stay left aligned.
+func newAlignedResourceTable(w io.Writer, n int, cols resourceColumns, aligns []Alignment, headers ...string) *resourceTable {
+	nameCol := slices.Index(headers, "NAME")
+	for _, key := range cols.labelKeys {
+		headers = append(headers, labelHeader(key))
+	}
+	t := newTableFor(w, n, aligns, headers...)
+	if cols.noTruncate {
+		t.SetMaxWidth("MESSAGE", 0)
+	}
+	return &resourceTable{
+		Table:     t,
+		labelKeys: cols.labelKeys,
+		kind:      cols.kind,
+		nameCol:   nameCol,
+	}
+}
+
+// addRow adds values followed by the item's value for each label key,
+// blank when the label is absent.
+func (t *resourceTable) addRow(meta map[string]interface{}, values ...string) {
+	if t.kind != "" && t.nameCol >= 0 && t.nameCol < len(values) {
+		values[t.nameCol] = t.kind + "/" + values[t.nameCol]
+	}
+	labels := AsMap(meta["labels"])
+	for _, key := range t.labelKeys {
+		values = append(values, GetString(labels, key))
+	}
+	t.AddRow(values...)
+}
+
+// kindOf returns the lowercase singular kind shown by ShowKind for a
+// resource type or alias, e.g. "pods" and "po" give "pod".
+func kindOf(resourceType string) string {
+	if kind, ok := kindAliases[resourceType]; ok {
+		return kind
+	}
+	return strings.TrimSuffix(resourceType, "s")
+}
+
+// kindAliases maps the short resource type aliases PrintResourceTable
+// accepts to their kind.
+var kindAliases = map[string]string{
+	"sts": "statefulset",
+	"ds":  "daemonset",
+	"rs":  "replicaset",
+	"np":  "nodepool",
+	"hcp": "hostedcontrolplane",
+	"svc": "service",
+	"ns":  "namespace",
+	"ev":  "event",
+	"cm":  "configmap",
+	"pvc": "persistentvolumeclaim",
+	"pv":  "persistentvolume",
+}
+
+// labelHeader is the column header for a label key: the upper-cased part
+// after the last "/", as kubectl -L shows it.
+func labelHeader(key string) string {
+	return strings.ToUpper(key[strings.LastIndex(key, "/")+1:])
+}
+
+// labelColumnDefs returns PrintTable columns for the requested label keys.
+func labelColumnDefs(labelKeys []string) []Column {
+	cols := make([]Column, 0, len(labelKeys))
+	for _, key := range labelKeys {
+		cols = append(cols, Column{Header: labelHeader(key), Compute: func(item map[string]interface{}, _ []interface{}) string {
+			return GetString(AsMap(AsMap(item["metadata"])["labels"]), key)
+		}})
+	}
+	return cols
+}
+
+// ContinueToken returns the token for the next page of a list response, from
+// its "continue" field or metadata.continue, or "" on the last page.
+func ContinueToken(list map[string]interface{}) string {
+	if token := stringVal(list, "continue"); token != "" {
+		return token
+	}
+	return stringVal(AsMap(list["metadata"]), "continue")
+}
+
+// printContinueFooter tells the user how to fetch the next page when a list
+// response was truncated and carries a continue token.
+func printContinueFooter(w io.Writer, data map[string]interface{}) {
+	token := ContinueToken(data)
+	if token == "" {
+		return
+	}
+
+	remaining := getInt(data, "remaining_item_count")
+	if remaining == 0 {
+		remaining = getInt(AsMap(data["metadata"]), "remainingItemCount")
+	}
+	if remaining > 0 {
+		fmt.Fprintf(w, "... %d more (use --continue %s)\n", remaining, token)
+	} else {
+		fmt.Fprintf(w, "... more results available (use --continue %s)\n", token)
+	}
+}
+
+func printPodsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newAlignedResourceTable(w, len(items), cols,
+		[]Alignment{AlignLeft, AlignLeft, AlignRight, AlignLeft, AlignRight, AlignLeft},
+		"NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
@@ -151,7 +687,7 @@ func printPodsTable(w io.Writer, items []interface{}) error {
 		podStatus := podEffectiveStatus(status)
 		restarts := podRestartCount(status)
 
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "namespace"),
 			GetString(meta, "name"),
 			fmt.Sprintf("%d/%d", readyCount, totalCount),
@@ -163,8 +699,10 @@ func printPodsTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printDeploymentsTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAMESPACE", "NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE")
+func printDeploymentsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newAlignedResourceTable(w, len(items), cols,
+		[]Alignment{AlignLeft, AlignLeft, AlignRight, AlignRight, AlignRight, AlignLeft},
+		"NAMESPACE", "NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
@@ -176,7 +714,7 @@ func printDeploymentsTable(w io.Writer, items []interface{}) error {
 		updated := getInt(status, "updatedReplicas")
 		available := getInt(status, "availableReplicas")
 
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "namespace"),
 			GetString(meta, "name"),
 			fmt.Sprintf("%d/%d", ready, desired),
@@ -188,26 +726,81 @@ func printDeploymentsTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printHostedClustersTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAMESPACE", "NAME", "VERSION", "PROGRESS", "AVAILABLE", "AGE")
+func printStatefulSetsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "READY", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
 		spec := AsMap(m["spec"])
 		status := AsMap(m["status"])
 
-		release := AsMap(spec["release"])
-		version := GetString(release, "image")
-		if version == "" {
-			version = "<none>"
-		} else if len(version) > 40 {
-			version = version[:40] + "..."
-		}
+		t.addRow(meta,
+			GetString(meta, "namespace"),
+			GetString(meta, "name"),
+			fmt.Sprintf("%d/%d", getInt(status, "readyReplicas"), getInt(spec, "replicas")),
+			age(GetString(meta, "creationTimestamp")),
+		)
+	}
+	return t.Flush()
+}
+
+func printDaemonSetsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newAlignedResourceTable(w, len(items), cols,
+		[]Alignment{AlignLeft, AlignLeft, AlignRight, AlignRight, AlignRight, AlignRight, AlignRight, AlignLeft},
+		"NAMESPACE", "NAME", "DESIRED", "CURRENT", "READY", "UP-TO-DATE", "AVAILABLE", "AGE")
+	for _, item := range items {
+		m := AsMap(item)
+		meta := AsMap(m["metadata"])
+		status := AsMap(m["status"])
+
+		t.addRow(meta,
+			GetString(meta, "namespace"),
+			GetString(meta, "name"),
+			fmt.Sprintf("%d", getInt(status, "desiredNumberScheduled")),
+			fmt.Sprintf("%d", getInt(status, "currentNumberScheduled")),
+			fmt.Sprintf("%d", getInt(status, "numberReady")),
+			fmt.Sprintf("%d", getInt(status, "updatedNumberScheduled")),
+			fmt.Sprintf("%d", getInt(status, "numberAvailable")),
+			age(GetString(meta, "creationTimestamp")),
+		)
+	}
+	return t.Flush()
+}
+
+func printReplicaSetsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "DESIRED", "CURRENT", "READY", "AGE")
+	for _, item := range items {
+		m := AsMap(item)
+		meta := AsMap(m["metadata"])
+		spec := AsMap(m["spec"])
+		status := AsMap(m["status"])
+
+		t.addRow(meta,
+			GetString(meta, "namespace"),
+			GetString(meta, "name"),
+			fmt.Sprintf("%d", getInt(spec, "replicas")),
+			fmt.Sprintf("%d", getInt(status, "replicas")),
+			fmt.Sprintf("%d", getInt(status, "readyReplicas")),
+			age(GetString(meta, "creationTimestamp")),
+		)
+	}
+	return t.Flush()
+}
+
+func printHostedClustersTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "VERSION", "PROGRESS", "AVAILABLE", "AGE")
+	for _, item := range items {
+		m := AsMap(item)
+		meta := AsMap(m["metadata"])
+		spec := AsMap(m["spec"])
+		status := AsMap(m["status"])
+
+		version := displayVersion(GetString(AsMap(spec["release"]), "image"))
 
 		progress := GetString(status, "progress")
-		available := conditionStatus(status, "Available")
+		available := ConditionStatus(status, "Available")
 
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "namespace"),
 			GetString(meta, "name"),
 			version,
@@ -219,14 +812,114 @@ func printHostedClustersTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printServicesTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAMESPACE", "NAME", "TYPE", "CLUSTER-IP", "AGE")
+func printNodePoolsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "CLUSTER", "DESIRED NODES", "CURRENT NODES", "VERSION", "AGE")
+	for _, item := range items {
+		m := AsMap(item)
+		meta := AsMap(m["metadata"])
+		spec := AsMap(m["spec"])
+		status := AsMap(m["status"])
+
+		version := GetString(status, "version")
+		if version == "" {
+			version = GetString(AsMap(spec["release"]), "image")
+		}
+
+		t.addRow(meta,
+			GetString(meta, "namespace"),
+			GetString(meta, "name"),
+			GetString(spec, "clusterName"),
+			fmt.Sprintf("%d", getInt(spec, "replicas")),
+			fmt.Sprintf("%d", getInt(status, "replicas")),
+			displayVersion(version),
+			age(GetString(meta, "creationTimestamp")),
+		)
+	}
+	return t.Flush()
+}
+
+func printHostedControlPlanesTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "VERSION", "READY", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
 		spec := AsMap(m["spec"])
+		status := AsMap(m["status"])
 
-		t.AddRow(
+		version := GetString(status, "version")
+		if version == "" {
+			version = GetString(spec, "releaseImage")
+		}
+
+		ready := ConditionStatus(status, "Available")
+		if r, ok := status["ready"].(bool); ok {
+			ready = "False"
+			if r {
+				ready = "True"
+			}
+		}
+
+		t.addRow(meta,
+			GetString(meta, "namespace"),
+			GetString(meta, "name"),
+			displayVersion(version),
+			ready,
+			age(GetString(meta, "creationTimestamp")),
+		)
+	}
+	return t.Flush()
+}
+
+// versionColumnWidth is the widest a VERSION column value may be.
+const versionColumnWidth = 40
+
+// displayVersion shortens a HyperShift version or release image for a table
+// column: "<none>" when unset, truncateImageRef to versionColumnWidth
+// otherwise.
+func displayVersion(version string) string {
+	if version == "" {
+		return "<none>"
+	}
+	return truncateImageRef(version, versionColumnWidth)
+}
+
+// truncateImageRef shortens an image reference to at most max runes. The
+// digest ("@sha256:...") or tag (":v4.15.0") identifies the image, so it is
+// kept and the registry path is cut in the middle with "...". When even the
+// tail does not fit, it is shown from its start after a leading "...".
+// Truncation never splits a multibyte rune.
+func truncateImageRef(ref string, max int) string {
+	runes := []rune(ref)
+	if len(runes) <= max {
+		return ref
+	}
+	const ellipsis = "..."
+	if max <= len(ellipsis) {
+		return string(runes[:max])
+	}
+
+	var tail []rune
+	if at := strings.LastIndex(ref, "@"); at >= 0 {
+		tail = []rune(ref[at:])
+	} else if colon := strings.LastIndex(ref, ":"); colon > strings.LastIndex(ref, "/") {
+		tail = []rune(ref[colon:])
+	}
+
+	budget := max - len(ellipsis)
+	if len(tail) >= budget {
+		return ellipsis + string(tail[:budget])
+	}
+	return string(runes[:budget-len(tail)]) + ellipsis + string(tail)
+}
+
+func printServicesTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "TYPE", "CLUSTER-IP", "AGE")
+	for _, item := range items {
+		m := AsMap(item)
+		meta := AsMap(m["metadata"])
+		spec := AsMap(m["spec"])
+
+		t.addRow(meta,
 			GetString(meta, "namespace"),
 			GetString(meta, "name"),
 			GetString(spec, "type"),
@@ -237,14 +930,14 @@ func printServicesTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printConfigMapsTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAMESPACE", "NAME", "DATA", "AGE")
+func printConfigMapsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "DATA", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
 		data := AsMap(m["data"])
 
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "namespace"),
 			GetString(meta, "name"),
 			fmt.Sprintf("%d", len(data)),
@@ -254,6 +947,48 @@ func printConfigMapsTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
+func printPVCTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	return PrintTable(w, items, append([]Column{
+		{Header: "NAMESPACE", Path: "metadata.namespace"},
+		cols.nameColumn(),
+		{Header: "STATUS", Path: "status.phase"},
+		{Header: "VOLUME", Path: "spec.volumeName"},
+		{Header: "CAPACITY", Compute: pvcCapacity},
+		{Header: "ACCESS MODES", Path: "spec.accessModes", Transform: TransformAccessModes},
+		{Header: "STORAGECLASS", Path: "spec.storageClassName"},
+		{Header: "AGE", Path: "metadata.creationTimestamp", Transform: TransformAge},
+	}, labelColumnDefs(cols.labelKeys)...))
+}
+
+// pvcCapacity is the provisioned capacity of a bound claim, or the requested
+// size while the claim is still pending.
+func pvcCapacity(item map[string]interface{}, _ []interface{}) string {
+	if capacity := GetString(AsMap(AsMap(item["status"])["capacity"]), "storage"); capacity != "" {
+		return capacity
+	}
+	resources := AsMap(AsMap(item["spec"])["resources"])
+	return GetString(AsMap(resources["requests"]), "storage")
+}
+
+func printPVTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	return PrintTable(w, items, append([]Column{
+		cols.nameColumn(),
+		{Header: "CAPACITY", Path: "spec.capacity.storage"},
+		{Header: "ACCESS MODES", Path: "spec.accessModes", Transform: TransformAccessModes},
+		{Header: "RECLAIM POLICY", Path: "spec.persistentVolumeReclaimPolicy"},
+		{Header: "STATUS", Path: "status.phase"},
+		{Header: "CLAIM", Compute: func(item map[string]interface{}, _ []interface{}) string {
+			cr := AsMap(AsMap(item["spec"])["claimRef"])
+			if ns := GetString(cr, "namespace"); ns != "" {
+				return ns + "/" + GetString(cr, "name")
+			}
+			return ""
+		}},
+		{Header: "STORAGECLASS", Path: "spec.storageClassName"},
+		{Header: "AGE", Path: "metadata.creationTimestamp", Transform: TransformAge},
+	}, labelColumnDefs(cols.labelKeys)...))
+}
+
 func formatAccessModes(v interface{}) string {
 	modes, ok := v.([]interface{})
 	if !ok || len(modes) == 0 {
@@ -277,13 +1012,13 @@ func formatAccessModes(v interface{}) string {
 	return strings.Join(parts, ",")
 }
 
-func printNamespacesTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAME", "STATUS", "AGE")
+func printNamespacesTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAME", "STATUS", "AGE")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
 		status := AsMap(m["status"])
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "name"),
 			GetString(status, "phase"),
 			age(GetString(meta, "creationTimestamp")),
@@ -292,8 +1027,8 @@ func printNamespacesTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printNodesTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "NAME", "STATUS", "ROLES", "AGE", "VERSION")
+func printNodesTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "NAME", "STATUS", "ROLES", "AGE", "VERSION")
 	for _, item := range items {
 		m := AsMap(item)
 		meta := AsMap(m["metadata"])
@@ -302,13 +1037,13 @@ func printNodesTable(w io.Writer, items []interface{}) error {
 
 		labels := AsMap(meta["labels"])
 		roles := nodeRoles(labels)
-		ready := conditionStatus(status, "Ready")
+		ready := ConditionStatus(status, "Ready")
 		readyStr := "NotReady"
 		if ready == "True" {
 			readyStr = "Ready"
 		}
 
-		t.AddRow(
+		t.addRow(meta,
 			GetString(meta, "name"),
 			readyStr,
 			roles,
@@ -319,20 +1054,16 @@ func printNodesTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printEventsTable(w io.Writer, items []interface{}) error {
-	t := NewTable(w, "LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE")
+func printEventsTable(w io.Writer, items []interface{}, cols resourceColumns) error {
+	t := newResourceTable(w, len(items), cols, "LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE")
 	for _, item := range items {
 		m := AsMap(item)
+		meta := AsMap(m["metadata"])
 		involvedObject := AsMap(m["involvedObject"])
 		objRef := fmt.Sprintf("%s/%s", GetString(involvedObject, "kind"), GetString(involvedObject, "name"))
 
-		lastTimestamp := GetString(m, "lastTimestamp")
-		if lastTimestamp == "" {
-			lastTimestamp = GetString(m, "eventTime")
-		}
-
-		t.AddRow(
-			age(lastTimestamp),
+		t.addRow(meta,
+			EventAge(m),
 			GetString(m, "type"),
 			GetString(m, "reason"),
 			objRef,
@@ -342,25 +1073,25 @@ func printEventsTable(w io.Writer, items []interface{}) error {
 	return t.Flush()
 }
 
-func printGenericTable(w io.Writer, items []interface{}, resourceType string) error {
+func printGenericTable(w io.Writer, items []interface{}, resourceType string, cols resourceColumns) error {
 	clusterScoped := isClusterScoped(items)
 	if clusterScoped {
-		t := NewTable(w, "NAME", "AGE")
+		t := newResourceTable(w, len(items), cols, "NAME", "AGE")
 		for _, item := range items {
 			m := AsMap(item)
 			meta := AsMap(m["metadata"])
-			t.AddRow(
+			t.addRow(meta,
 				GetString(meta, "name"),
 				age(GetString(meta, "creationTimestamp")),
 			)
 		}
 		_ = t.Flush()
 	} else {
-		t := NewTable(w, "NAMESPACE", "NAME", "AGE")
+		t := newResourceTable(w, len(items), cols, "NAMESPACE", "NAME", "AGE")
 		for _, item := range items {
 			m := AsMap(item)
 			meta := AsMap(m["metadata"])
-			t.AddRow(
+			t.addRow(meta,
 				GetString(meta, "namespace"),
 				GetString(meta, "name"),
 				age(GetString(meta, "creationTimestamp")),
@@ -473,6 +1204,23 @@ func podEffectiveStatus(status map[string]interface{}) string {
 	return phase
 }
 
+// PodHealth reports whether a pod is healthy (Running with every container
+// ready, or Completed) along with the status and READY column shown by get.
+func PodHealth(pod map[string]interface{}) (healthy bool, status, ready string) {
+	podStatus := AsMap(pod["status"])
+	status = podEffectiveStatus(podStatus)
+	readyCount, total := podReadyCounts(podStatus)
+	ready = fmt.Sprintf("%d/%d", readyCount, total)
+
+	switch {
+	case status == "Completed" || status == "Succeeded":
+		healthy = true
+	case status == "Running":
+		healthy = total > 0 && readyCount == total
+	}
+	return healthy, status, ready
+}
+
 func podReadyCounts(status map[string]interface{}) (ready, total int) {
 	containers, ok := status["containerStatuses"].([]interface{})
 	if !ok {
@@ -501,7 +1249,9 @@ func podRestartCount(status map[string]interface{}) int {
 	return total
 }
 
-func conditionStatus(status map[string]interface{}, condType string) string {
+// ConditionStatus returns the status ("True", "False", ...) of the condition
+// of type condType in a resource's status, or "Unknown" if it is absent.
+func ConditionStatus(status map[string]interface{}, condType string) string {
 	conditions, ok := status["conditions"].([]interface{})
 	if !ok {
 		return "Unknown"
@@ -515,34 +1265,125 @@ func conditionStatus(status map[string]interface{}, condType string) string {
 	return "Unknown"
 }
 
-// Age formats a Kubernetes timestamp as a human-readable duration.
+// timeNow returns the current time. Tests override it to get stable ages.
+var timeNow = time.Now
+
+// Age formats a Kubernetes timestamp as a human-readable duration. A
+// timestamp slightly in the future is 0s; one more than a minute ahead is
+// "<future>".
 func Age(timestamp string) string {
 	return age(timestamp)
 }
 
+// k8sTimeLayouts are the timestamp layouts parseK8sTime accepts, in order.
+// RFC3339Nano also covers metav1.Time and metav1.MicroTime; the others
+// appear in resources written by tools that drop the colon in the offset or
+// the timezone altogether (read as UTC).
+var k8sTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseK8sTime parses a Kubernetes timestamp, trying each of
+// k8sTimeLayouts.
+func parseK8sTime(s string) (time.Time, bool) {
+	for _, layout := range k8sTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// eventTimestamp returns when an event was last seen: lastTimestamp, or
+// eventTime for events.k8s.io/v1 events, falling back to firstTimestamp.
+// The first value that parses wins, so a malformed field does not hide a
+// good one.
+func eventTimestamp(ev map[string]interface{}) string {
+	var first string
+	for _, key := range []string{"lastTimestamp", "eventTime", "firstTimestamp"} {
+		ts := GetString(ev, key)
+		if ts == "" {
+			continue
+		}
+		if _, ok := parseK8sTime(ts); ok {
+			return ts
+		}
+		if first == "" {
+			first = ts
+		}
+	}
+	return first
+}
+
+// EventAge formats how long ago an event was last seen.
+func EventAge(ev map[string]interface{}) string {
+	return age(eventTimestamp(ev))
+}
+
+// clockSkewTolerance is how far in the future a timestamp may be before age
+// stops treating it as clock skew between the cluster and this machine.
+const clockSkewTolerance = time.Minute
+
+// futureAge is shown for timestamps further in the future than
+// clockSkewTolerance.
+const futureAge = "<future>"
+
 func age(timestamp string) string {
 	if timestamp == "" {
 		return "<unknown>"
 	}
-	t, err := time.Parse(time.RFC3339, timestamp)
-	if err != nil {
+	t, ok := parseK8sTime(timestamp)
+	if !ok {
 		return timestamp
 	}
-	return formatDuration(time.Since(t))
+	d := timeNow().Sub(t)
+	if d < -clockSkewTolerance {
+		return futureAge
+	}
+	return formatDuration(d)
 }
 
+// formatDuration renders a duration the way kubectl does, keeping a second
+// unit where it still carries useful precision (e.g. 5m12s, 3h5m, 2d4h).
+// Negative durations, from small clock skew, are shown as 0s.
 func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm", int(d.Minutes()))
+	if d < 0 {
+		d = 0
 	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh", int(d.Hours()))
+	seconds := int(d.Seconds())
+	minutes := seconds / 60
+	hours := minutes / 60
+	days := hours / 24
+
+	switch {
+	case seconds < 2*60:
+		return fmt.Sprintf("%ds", seconds)
+	case minutes < 10:
+		if s := seconds % 60; s != 0 {
+			return fmt.Sprintf("%dm%ds", minutes, s)
+		}
+		return fmt.Sprintf("%dm", minutes)
+	case hours < 1:
+		return fmt.Sprintf("%dm", minutes)
+	case hours < 8:
+		if m := minutes % 60; m != 0 {
+			return fmt.Sprintf("%dh%dm", hours, m)
+		}
+		return fmt.Sprintf("%dh", hours)
+	case hours < 48:
+		return fmt.Sprintf("%dh", hours)
+	case days < 8:
+		if h := hours % 24; h != 0 {
+			return fmt.Sprintf("%dd%dh", days, h)
+		}
+		return fmt.Sprintf("%dd", days)
+	default:
+		return fmt.Sprintf("%dd", days)
 	}
-	days := int(d.Hours() / 24)
-	return fmt.Sprintf("%dd", days)
 }
 
 // PrintAnalysis renders AI analysis output for a pod in a human-readable format.
@@ -602,17 +1443,25 @@ func PrintAnalysis(w io.Writer, data map[string]interface{}, namespace string) e
 	return nil
 }
 
-// renderStructuredAnalysis attempts to parse the AI response as structured JSON
-// and render it in a human-readable format. Returns true if it succeeded.
-func renderStructuredAnalysis(w io.Writer, raw string) bool {
-	cleaned := stripCodeFence(raw)
-
+// StructuredAnalysis parses an AI analysis response (the "ai_analysis" field
+// of an analysis result), optionally wrapped in a Markdown code fence. It
+// reports false unless the response is a JSON object with a summary.
+func StructuredAnalysis(raw string) (map[string]interface{}, bool) {
 	var parsed map[string]interface{}
-	if err := json.Unmarshal([]byte(cleaned), &parsed); err != nil {
-		return false
+	if err := json.Unmarshal([]byte(stripCodeFence(raw)), &parsed); err != nil {
+		return nil, false
 	}
-
 	if _, ok := parsed["summary"]; !ok {
+		return nil, false
+	}
+	return parsed, true
+}
+
+// renderStructuredAnalysis attempts to parse the AI response as structured JSON
+// and render it in a human-readable format. Returns true if it succeeded.
+func renderStructuredAnalysis(w io.Writer, raw string) bool {
+	parsed, ok := StructuredAnalysis(raw)
+	if !ok {
 		return false
 	}
 
@@ -625,7 +1474,9 @@ func renderStructuredAnalysis(w io.Writer, raw string) bool {
 		printSection(w, "Summary", summary)
 	}
 
-	if errors := listVal(parsed, "errors_detected"); len(errors) > 0 {
+	if objs := listObjVal(parsed, "errors_detected"); len(objs) > 0 {
+		printObjectSection(w, "Errors Detected", objs, false)
+	} else if errors := listVal(parsed, "errors_detected"); len(errors) > 0 {
 		printListSection(w, "Errors Detected", errors)
 	} else if errStr := stringVal(parsed, "errors_detected"); errStr != "" {
 		printSection(w, "Errors Detected", errStr)
@@ -635,7 +1486,9 @@ func renderStructuredAnalysis(w io.Writer, raw string) bool {
 		printSection(w, "Root Cause Analysis", rca)
 	}
 
-	if actions := listVal(parsed, "recommended_actions"); len(actions) > 0 {
+	if objs := listObjVal(parsed, "recommended_actions"); len(objs) > 0 {
+		printObjectSection(w, "Recommended Actions", objs, true)
+	} else if actions := listVal(parsed, "recommended_actions"); len(actions) > 0 {
 		printNumberedSection(w, "Recommended Actions", actions)
 	} else if actStr := stringVal(parsed, "recommended_actions"); actStr != "" {
 		printSection(w, "Recommended Actions", actStr)
@@ -687,6 +1540,59 @@ func listVal(m map[string]interface{}, key string) []string {
 	return out
 }
 
+// listObjVal returns the object items of the array at key, as newer analysis
+// payloads send e.g. {"action": "...", "command": "...", "priority": "high"}
+// instead of plain strings. Non-object items are skipped.
+func listObjVal(m map[string]interface{}, key string) []map[string]interface{} {
+	arr, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	var out []map[string]interface{}
+	for _, item := range arr {
+		if obj, ok := item.(map[string]interface{}); ok {
+			out = append(out, obj)
+		}
+	}
+	return out
+}
+
+// analysisItemTextKeys are the fields tried, in order, for the main text of
+// an object item in an analysis list.
+var analysisItemTextKeys = []string{"action", "error", "message", "description"}
+
+// printObjectSection renders object items from an analysis list: the main
+// text with a "[PRIORITY]" tag when present, then the item's command on an
+// indented "$ " line.
+func printObjectSection(w io.Writer, title string, items []map[string]interface{}, numbered bool) {
+	fmt.Fprintf(w, "  %s\n", title)
+	for i, item := range items {
+		var text string
+		for _, key := range analysisItemTextKeys {
+			if text = stringVal(item, key); text != "" {
+				break
+			}
+		}
+		if priority := stringVal(item, "priority"); priority != "" {
+			text = "[" + strings.ToUpper(priority) + "] " + text
+		}
+
+		marker, indent := "•", "      "
+		if numbered {
+			marker, indent = fmt.Sprintf("%d.", i+1), "       "
+		}
+		lines := wrapText(text, 72)
+		fmt.Fprintf(w, "    %s %s\n", marker, lines[0])
+		for _, cont := range lines[1:] {
+			fmt.Fprintf(w, "%s%s\n", indent, cont)
+		}
+		if command := stringVal(item, "command"); command != "" {
+			fmt.Fprintf(w, "%s$ %s\n", indent, command)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
 func printSection(w io.Writer, title, body string) {
 	fmt.Fprintf(w, "  %s\n", title)
 	for _, line := range wrapText(body, 76) {
@@ -794,7 +1700,9 @@ func shortenEndpoint(endpoint string) string {
 	return endpoint
 }
 
-// FormatBytes converts a numeric byte count to a human-readable string.
+// FormatBytes converts a numeric byte count to a human-readable string using
+// binary units. A value that would round up to 1024 of one unit is shown in
+// the next, so 1048575 prints as "1.0 MiB" rather than "1024.0 KiB".
 func FormatBytes(v interface{}) string {
 	var b float64
 	switch n := v.(type) {
@@ -802,26 +1710,24 @@ func FormatBytes(v interface{}) string {
 		b = n
 	case int:
 		b = float64(n)
+	case int64:
+		b = float64(n)
 	default:
 		return fmt.Sprintf("%v", v)
 	}
 
-	const (
-		KiB = 1024
-		MiB = 1024 * KiB
-		GiB = 1024 * MiB
-	)
-
-	switch {
-	case b >= GiB:
-		return fmt.Sprintf("%.1f GiB", b/GiB)
-	case b >= MiB:
-		return fmt.Sprintf("%.1f MiB", b/MiB)
-	case b >= KiB:
-		return fmt.Sprintf("%.1f KiB", b/KiB)
-	default:
+	if b < 1024 {
 		return fmt.Sprintf("%.0f B", b)
 	}
+	units := []string{"KiB", "MiB", "GiB"}
+	value := b / 1024
+	for i, unit := range units {
+		if i == len(units)-1 || math.Round(value*10)/10 < 1024 {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+		value /= 1024
+	}
+	return ""
 }
 
 // Column defines a column for PrintTable.
@@ -872,7 +1778,7 @@ func PrintTable(w io.Writer, data interface{}, columns []Column) error {
 			headers = append(headers, col.Header)
 		}
 	}
-	t := NewTable(w, headers...)
+	t := newTableFor(w, len(items), nil, headers...)
 
 	// Build rows
 	for _, item := range items {
@@ -2,9 +2,14 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 func TestFormatDuration(t *testing.T) {
@@ -14,9 +19,20 @@ func TestFormatDuration(t *testing.T) {
 		want string
 	}{
 		{"30 seconds", 30 * time.Second, "30s"},
+		{"90 seconds", 90 * time.Second, "90s"},
+		{"2 minutes 30 seconds", 2*time.Minute + 30*time.Second, "2m30s"},
 		{"5 minutes", 5 * time.Minute, "5m"},
+		{"9 minutes 59 seconds", 9*time.Minute + 59*time.Second, "9m59s"},
+		{"10 minutes 30 seconds", 10*time.Minute + 30*time.Second, "10m"},
+		{"59 minutes", 59 * time.Minute, "59m"},
 		{"2 hours", 2 * time.Hour, "2h"},
+		{"3 hours 5 minutes", 3*time.Hour + 5*time.Minute, "3h5m"},
+		{"8 hours 30 minutes", 8*time.Hour + 30*time.Minute, "8h"},
+		{"47 hours", 47 * time.Hour, "47h"},
+		{"2 days 4 hours", 52 * time.Hour, "2d4h"},
 		{"3 days", 72 * time.Hour, "3d"},
+		{"10 days 5 hours", 245 * time.Hour, "10d"},
+		{"negative", -3 * time.Second, "0s"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -27,6 +43,90 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestParseK8sTime(t *testing.T) {
+	want := time.Date(2025, 6, 1, 11, 59, 15, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{"value is RFC3339", "2025-06-01T11:59:15Z", want, true},
+		{"value has nanoseconds", "2025-06-01T11:59:15.123456789Z", want.Add(123456789), true},
+		{"value is a MicroTime", "2025-06-01T11:59:15.123456Z", want.Add(123456000), true},
+		{"value has a numeric offset", "2025-06-01T13:59:15+02:00", want, true},
+		{"offset has no colon", "2025-06-01T13:59:15+0200", want, true},
+		{"value has no timezone", "2025-06-01T11:59:15", want, true},
+		{"value uses a space separator", "2025-06-01 11:59:15Z", want, true},
+		{"value uses a space and no timezone", "2025-06-01 11:59:15", want, true},
+		{"value is not a timestamp", "yesterday", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseK8sTime(tt.value)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("parseK8sTime(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEventAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	tests := []struct {
+		name  string
+		event map[string]interface{}
+		want  string
+	}{
+		{"lastTimestamp is set", map[string]interface{}{"lastTimestamp": "2025-06-01T11:59:00Z", "eventTime": "2025-06-01T11:00:00.000000Z"}, "60s"},
+		{"only eventTime is set", map[string]interface{}{"eventTime": "2025-06-01T11:59:30.123456Z"}, "29s"},
+		{"lastTimestamp is malformed", map[string]interface{}{"lastTimestamp": "soon", "firstTimestamp": "2025-06-01T11:55:00Z"}, "5m"},
+		{"nothing parses", map[string]interface{}{"lastTimestamp": "soon"}, "soon"},
+		{"no timestamp is set", map[string]interface{}{}, "<unknown>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EventAge(tt.event); got != tt.want {
+				t.Errorf("EventAge() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAge_FixedClock(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	tests := []struct {
+		name      string
+		timestamp string
+		want      string
+	}{
+		{"timestamp is empty", "", "<unknown>"},
+		{"timestamp is unparseable", "yesterday", "yesterday"},
+		{"timestamp is seconds ago", "2025-06-01T11:59:15Z", "45s"},
+		{"timestamp is minutes ago", "2025-06-01T11:54:48Z", "5m12s"},
+		{"timestamp is hours ago", "2025-06-01T08:55:00Z", "3h5m"},
+		{"timestamp is days ago", "2025-05-30T08:00:00Z", "2d4h"},
+		{"timestamp is 2 seconds in the future", "2025-06-01T12:00:02Z", "0s"},
+		{"timestamp is 2 hours in the future", "2025-06-01T14:00:00Z", "<future>"},
+		{"timestamp has no timezone", "2025-06-01T11:59:15", "45s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := age(tt.timestamp); got != tt.want {
+				t.Errorf("age(%q) = %q, want %q", tt.timestamp, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConditionStatus(t *testing.T) {
 	status := map[string]interface{}{
 		"conditions": []interface{}{
@@ -34,16 +134,16 @@ func TestConditionStatus(t *testing.T) {
 			map[string]interface{}{"type": "Available", "status": "False"},
 		},
 	}
-	if got := conditionStatus(status, "Ready"); got != "True" {
+	if got := ConditionStatus(status, "Ready"); got != "True" {
 		t.Errorf("expected 'True', got %q", got)
 	}
-	if got := conditionStatus(status, "Available"); got != "False" {
+	if got := ConditionStatus(status, "Available"); got != "False" {
 		t.Errorf("expected 'False', got %q", got)
 	}
-	if got := conditionStatus(status, "Missing"); got != "Unknown" {
+	if got := ConditionStatus(status, "Missing"); got != "Unknown" {
 		t.Errorf("expected 'Unknown' for missing condition, got %q", got)
 	}
-	if got := conditionStatus(map[string]interface{}{}, "Ready"); got != "Unknown" {
+	if got := ConditionStatus(map[string]interface{}{}, "Ready"); got != "Unknown" {
 		t.Errorf("expected 'Unknown' for no conditions, got %q", got)
 	}
 }
@@ -350,6 +450,13 @@ func TestFormatBytes(t *testing.T) {
 		{"KiB", float64(2048), "2.0 KiB"},
 		{"bytes", float64(512), "512 B"},
 		{"int", 1073741824, "1.0 GiB"},
+		{"int64", int64(12595), "12.3 KiB"},
+		{"just below KiB", int64(1023), "1023 B"},
+		{"exactly KiB", int64(1024), "1.0 KiB"},
+		{"just below MiB", int64(1<<20 - 1), "1.0 MiB"},
+		{"exactly MiB", int64(1 << 20), "1.0 MiB"},
+		{"just below GiB", int64(1<<30 - 1), "1.0 GiB"},
+		{"beyond GiB", int64(5 << 40), "5120.0 GiB"},
 		{"string fallback", "unknown", "unknown"},
 	}
 	for _, tt := range tests {
@@ -583,6 +690,32 @@ func TestPrintPVCTable(t *testing.T) {
 	}
 }
 
+func TestPrintPVCTable_PendingUsesRequestedSize(t *testing.T) {
+	var buf bytes.Buffer
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "data-etcd-1", "namespace": "clusters-test-ns"},
+				"spec": map[string]interface{}{
+					"accessModes": []interface{}{"ReadWriteOnce"},
+					"resources":   map[string]interface{}{"requests": map[string]interface{}{"storage": "8Gi"}},
+				},
+				"status": map[string]interface{}{"phase": "Pending"},
+			},
+		},
+	}
+	if err := PrintResourceTable(&buf, data, "pvc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got:\n%s", buf.String())
+	}
+	if fields := strings.Fields(lines[1]); len(fields) < 4 || fields[2] != "Pending" || fields[3] != "8Gi" {
+		t.Errorf("expected Pending claim with requested capacity 8Gi, got %q", lines[1])
+	}
+}
+
 func TestPrintPVTable(t *testing.T) {
 	var buf bytes.Buffer
 	data := map[string]interface{}{
@@ -621,3 +754,909 @@ func TestPrintPVTable(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintJSONCompact(t *testing.T) {
+	data := map[string]interface{}{"name": "etcd-0", "labels": map[string]interface{}{"app": "etcd"}}
+
+	var indented, compact bytes.Buffer
+	if err := PrintJSON(&indented, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := PrintJSONCompact(&compact, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantIndented := "{\n  \"labels\": {\n    \"app\": \"etcd\"\n  },\n  \"name\": \"etcd-0\"\n}\n"
+	if indented.String() != wantIndented {
+		t.Errorf("PrintJSON() = %q, want %q", indented.String(), wantIndented)
+	}
+	wantCompact := `{"labels":{"app":"etcd"},"name":"etcd-0"}` + "\n"
+	if compact.String() != wantCompact {
+		t.Errorf("PrintJSONCompact() = %q, want %q", compact.String(), wantCompact)
+	}
+
+	t.Run("compact JSON is selected", func(t *testing.T) {
+		SetCompactJSON(true)
+		defer SetCompactJSON(false)
+
+		var buf bytes.Buffer
+		if err := PrintJSON(&buf, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), compact.Bytes()) {
+			t.Errorf("PrintJSON() = %q, want %q", buf.String(), compact.String())
+		}
+	})
+}
+
+func TestPrintJSONL(t *testing.T) {
+	t.Run("data has items", func(t *testing.T) {
+		data := map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-a"}},
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-b"}},
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-c"}},
+			},
+		}
+		var buf bytes.Buffer
+		if err := PrintJSONL(&buf, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+		}
+		for i, line := range lines {
+			var obj map[string]interface{}
+			if err := json.Unmarshal([]byte(line), &obj); err != nil {
+				t.Errorf("line %d is not valid JSON: %q: %v", i, line, err)
+			}
+		}
+	})
+
+	t.Run("data is a single resource", func(t *testing.T) {
+		data := map[string]interface{}{
+			"resource": map[string]interface{}{"metadata": map[string]interface{}{"name": "my-svc"}},
+		}
+		var buf bytes.Buffer
+		if err := PrintJSONL(&buf, data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := strings.Count(buf.String(), "\n"); n != 1 {
+			t.Errorf("expected a single line, got %d:\n%s", n, buf.String())
+		}
+		if !strings.Contains(buf.String(), `"name":"my-svc"`) {
+			t.Errorf("expected resource body, got %q", buf.String())
+		}
+	})
+}
+
+func TestParseFormat_JSONL(t *testing.T) {
+	if got := ParseFormat("jsonl"); got != FormatJSONL {
+		t.Errorf("ParseFormat(\"jsonl\") = %q, want %q", got, FormatJSONL)
+	}
+}
+
+func TestPrintTemplate(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "pod-a"},
+				"status":   map[string]interface{}{"phase": "Running"},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "pod-b"},
+				"status":   map[string]interface{}{},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := PrintTemplate(&buf, `{{.metadata.name}} {{default "Unknown" .status.phase}}`, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "pod-a Running\npod-b Unknown\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTemplate_ParseError(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTemplate(&buf, `{{.metadata.name`, map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected error for malformed template")
+	}
+	if !strings.Contains(err.Error(), "parsing go-template") {
+		t.Errorf("expected parse error, got: %v", err)
+	}
+}
+
+func TestTemplateFromFormat(t *testing.T) {
+	tmpl, ok := TemplateFromFormat("go-template={{.metadata.name}}")
+	if !ok || tmpl != "{{.metadata.name}}" {
+		t.Errorf("got (%q, %v), want ({{.metadata.name}}, true)", tmpl, ok)
+	}
+	if ParseFormat("go-template={{.x}}") != FormatGoTemplate {
+		t.Error("expected go-template format")
+	}
+	if _, ok := TemplateFromFormat("json"); ok {
+		t.Error("expected json not to be a template format")
+	}
+}
+
+func TestPrintResourceTable_ContinueFooter(t *testing.T) {
+	pod := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "etcd-0", "namespace": "clusters-abc", "creationTimestamp": "2025-01-01T00:00:00Z"},
+		"status":   map[string]interface{}{"phase": "Running"},
+	}
+
+	t.Run("response has a continue token", func(t *testing.T) {
+		data := map[string]interface{}{
+			"items":                []interface{}{pod},
+			"continue":             "eyJ2IjoibWV0YSJ9",
+			"remaining_item_count": float64(42),
+		}
+		var buf bytes.Buffer
+		if err := PrintResourceTable(&buf, data, "pods"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "... 42 more (use --continue eyJ2IjoibWV0YSJ9)") {
+			t.Errorf("expected continue footer, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("remaining count is unknown", func(t *testing.T) {
+		data := map[string]interface{}{
+			"items":    []interface{}{pod},
+			"continue": "tok",
+		}
+		var buf bytes.Buffer
+		if err := PrintResourceTable(&buf, data, "machines"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "more results available (use --continue tok)") {
+			t.Errorf("expected continue footer, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("there is no continue token", func(t *testing.T) {
+		data := map[string]interface{}{"items": []interface{}{pod}, "continue": nil}
+		var buf bytes.Buffer
+		if err := PrintResourceTable(&buf, data, "pods"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(buf.String(), "--continue") {
+			t.Errorf("unexpected footer:\n%s", buf.String())
+		}
+	})
+}
+
+func TestPrintResourceNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string]interface{}
+		resourceType string
+		want         string
+	}{
+		{
+			name: "listing namespaced pods",
+			data: map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "etcd-0", "namespace": "ns"}},
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "etcd-1", "namespace": "ns"}},
+			}},
+			resourceType: "pods",
+			want:         "pod/etcd-0\npod/etcd-1\n",
+		},
+		{
+			name: "item has a kind",
+			data: map[string]interface{}{"resource": map[string]interface{}{
+				"kind":     "HostedCluster",
+				"metadata": map[string]interface{}{"name": "hc1"},
+			}},
+			resourceType: "hostedclusters",
+			want:         "hostedcluster/hc1\n",
+		},
+		{
+			name: "resource type is irregular",
+			data: map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"metadata": map[string]interface{}{"name": "kubernetes"}},
+			}},
+			resourceType: "endpoints",
+			want:         "endpoints/kubernetes\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintResourceNames(&buf, tt.data, tt.resourceType); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat_Name(t *testing.T) {
+	if got := ParseFormat("name"); got != FormatName {
+		t.Errorf("ParseFormat(\"name\") = %q, want %q", got, FormatName)
+	}
+}
+
+func TestPrintResourceTable_LabelColumns(t *testing.T) {
+	pod := func(name string, labels map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "namespace": "default", "labels": labels},
+			"status":   map[string]interface{}{"phase": "Running"},
+		}
+	}
+	data := map[string]interface{}{
+		"items": []interface{}{
+			pod("web-0", map[string]interface{}{"app": "web", "tier": "frontend"}),
+			pod("cache-0", map[string]interface{}{"app": "cache", "app.kubernetes.io/version": "7.2"}),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintResourceTable(&buf, data, "pods", "tier", "app.kubernetes.io/version"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows, got:\n%s", buf.String())
+	}
+	header := strings.Fields(lines[0])
+	if got := header[len(header)-2:]; got[0] != "TIER" || got[1] != "VERSION" {
+		t.Errorf("expected TIER and VERSION label headers, got %v", header)
+	}
+
+	web := strings.Fields(lines[1])
+	if web[len(web)-1] != "frontend" {
+		t.Errorf("expected web-0 to end with its tier and a blank version, got %q", lines[1])
+	}
+	cache := strings.Fields(lines[2])
+	if cache[len(cache)-1] != "7.2" || strings.Contains(lines[2], "frontend") {
+		t.Errorf("expected cache-0 to have a blank tier and version 7.2, got %q", lines[2])
+	}
+
+	tierCol := strings.Index(lines[0], "TIER")
+	if !strings.HasPrefix(lines[1][tierCol:], "frontend") {
+		t.Errorf("expected tier value under TIER column, got:\n%s", buf.String())
+	}
+	if strings.TrimSpace(lines[2][tierCol:strings.Index(lines[0], "VERSION")]) != "" {
+		t.Errorf("expected blank tier for cache-0, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintResourceTable_RightAlignsNumbers(t *testing.T) {
+	pod := func(name string, restarts int) interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "namespace": "default"},
+			"status": map[string]interface{}{
+				"phase":             "Running",
+				"containerStatuses": []interface{}{map[string]interface{}{"ready": true, "restartCount": float64(restarts)}},
+			},
+		}
+	}
+	data := map[string]interface{}{
+		"items": []interface{}{pod("web-0", 3), pod("web-1", 1204)},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintResourceTable(&buf, data, "pods"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows, got:\n%s", buf.String())
+	}
+	// Right-aligned cells end in the same column as their header.
+	end := strings.Index(lines[0], "RESTARTS") + len("RESTARTS")
+	for _, line := range lines[1:] {
+		if line[end-1] == ' ' || line[end] != ' ' {
+			t.Errorf("expected RESTARTS value to end at column %d, got:\n%s", end, buf.String())
+		}
+	}
+	if !strings.Contains(lines[1], "       3  ") {
+		t.Errorf("expected restarts padded on the left, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[1], "default") || !strings.HasPrefix(lines[0], "NAMESPACE") {
+		t.Errorf("expected text columns left aligned, got:\n%s", buf.String())
+	}
+}
+
+func TestTable_MixedAlignment(t *testing.T) {
+	var buf bytes.Buffer
+	tbl := NewTableWithAlign(&buf, []Alignment{AlignLeft, AlignRight}, "NAME", "COUNT", "NOTE")
+	tbl.AddRow("a", "7", "x")
+	tbl.AddRow("bbbb", "12345", "y")
+	if err := tbl.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "NAME  COUNT  NOTE\n" +
+		"a         7  x\n" +
+		"bbbb  12345  y\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintResourceTable_ListShapes(t *testing.T) {
+	podItem := func(name string) interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "namespace": "default"},
+			"status":   map[string]interface{}{"phase": "Running"},
+		}
+	}
+
+	tests := []struct {
+		name string
+		data interface{}
+		want []string
+	}{
+		{
+			name: "PodList object",
+			data: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "PodList",
+				"metadata":   map[string]interface{}{"resourceVersion": "123"},
+				"items":      []interface{}{podItem("etcd-0"), podItem("etcd-1")},
+			},
+			want: []string{"NAME", "etcd-0", "etcd-1"},
+		},
+		{
+			name: "PodList is wrapped in resource",
+			data: map[string]interface{}{
+				"resource": map[string]interface{}{
+					"kind":  "PodList",
+					"items": []interface{}{podItem("etcd-0")},
+				},
+			},
+			want: []string{"NAME", "etcd-0"},
+		},
+		{
+			name: "PodList has null items",
+			data: map[string]interface{}{"kind": "PodList", "items": nil},
+			want: []string{"No pods found"},
+		},
+		{
+			name: "bare array",
+			data: []interface{}{podItem("api-0"), podItem("api-1")},
+			want: []string{"NAME", "api-0", "api-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintResourceTable(&buf, tt.data, "pods"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out := buf.String()
+			if strings.HasPrefix(strings.TrimSpace(out), "{") || strings.HasPrefix(strings.TrimSpace(out), "[") {
+				t.Fatalf("expected a table, got JSON:\n%s", out)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("expected %q in output, got:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintResourceTable_Unrecognized(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintResourceTable(&buf, map[string]interface{}{"message": "ok"}, "pods"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"message": "ok"`) {
+		t.Errorf("expected JSON fallback, got:\n%s", buf.String())
+	}
+}
+
+// nodeList returns a nodes list response with n items.
+func nodeList(n int) map[string]interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":              fmt.Sprintf("gke-node-pool-%05d", i),
+				"creationTimestamp": "2025-01-01T00:00:00Z",
+				"labels":            map[string]interface{}{"node-role.kubernetes.io/worker": ""},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+				"nodeInfo":   map[string]interface{}{"kubeletVersion": "v1.30.4"},
+			},
+		}
+	}
+	return map[string]interface{}{"items": items}
+}
+
+func TestPrintResourceTable_StreamsLargeLists(t *testing.T) {
+	n := StreamThreshold + streamChunkRows + 1
+	var buf bytes.Buffer
+	if err := PrintResourceTable(&buf, nodeList(n), "nodes"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != n+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), n+1)
+	}
+	if fields := strings.Fields(lines[0]); fields[0] != "NAME" || fields[len(fields)-1] != "VERSION" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	for i, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) != 5 || fields[0] != fmt.Sprintf("gke-node-pool-%05d", i) || fields[1] != "Ready" {
+			t.Fatalf("line %d = %q", i+1, line)
+		}
+	}
+}
+
+// writeRows fills t with n node-like rows and flushes it.
+func writeRows(t *Table, n int) {
+	for i := 0; i < n; i++ {
+		t.AddRow(fmt.Sprintf("gke-node-pool-%05d", i), "Ready", "worker", "42d", "v1.30.4")
+	}
+	_ = t.Flush()
+}
+
+// BenchmarkTable_Buffered and BenchmarkTable_Streamed compare a table that
+// buffers rows to size its columns with one that streams them; compare their
+// B/op with -benchmem.
+func BenchmarkTable_Buffered(b *testing.B) {
+	headers := []string{"NAME", "STATUS", "ROLES", "AGE", "VERSION"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writeRows(NewTable(io.Discard, headers...), 10000)
+	}
+}
+
+func BenchmarkTable_Streamed(b *testing.B) {
+	headers := []string{"NAME", "STATUS", "ROLES", "AGE", "VERSION"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writeRows(newTableFor(io.Discard, 10000, nil, headers...), 10000)
+	}
+}
+
+func BenchmarkPrintResourceTable_Nodes10k(b *testing.B) {
+	data := nodeList(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := PrintResourceTable(io.Discard, data, "nodes"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPrintResourceTable_WorkloadKinds(t *testing.T) {
+	meta := map[string]interface{}{"name": "etcd", "namespace": "clusters-test-ns", "creationTimestamp": "2025-01-01T00:00:00Z"}
+	tests := []struct {
+		resourceType string
+		item         map[string]interface{}
+		wantHeader   []string
+		wantRow      []string
+	}{
+		{
+			resourceType: "sts",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"spec":     map[string]interface{}{"replicas": float64(3)},
+				"status":   map[string]interface{}{"readyReplicas": float64(2)},
+			},
+			wantHeader: []string{"NAMESPACE", "NAME", "READY", "AGE"},
+			wantRow:    []string{"clusters-test-ns", "etcd", "2/3"},
+		},
+		{
+			resourceType: "daemonsets",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"status": map[string]interface{}{
+					"desiredNumberScheduled": float64(5),
+					"currentNumberScheduled": float64(4),
+					"numberReady":            float64(3),
+					"updatedNumberScheduled": float64(2),
+					"numberAvailable":        float64(1),
+				},
+			},
+			wantHeader: []string{"NAMESPACE", "NAME", "DESIRED", "CURRENT", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"},
+			wantRow:    []string{"clusters-test-ns", "etcd", "5", "4", "3", "2", "1"},
+		},
+		{
+			resourceType: "rs",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"spec":     map[string]interface{}{"replicas": float64(3)},
+				"status":   map[string]interface{}{"replicas": float64(3), "readyReplicas": float64(1)},
+			},
+			wantHeader: []string{"NAMESPACE", "NAME", "DESIRED", "CURRENT", "READY", "AGE"},
+			wantRow:    []string{"clusters-test-ns", "etcd", "3", "3", "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			var buf bytes.Buffer
+			data := map[string]interface{}{"items": []interface{}{tt.item}}
+			if err := PrintResourceTable(&buf, data, tt.resourceType); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected header and one row, got:\n%s", buf.String())
+			}
+			header := strings.Join(strings.Fields(lines[0]), " ")
+			if want := strings.Join(tt.wantHeader, " "); header != want {
+				t.Errorf("header = %q, want %q", header, want)
+			}
+			row := strings.Fields(lines[1])
+			if len(row) != len(tt.wantRow)+1 {
+				t.Fatalf("row = %q, want %v followed by an age", lines[1], tt.wantRow)
+			}
+			for i, want := range tt.wantRow {
+				if row[i] != want {
+					t.Errorf("column %d = %q, want %q", i, row[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintResourceTable_HyperShiftKinds(t *testing.T) {
+	longImage := "quay.io/openshift-release-dev/ocp-release:4.17.0-multi"
+	meta := map[string]interface{}{"name": "example", "namespace": "clusters", "creationTimestamp": "2025-01-01T00:00:00Z"}
+	tests := []struct {
+		name         string
+		resourceType string
+		item         map[string]interface{}
+		wantHeader   string
+		wantRow      []string
+	}{
+		{
+			name:         "nodepool with a reported version",
+			resourceType: "nodepools",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"spec":     map[string]interface{}{"clusterName": "example", "replicas": float64(3)},
+				"status":   map[string]interface{}{"replicas": float64(2), "version": "4.17.0"},
+			},
+			wantHeader: "NAMESPACE NAME CLUSTER DESIRED NODES CURRENT NODES VERSION AGE",
+			wantRow:    []string{"clusters", "example", "example", "3", "2", "4.17.0"},
+		},
+		{
+			name:         "nodepool falling back to a truncated release image",
+			resourceType: "np",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"spec": map[string]interface{}{
+					"clusterName": "example",
+					"replicas":    float64(1),
+					"release":     map[string]interface{}{"image": longImage},
+				},
+			},
+			wantHeader: "NAMESPACE NAME CLUSTER DESIRED NODES CURRENT NODES VERSION AGE",
+			wantRow:    []string{"clusters", "example", "example", "1", "0", "quay.io/openshift-releas...:4.17.0-multi"},
+		},
+		{
+			name:         "hostedcontrolplane with ready status",
+			resourceType: "hostedcontrolplanes",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"spec":     map[string]interface{}{"releaseImage": longImage},
+				"status":   map[string]interface{}{"version": "4.17.0", "ready": true},
+			},
+			wantHeader: "NAMESPACE NAME VERSION READY AGE",
+			wantRow:    []string{"clusters", "example", "4.17.0", "True"},
+		},
+		{
+			name:         "hostedcontrolplane without version or ready field",
+			resourceType: "hcp",
+			item: map[string]interface{}{
+				"metadata": meta,
+				"status": map[string]interface{}{
+					"conditions": []interface{}{map[string]interface{}{"type": "Available", "status": "False"}},
+				},
+			},
+			wantHeader: "NAMESPACE NAME VERSION READY AGE",
+			wantRow:    []string{"clusters", "example", "<none>", "False"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			data := map[string]interface{}{"items": []interface{}{tt.item}}
+			if err := PrintResourceTable(&buf, data, tt.resourceType); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected header and one row, got:\n%s", buf.String())
+			}
+			if header := strings.Join(strings.Fields(lines[0]), " "); header != tt.wantHeader {
+				t.Errorf("header = %q, want %q", header, tt.wantHeader)
+			}
+			row := strings.Fields(lines[1])
+			if len(row) != len(tt.wantRow)+1 {
+				t.Fatalf("row = %q, want %v followed by an age", lines[1], tt.wantRow)
+			}
+			for i, want := range tt.wantRow {
+				if row[i] != want {
+					t.Errorf("column %d = %q, want %q", i, row[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateImageRef(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name string
+		ref  string
+		max  int
+		want string
+	}{
+		{
+			name: "ref fits",
+			ref:  "quay.io/ocp-release:4.17.0",
+			max:  40,
+			want: "quay.io/ocp-release:4.17.0",
+		},
+		{
+			name: "tagged ref is long",
+			ref:  "us-central1-docker.pkg.dev/my-project/releases/ocp-release:v4.15.0",
+			max:  40,
+			want: "us-central1-docker.pkg.dev/my...:v4.15.0",
+		},
+		{
+			name: "digest does not fit",
+			ref:  "quay.io/openshift-release-dev/ocp-release@" + digest,
+			max:  20,
+			want: "...@sha256:012345678",
+		},
+		{
+			name: "registry has a port",
+			ref:  "registry.example.com:5000/openshift/release/images/ocp",
+			max:  20,
+			want: "registry.example....",
+		},
+		{
+			name: "ref is multibyte",
+			ref:  "ÄÖÜäöüß.example/image:v1",
+			max:  12,
+			want: "ÄÖÜäöü...:v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateImageRef(tt.ref, tt.max)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("result %q is not valid UTF-8", got)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.max {
+				t.Errorf("result has %d runes, want at most %d", n, tt.max)
+			}
+		})
+	}
+}
+
+func TestListObjVal(t *testing.T) {
+	m := map[string]interface{}{
+		"mixed":   []interface{}{"plain", map[string]interface{}{"action": "Restart"}, float64(3)},
+		"strings": []interface{}{"a", "b"},
+		"scalar":  "not a list",
+	}
+	if got := listObjVal(m, "mixed"); len(got) != 1 || got[0]["action"] != "Restart" {
+		t.Errorf("mixed = %v, want the single object item", got)
+	}
+	for _, key := range []string{"strings", "scalar", "missing"} {
+		if got := listObjVal(m, key); got != nil {
+			t.Errorf("%s = %v, want nil", key, got)
+		}
+	}
+}
+
+func TestRenderStructuredAnalysis_ObjectItems(t *testing.T) {
+	raw := `{
+		"summary": "etcd leader elections are frequent.",
+		"errors_detected": [{"error": "etcdserver: request timed out", "priority": "high"}],
+		"recommended_actions": [
+			{"action": "Check disk latency on the etcd volume", "command": "gcphcp ops etcd health -n clusters-abc", "priority": "high"},
+			{"action": "Review recent node events", "priority": "low"},
+			{"action": "Restart the member"}
+		]
+	}`
+
+	var buf bytes.Buffer
+	if !renderStructuredAnalysis(&buf, raw) {
+		t.Fatal("expected structured analysis to render")
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"  Errors Detected\n    • [HIGH] etcdserver: request timed out\n",
+		"    1. [HIGH] Check disk latency on the etcd volume\n       $ gcphcp ops etcd health -n clusters-abc\n",
+		"    2. [LOW] Review recent node events\n",
+		"    3. Restart the member\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderStructuredAnalysis_StringItems(t *testing.T) {
+	raw := `{"summary": "ok", "errors_detected": ["disk full"], "recommended_actions": ["Expand the volume", "Prune old data"]}`
+
+	var buf bytes.Buffer
+	if !renderStructuredAnalysis(&buf, raw) {
+		t.Fatal("expected structured analysis to render")
+	}
+	out := buf.String()
+	for _, want := range []string{"    • disk full\n", "    1. Expand the volume\n", "    2. Prune old data\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintResourceTableWithOptions_ShowKind(t *testing.T) {
+	item := map[string]interface{}{"metadata": map[string]interface{}{"name": "data-etcd-0", "namespace": "ns"}}
+	data := map[string]interface{}{"items": []interface{}{item}}
+
+	tests := []struct {
+		resourceType string
+		want         string
+	}{
+		{"statefulsets", "statefulset/data-etcd-0"},
+		{"sts", "statefulset/data-etcd-0"},
+		{"pvc", "persistentvolumeclaim/data-etcd-0"},
+		{"widgets", "widget/data-etcd-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintResourceTableWithOptions(&buf, data, tt.resourceType, TableOptions{ShowKind: true}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	long := strings.Repeat("x", MessageColumnWidth+20)
+	tests := []struct {
+		name     string
+		setup    func(*Table)
+		cell     string
+		wantCell string
+	}{
+		{
+			name:     "cell exceeds the column width",
+			setup:    func(t *Table) { t.SetMaxWidth("NAME", 8) },
+			cell:     "etcd-0-very-long-name",
+			wantCell: "etcd-0-…",
+		},
+		{
+			name:     "cell fits",
+			setup:    func(t *Table) { t.SetMaxWidth("NAME", 8) },
+			cell:     "etcd-0",
+			wantCell: "etcd-0",
+		},
+		{
+			name:     "width counts runes",
+			setup:    func(t *Table) { t.SetMaxWidth("NAME", 3) },
+			cell:     "ääää",
+			wantCell: "ää…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			table := NewTable(&buf, "NAME", "AGE")
+			tt.setup(table)
+			table.AddRow(tt.cell, "1m")
+			if err := table.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if got := strings.Fields(lines[1])[0]; got != tt.wantCell {
+				t.Errorf("cell = %q, want %q", got, tt.wantCell)
+			}
+		})
+	}
+
+	t.Run("table has a MESSAGE column", func(t *testing.T) {
+		var buf bytes.Buffer
+		table := NewTable(&buf, "REASON", "MESSAGE")
+		table.AddRow("BackOff", long)
+		_ = table.Flush()
+		want := strings.Repeat("x", MessageColumnWidth-1) + "…"
+		if !strings.Contains(buf.String(), want) || strings.Contains(buf.String(), long) {
+			t.Errorf("expected MESSAGE ellipsized to %d runes:\n%s", MessageColumnWidth, buf.String())
+		}
+	})
+
+	t.Run("MESSAGE width is lifted", func(t *testing.T) {
+		var buf bytes.Buffer
+		table := NewTable(&buf, "REASON", "MESSAGE")
+		table.SetMaxWidth("MESSAGE", 0)
+		table.AddRow("BackOff", long)
+		_ = table.Flush()
+		if !strings.Contains(buf.String(), long) {
+			t.Errorf("expected the full message:\n%s", buf.String())
+		}
+	})
+}
+
+func TestFilterByNamespacePrefix(t *testing.T) {
+	item := func(name, ns string) interface{} {
+		meta := map[string]interface{}{"name": name}
+		if ns != "" {
+			meta["namespace"] = ns
+		}
+		return map[string]interface{}{"metadata": meta}
+	}
+	items := []interface{}{
+		item("kas", "clusters-test-pd-test-pd"),
+		item("operator", "hypershift"),
+		item("etcd-0", "clusters-test-pd-test-pd"),
+		item("node-1", ""),
+		item("kas", "clusters-other"),
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "prefix matches",
+			prefix: "clusters-test-pd",
+			want:   []string{"kas", "etcd-0"},
+		},
+		{
+			name:   "prefix is shared",
+			prefix: "clusters-",
+			want:   []string{"kas", "etcd-0", "kas"},
+		},
+		{
+			name:   "nothing matches",
+			prefix: "openshift-",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterByNamespacePrefix(items, tt.prefix)
+			names := []string{}
+			for _, it := range got {
+				names = append(names, GetString(AsMap(AsMap(it)["metadata"]), "name"))
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("FilterByNamespacePrefix(%q) kept %v, want %v", tt.prefix, names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathPrefix introduces a JSONPath expression in the output flag.
+const jsonPathPrefix = "jsonpath="
+
+// JSONPathFromFormat extracts the expression from a "jsonpath=..." output
+// value. It returns false if s does not select JSONPath.
+func JSONPathFromFormat(s string) (string, bool) {
+	if !strings.HasPrefix(strings.ToLower(s), jsonPathPrefix) {
+		return "", false
+	}
+	return s[len(jsonPathPrefix):], true
+}
+
+// JSONPath is a parsed kubectl-style JSONPath template such as
+// "{.items[*].metadata.name}" or
+// "{range .items[*]}{.metadata.name}{\"\\t\"}{.status.phase}{\"\\n\"}{end}".
+//
+// Only the subset operators need day to day is supported: field access,
+// ['quoted'] keys, [N] indexes, [*] wildcards, string literals and range/end.
+type JSONPath struct {
+	nodes []jpNode
+}
+
+type jpNodeKind int
+
+const (
+	jpText jpNodeKind = iota
+	jpField
+	jpRange
+)
+
+type jpNode struct {
+	kind jpNodeKind
+	text string      // jpText
+	path []jpSegment // jpField, jpRange
+	body []jpNode    // jpRange
+}
+
+type jpSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParseJSONPath parses a JSONPath template. An expression without braces,
+// e.g. ".items[*].metadata.name", is treated as a single {...} expression.
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	if !strings.Contains(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+
+	p := &jpParser{input: expr}
+	nodes, err := p.parseNodes(false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing jsonpath %q: %w", expr, err)
+	}
+	return &JSONPath{nodes: nodes}, nil
+}
+
+type jpParser struct {
+	input string
+	pos   int
+}
+
+// parseNodes reads text and {...} actions until the input ends or, inside a
+// range, until the matching {end}.
+func (p *jpParser) parseNodes(inRange bool) ([]jpNode, error) {
+	var nodes []jpNode
+	for p.pos < len(p.input) {
+		open := strings.IndexByte(p.input[p.pos:], '{')
+		if open < 0 {
+			nodes = append(nodes, jpNode{kind: jpText, text: p.input[p.pos:]})
+			p.pos = len(p.input)
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jpNode{kind: jpText, text: p.input[p.pos : p.pos+open]})
+		}
+		p.pos += open + 1
+
+		action, err := p.readAction()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, fmt.Errorf("unexpected {end}")
+			}
+			return nodes, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parseJSONPathSegments(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			body, err := p.parseNodes(true)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, jpNode{kind: jpRange, path: path, body: body})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal %s", action)
+			}
+			nodes = append(nodes, jpNode{kind: jpText, text: text})
+		default:
+			path, err := parseJSONPathSegments(action)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, jpNode{kind: jpField, path: path})
+		}
+	}
+	if inRange {
+		return nil, fmt.Errorf("{range} without matching {end}")
+	}
+	return nodes, nil
+}
+
+// readAction returns the trimmed contents up to the closing brace, skipping
+// braces inside quoted strings.
+func (p *jpParser) readAction() (string, error) {
+	start := p.pos
+	var quote byte
+	for ; p.pos < len(p.input); p.pos++ {
+		c := p.input[p.pos]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				p.pos++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			action := strings.TrimSpace(p.input[start:p.pos])
+			p.pos++
+			if action == "" {
+				return "", fmt.Errorf("empty expression {}")
+			}
+			return action, nil
+		}
+	}
+	return "", fmt.Errorf("unclosed {")
+}
+
+// parseJSONPathSegments parses a path such as .items[*].metadata['name'].
+func parseJSONPathSegments(expr string) ([]jpSegment, error) {
+	expr = strings.TrimPrefix(expr, "$")
+	if expr == "" || expr == "." {
+		return nil, nil
+	}
+	if expr[0] != '.' && expr[0] != '[' {
+		return nil, fmt.Errorf("expression %q must start with '.' or '['", expr)
+	}
+
+	var segs []jpSegment
+	for i := 0; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			i++
+			j := i
+			for j < len(expr) && expr[j] != '.' && expr[j] != '[' {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("empty field name in %q", expr)
+			}
+			segs = append(segs, jpSegment{key: expr[i:j]})
+			i = j
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %q", expr)
+			}
+			inner := strings.TrimSpace(expr[i+1 : i+end])
+			i += end + 1
+			switch {
+			case inner == "*":
+				segs = append(segs, jpSegment{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				segs = append(segs, jpSegment{key: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("unsupported subscript [%s] in %q", inner, expr)
+				}
+				segs = append(segs, jpSegment{index: n, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", expr[i], expr)
+		}
+	}
+	return segs, nil
+}
+
+// Execute writes the template evaluated against data. Multiple values from a
+// single expression are separated by spaces, as in kubectl.
+func (jp *JSONPath) Execute(w io.Writer, data interface{}) error {
+	return executeJSONPath(w, jp.nodes, data)
+}
+
+func executeJSONPath(w io.Writer, nodes []jpNode, data interface{}) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case jpText:
+			if _, err := io.WriteString(w, n.text); err != nil {
+				return err
+			}
+		case jpField:
+			values := evalJSONPath(n.path, data)
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = jsonPathString(v)
+			}
+			if _, err := io.WriteString(w, strings.Join(parts, " ")); err != nil {
+				return err
+			}
+		case jpRange:
+			for _, v := range evalJSONPath(n.path, data) {
+				if err := executeJSONPath(w, n.body, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// evalJSONPath returns every value matched by path. Missing keys and
+// out-of-range indexes match nothing.
+func evalJSONPath(path []jpSegment, data interface{}) []interface{} {
+	current := []interface{}{data}
+	for _, seg := range path {
+		var next []interface{}
+		for _, v := range current {
+			switch {
+			case seg.wildcard:
+				switch t := v.(type) {
+				case []interface{}:
+					next = append(next, t...)
+				case map[string]interface{}:
+					for _, k := range sortedMapKeys(t) {
+						next = append(next, t[k])
+					}
+				}
+			case seg.isIndex:
+				if list, ok := v.([]interface{}); ok {
+					idx := seg.index
+					if idx < 0 {
+						idx += len(list)
+					}
+					if idx >= 0 && idx < len(list) {
+						next = append(next, list[idx])
+					}
+				}
+			default:
+				if m, ok := v.(map[string]interface{}); ok {
+					if val, ok := m[seg.key]; ok {
+						next = append(next, val)
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonPathString renders a matched value: strings as-is, whole numbers
+// without a decimal point, and objects/arrays as compact JSON.
+func jsonPathString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// PrintJSONPath evaluates a JSONPath template against data and writes the
+// result. Like kubectl, no trailing newline is added; use {"\n"} in the
+// template. As with PrintTemplate, a single-resource response
+// (data["resource"]) is evaluated against the resource itself.
+func PrintJSONPath(w io.Writer, expr string, data interface{}) error {
+	jp, err := ParseJSONPath(expr)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := data.(map[string]interface{}); ok {
+		if _, hasItems := m["items"]; !hasItems {
+			if resource, ok := m["resource"].(map[string]interface{}); ok {
+				data = resource
+			}
+		}
+	}
+
+	return jp.Execute(w, data)
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func jsonPathPodList() map[string]interface{} {
+	pod := func(name, phase string) interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name, "labels": map[string]interface{}{"app": "etcd"}},
+			"status":   map[string]interface{}{"phase": phase, "containerStatuses": []interface{}{map[string]interface{}{"restartCount": float64(2)}}},
+		}
+	}
+	return map[string]interface{}{
+		"items": []interface{}{pod("etcd-0", "Running"), pod("etcd-1", "Pending")},
+	}
+}
+
+func TestPrintJSONPath(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		data map[string]interface{}
+		want string
+	}{
+		{
+			name: "using a wildcard",
+			expr: "{.items[*].metadata.name}",
+			data: jsonPathPodList(),
+			want: "etcd-0 etcd-1",
+		},
+		{
+			name: "indexing",
+			expr: "{.items[1].status.phase}",
+			data: jsonPathPodList(),
+			want: "Pending",
+		},
+		{
+			name: "using range",
+			expr: `{range .items[*]}{.metadata.name}{"\t"}{.status.phase}{"\n"}{end}`,
+			data: jsonPathPodList(),
+			want: "etcd-0\tRunning\netcd-1\tPending\n",
+		},
+		{
+			name: "braces are omitted",
+			expr: ".items[*].status.phase",
+			data: jsonPathPodList(),
+			want: "Running Pending",
+		},
+		{
+			name: "using a quoted key and a number",
+			expr: "{.items[0].metadata.labels['app']} {.items[0].status.containerStatuses[0].restartCount}",
+			data: jsonPathPodList(),
+			want: "etcd 2",
+		},
+		{
+			name: "response is a single resource",
+			expr: "{.metadata.name}",
+			data: map[string]interface{}{"resource": map[string]interface{}{"metadata": map[string]interface{}{"name": "etcd-0"}}},
+			want: "etcd-0",
+		},
+		{
+			name: "field is missing",
+			expr: "{.items[*].spec.nodeName}",
+			data: jsonPathPodList(),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintJSONPath(&buf, tt.expr, tt.data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"{.items[*].metadata.name",
+		"{.items[abc]}",
+		"{range .items[*]}{.metadata.name}",
+		"{end}",
+		"{items}",
+		"{}",
+	} {
+		if _, err := ParseJSONPath(expr); err == nil {
+			t.Errorf("ParseJSONPath(%q) expected error", expr)
+		}
+	}
+}
+
+func TestJSONPathFromFormat(t *testing.T) {
+	expr, ok := JSONPathFromFormat("jsonpath={.items[*].metadata.name}")
+	if !ok || expr != "{.items[*].metadata.name}" {
+		t.Errorf("got %q, %v", expr, ok)
+	}
+	if _, ok := JSONPathFromFormat("json"); ok {
+		t.Error("expected json not to select jsonpath")
+	}
+	if ParseFormat("jsonpath={.x}") != FormatJSONPath {
+		t.Error("expected ParseFormat to return FormatJSONPath")
+	}
+}
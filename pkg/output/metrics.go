@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// executionDurationBuckets are the histogram upper bounds, in seconds, for
+// completed execution durations.
+var executionDurationBuckets = []float64{1, 5, 10, 30, 60, 120, 300, 600}
+
+// PrintExecutionMetrics writes execution statistics for workflow in the
+// Prometheus text exposition format: a counter of executions per state, from
+// counts, and a histogram of the completed execution durations. Series carry
+// the labels workflow and state (state only on the counter) and are ordered
+// so output is stable between runs.
+func PrintExecutionMetrics(w io.Writer, workflow string, counts map[string]int, durations []time.Duration) error {
+	states := make([]string, 0, len(counts))
+	for s := range counts {
+		states = append(states, s)
+	}
+	sort.Strings(states)
+
+	wfLabel := `workflow="` + escapeLabelValue(workflow) + `"`
+
+	var b strings.Builder
+	b.WriteString("# HELP gcphcp_workflow_executions_total Workflow executions by state.\n")
+	b.WriteString("# TYPE gcphcp_workflow_executions_total counter\n")
+	for _, s := range states {
+		fmt.Fprintf(&b, "gcphcp_workflow_executions_total{%s,state=\"%s\"} %d\n", wfLabel, escapeLabelValue(s), counts[s])
+	}
+
+	b.WriteString("# HELP gcphcp_workflow_execution_duration_seconds Duration of completed workflow executions.\n")
+	b.WriteString("# TYPE gcphcp_workflow_execution_duration_seconds histogram\n")
+	var sum float64
+	for _, d := range durations {
+		sum += d.Seconds()
+	}
+	for _, le := range executionDurationBuckets {
+		n := 0
+		for _, d := range durations {
+			if d.Seconds() <= le {
+				n++
+			}
+		}
+		fmt.Fprintf(&b, "gcphcp_workflow_execution_duration_seconds_bucket{%s,le=\"%s\"} %d\n", wfLabel, formatFloat(le), n)
+	}
+	fmt.Fprintf(&b, "gcphcp_workflow_execution_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", wfLabel, len(durations))
+	fmt.Fprintf(&b, "gcphcp_workflow_execution_duration_seconds_sum{%s} %s\n", wfLabel, formatFloat(sum))
+	fmt.Fprintf(&b, "gcphcp_workflow_execution_duration_seconds_count{%s} %d\n", wfLabel, len(durations))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabelValue escapes a Prometheus label value.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
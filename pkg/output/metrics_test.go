@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintExecutionMetrics(t *testing.T) {
+	counts := map[string]int{"SUCCEEDED": 2, "FAILED": 1, "ACTIVE": 1}
+	durations := []time.Duration{3 * time.Second, 45 * time.Second, 1500 * time.Millisecond}
+
+	var buf bytes.Buffer
+	if err := PrintExecutionMetrics(&buf, "get", counts, durations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	// Every line is a HELP/TYPE comment or a sample: name{labels} value.
+	sample := regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*\{([a-zA-Z_][a-zA-Z0-9_]*="[^"]*",?)*\} [0-9.e+-]+$`)
+	comment := regexp.MustCompile(`^# (HELP|TYPE) [a-zA-Z_:][a-zA-Z0-9_:]* .+$`)
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if !sample.MatchString(line) && !comment.MatchString(line) {
+			t.Errorf("invalid exposition line: %q", line)
+		}
+	}
+
+	for _, want := range []string{
+		`# TYPE gcphcp_workflow_executions_total counter`,
+		`gcphcp_workflow_executions_total{workflow="get",state="ACTIVE"} 1`,
+		`gcphcp_workflow_executions_total{workflow="get",state="FAILED"} 1`,
+		`gcphcp_workflow_executions_total{workflow="get",state="SUCCEEDED"} 2`,
+		`# TYPE gcphcp_workflow_execution_duration_seconds histogram`,
+		`gcphcp_workflow_execution_duration_seconds_bucket{workflow="get",le="1"} 0`,
+		`gcphcp_workflow_execution_duration_seconds_bucket{workflow="get",le="5"} 2`,
+		`gcphcp_workflow_execution_duration_seconds_bucket{workflow="get",le="60"} 3`,
+		`gcphcp_workflow_execution_duration_seconds_bucket{workflow="get",le="+Inf"} 3`,
+		`gcphcp_workflow_execution_duration_seconds_sum{workflow="get"} 49.5`,
+		`gcphcp_workflow_execution_duration_seconds_count{workflow="get"} 3`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("missing line %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if got := escapeLabelValue("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// quantitySuffixes maps Kubernetes quantity suffixes to their multipliers.
+// Binary suffixes are listed before decimal ones so "Mi" is not read as "M".
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"Pi", 1 << 50},
+	{"Ei", 1 << 60},
+	{"m", 1e-3},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"P", 1e15},
+	{"E", 1e18},
+}
+
+// ParseQuantity parses a Kubernetes resource quantity (e.g. "250m", "1",
+// "512Mi", "1Gi") into its base unit: cores for CPU, bytes for memory.
+func ParseQuantity(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+
+	multiplier := 1.0
+	number := s
+	for _, qs := range quantitySuffixes {
+		if strings.HasSuffix(s, qs.suffix) {
+			number = strings.TrimSuffix(s, qs.suffix)
+			multiplier = qs.multiplier
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return v * multiplier, nil
+}
+
+// FormatCPU renders a core count as a Kubernetes CPU quantity, using
+// millicores for fractional values (e.g. 0.35 -> "350m", 2 -> "2").
+func FormatCPU(cores float64) string {
+	milli := int64(cores*1000 + 0.5)
+	if milli%1000 == 0 {
+		return fmt.Sprintf("%d", milli/1000)
+	}
+	return fmt.Sprintf("%dm", milli)
+}
+
+// FormatMemory renders a byte count as a Kubernetes memory quantity using the
+// largest binary suffix that represents it exactly (e.g. 805306368 -> "768Mi").
+func FormatMemory(bytes float64) string {
+	b := int64(bytes)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"Ti", 1 << 40},
+		{"Gi", 1 << 30},
+		{"Mi", 1 << 20},
+		{"Ki", 1 << 10},
+	} {
+		if b >= unit.size && b%unit.size == 0 {
+			return fmt.Sprintf("%d%s", b/unit.size, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%d", b)
+}
@@ -0,0 +1,52 @@
+package output
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"100m", 0.1},
+		{"1", 1},
+		{"1.5", 1.5},
+		{"512Mi", 512 * 1024 * 1024},
+		{"1Gi", 1024 * 1024 * 1024},
+		{"1G", 1e9},
+		{"128974848", 128974848},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseQuantity(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseQuantity(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuantity_Invalid(t *testing.T) {
+	for _, input := range []string{"", "abc", "12Xi"} {
+		if _, err := ParseQuantity(input); err == nil {
+			t.Errorf("ParseQuantity(%q) expected error", input)
+		}
+	}
+}
+
+func TestFormatCPUAndMemory(t *testing.T) {
+	if got := FormatCPU(0.35); got != "350m" {
+		t.Errorf("FormatCPU(0.35) = %q, want 350m", got)
+	}
+	if got := FormatCPU(2); got != "2" {
+		t.Errorf("FormatCPU(2) = %q, want 2", got)
+	}
+	if got := FormatMemory(768 * 1024 * 1024); got != "768Mi" {
+		t.Errorf("FormatMemory(768Mi) = %q, want 768Mi", got)
+	}
+	if got := FormatMemory(1024 * 1024 * 1024); got != "1Gi" {
+		t.Errorf("FormatMemory(1Gi) = %q, want 1Gi", got)
+	}
+}
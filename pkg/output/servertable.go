@@ -0,0 +1,102 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// serverTable returns the Kubernetes server-side Table (kind: Table, from
+// meta.k8s.io/v1) in data, at the top level or under "resource".
+func serverTable(data interface{}) (map[string]interface{}, bool) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if resource, ok := m["resource"].(map[string]interface{}); ok {
+		m = resource
+	}
+	if GetString(m, "kind") != "Table" {
+		return nil, false
+	}
+	if _, ok := m["columnDefinitions"].([]interface{}); !ok {
+		return nil, false
+	}
+	return m, true
+}
+
+// PrintServerTable writes a server-side Table response using its own
+// columnDefinitions, so CRDs with additional printer columns render like
+// kubectl get without client-side knowledge of the type. Columns with a
+// non-zero priority (kubectl's -o wide columns) are left out. Data that is
+// not a Table is printed as JSON.
+func PrintServerTable(w io.Writer, data interface{}) error {
+	table, ok := serverTable(data)
+	if !ok {
+		return PrintJSON(w, data)
+	}
+	return printServerTable(w, table, "resources", resourceColumns{})
+}
+
+func printServerTable(w io.Writer, table map[string]interface{}, resourceType string, cols resourceColumns) error {
+	rows, _ := table["rows"].([]interface{})
+	if len(rows) == 0 {
+		fmt.Fprintf(w, "No %s found.\n", resourceType)
+		return nil
+	}
+
+	defs, _ := table["columnDefinitions"].([]interface{})
+	var (
+		headers []string
+		indexes []int
+		types   []string
+	)
+	for i, d := range defs {
+		def := AsMap(d)
+		if getInt(def, "priority") != 0 {
+			continue
+		}
+		headers = append(headers, strings.ToUpper(GetString(def, "name")))
+		indexes = append(indexes, i)
+		types = append(types, GetString(def, "type"))
+	}
+
+	t := newResourceTable(w, len(rows), cols, headers...)
+	for _, r := range rows {
+		row := AsMap(r)
+		cells, _ := row["cells"].([]interface{})
+		values := make([]string, len(indexes))
+		for j, i := range indexes {
+			var cell interface{}
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			values[j] = serverTableCell(cell, types[j])
+		}
+		t.addRow(AsMap(AsMap(row["object"])["metadata"]), values...)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	printContinueFooter(w, table)
+	return nil
+}
+
+// serverTableCell formats one Table cell. Date cells hold timestamps and are
+// shown as ages, as kubectl does.
+func serverTableCell(cell interface{}, columnType string) string {
+	switch v := cell.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		if columnType == "date" {
+			return age(v)
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
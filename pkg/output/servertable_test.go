@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// hostedClusterTable is a server-side Table for a CRD with printer columns,
+// as returned for GET .../hostedclusters with
+// Accept: application/json;as=Table;v=v1;g=meta.k8s.io.
+const hostedClusterTable = `{
+  "kind": "Table",
+  "apiVersion": "meta.k8s.io/v1",
+  "metadata": {"resourceVersion": "123"},
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "format": "name", "priority": 0},
+    {"name": "Version", "type": "string", "priority": 0},
+    {"name": "Progress", "type": "string", "priority": 0},
+    {"name": "Available", "type": "string", "priority": 0},
+    {"name": "Message", "type": "string", "priority": 1},
+    {"name": "Nodes", "type": "integer", "priority": 0},
+    {"name": "Age", "type": "date", "priority": 0}
+  ],
+  "rows": [
+    {
+      "cells": ["hc-a", "4.17.3", "Completed", "True", "The hosted control plane is available", 3, "2025-01-01T10:00:00Z"],
+      "object": {"kind": "PartialObjectMetadata", "metadata": {"name": "hc-a", "namespace": "clusters", "labels": {"env": "prod"}}}
+    },
+    {
+      "cells": ["hc-b", null, "Partial", "False", "Waiting for etcd", 0, "2025-01-01T11:30:00Z"],
+      "object": {"kind": "PartialObjectMetadata", "metadata": {"name": "hc-b", "namespace": "clusters"}}
+    }
+  ]
+}`
+
+func decodeServerTable(t *testing.T) map[string]interface{} {
+	t.Helper()
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(hostedClusterTable), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestPrintServerTable(t *testing.T) {
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	var buf bytes.Buffer
+	if err := PrintServerTable(&buf, decodeServerTable(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 rows, got:\n%s", buf.String())
+	}
+	if got := strings.Fields(lines[0]); strings.Join(got, " ") != "NAME VERSION PROGRESS AVAILABLE NODES AGE" {
+		t.Errorf("header = %v", got)
+	}
+	if got := strings.Fields(lines[1]); strings.Join(got, " ") != "hc-a 4.17.3 Completed True 3 2h" {
+		t.Errorf("row 1 = %v", got)
+	}
+	if got := strings.Fields(lines[2]); strings.Join(got, " ") != "hc-b <none> Partial False 0 30m" {
+		t.Errorf("row 2 = %v", got)
+	}
+	if strings.Contains(buf.String(), "Waiting for etcd") {
+		t.Error("priority 1 columns should be left out")
+	}
+}
+
+func TestPrintResourceTable_DetectsServerTable(t *testing.T) {
+	tests := []struct {
+		name string
+		data func(t *testing.T) interface{}
+		opts TableOptions
+		want []string
+	}{
+		{
+			name: "result is a Table",
+			data: func(t *testing.T) interface{} { return decodeServerTable(t) },
+			want: []string{"PROGRESS", "Completed"},
+		},
+		{
+			name: "table is under resource",
+			data: func(t *testing.T) interface{} {
+				return map[string]interface{}{"resource": decodeServerTable(t)}
+			},
+			want: []string{"PROGRESS", "Completed"},
+		},
+		{
+			name: "label columns and kinds are asked for",
+			data: func(t *testing.T) interface{} { return decodeServerTable(t) },
+			opts: TableOptions{LabelColumns: []string{"env"}, ShowKind: true},
+			want: []string{"ENV", "hostedcluster/hc-a", "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintResourceTableWithOptions(&buf, tt.data(t), "hostedclusters", tt.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResolveOutputWriter returns the destination for command output: stdout when
+// path is empty, otherwise the file at path (parent directories are created
+// and the file is truncated, mode 0644). Closing the stdout writer is a no-op.
+func ResolveOutputWriter(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening output file: %w", err)
+	}
+	return f, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// snapshotTimeLayout is the UTC timestamp in snapshot file names. It is
+// fixed-width, so names sort in capture order.
+const snapshotTimeLayout = "20060102T150405Z"
+
+// SnapshotExtension returns the file extension for output in format.
+func SnapshotExtension(format Format) string {
+	switch format {
+	case FormatJSON, FormatJSONL, FormatYAML:
+		return "." + string(format)
+	default:
+		return ".txt"
+	}
+}
+
+// SnapshotPath returns the path of a snapshot taken at t in dir, e.g.
+// dir/pods-20250101T120000Z.json.
+func SnapshotPath(dir, prefix, ext string, t time.Time) string {
+	return filepath.Join(dir, prefix+"-"+t.UTC().Format(snapshotTimeLayout)+ext)
+}
+
+// PruneSnapshots removes the oldest snapshots of prefix with extension ext
+// in dir so that at most keep remain, and returns the removed paths. Other
+// files in dir are left alone. keep <= 0 keeps everything.
+func PruneSnapshots(dir, prefix, ext string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix+"-") || !strings.HasSuffix(e.Name(), ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(e.Name(), prefix+"-"), ext)
+		if _, err := time.Parse(snapshotTimeLayout, stamp); err != nil {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	if len(names) <= keep {
+		return nil, nil
+	}
+
+	sort.Strings(names)
+	var removed []string
+	for _, name := range names[:len(names)-keep] {
+		path := filepath.Join(dir, name)
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("pruning snapshot: %w", err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResolveOutputWriter_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots", "pods.json")
+
+	w, err := ResolveOutputWriter(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := PrintJSON(w, map[string]interface{}{"items": []interface{}{"etcd-0"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading output file: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, raw)
+	}
+	items, _ := got["items"].([]interface{})
+	if len(items) != 1 || items[0] != "etcd-0" {
+		t.Errorf("unexpected content: %s", raw)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&^0644 != 0 {
+		t.Errorf("expected mode at most 0644, got %o", perm)
+	}
+}
+
+func TestResolveOutputWriter_Stdout(t *testing.T) {
+	w, err := ResolveOutputWriter("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing stdout writer should be a no-op, got %v", err)
+	}
+}
+
+func TestSnapshotPath(t *testing.T) {
+	at := time.Date(2025, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))
+	got := SnapshotPath("incident", "pods", SnapshotExtension(FormatJSON), at)
+	if want := filepath.Join("incident", "pods-20250101T120000Z.json"); got != want {
+		t.Errorf("SnapshotPath() = %q, want %q", got, want)
+	}
+
+	for format, want := range map[Format]string{FormatJSON: ".json", FormatJSONL: ".jsonl", FormatYAML: ".yaml", FormatText: ".txt", FormatName: ".txt"} {
+		if got := SnapshotExtension(format); got != want {
+			t.Errorf("SnapshotExtension(%s) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 5; i++ {
+		path := SnapshotPath(dir, "pods", ".json", start.Add(time.Duration(i)*time.Minute))
+		paths = append(paths, path)
+	}
+	others := []string{
+		SnapshotPath(dir, "services", ".json", start),
+		SnapshotPath(dir, "pods", ".txt", start),
+		filepath.Join(dir, "pods-notes.json"),
+	}
+	for _, p := range append(append([]string{}, paths...), others...) {
+		if err := os.WriteFile(p, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := PruneSnapshots(dir, "pods", ".json", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(removed, paths[:2]) {
+		t.Errorf("removed %v, want the two oldest %v", removed, paths[:2])
+	}
+	for i, p := range paths {
+		_, err := os.Stat(p)
+		if exists := err == nil; exists != (i >= 2) {
+			t.Errorf("%s exists = %v, want %v", filepath.Base(p), exists, i >= 2)
+		}
+	}
+	for _, p := range others {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected unrelated file %s to be kept: %v", filepath.Base(p), err)
+		}
+	}
+
+	if removed, err := PruneSnapshots(dir, "pods", ".json", 0); err != nil || removed != nil {
+		t.Errorf("keep 0 should prune nothing, got %v, %v", removed, err)
+	}
+}
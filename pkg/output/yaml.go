@@ -0,0 +1,59 @@
+package output
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrintYAML writes a workflow result as a single YAML document. A list
+// result (data["items"]) is wrapped in a v1 List, like kubectl get -o yaml;
+// a single resource (data["resource"]) is printed bare; any other payload
+// is printed as-is.
+func PrintYAML(w io.Writer, data interface{}) error {
+	if m, ok := data.(map[string]interface{}); ok {
+		if items, ok := m["items"].([]interface{}); ok {
+			data = map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      items,
+				"metadata":   map[string]interface{}{"resourceVersion": ""},
+			}
+		} else if resource, ok := m["resource"].(map[string]interface{}); ok {
+			data = resource
+		}
+	}
+
+	enc := newYAMLEncoder(w)
+	if err := enc.Encode(data); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// PrintYAMLDocuments writes each item of a list result as its own YAML
+// document, separated by "---". Results without items are printed as by
+// PrintYAML.
+func PrintYAMLDocuments(w io.Writer, data map[string]interface{}) error {
+	items, ok := data["items"].([]interface{})
+	if !ok {
+		return PrintYAML(w, data)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	enc := newYAMLEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+func newYAMLEncoder(w io.Writer) *yaml.Encoder {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	return enc
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func yamlTestResult() map[string]interface{} {
+	return map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"kind": "Pod", "metadata": map[string]interface{}{"name": "etcd-0"}},
+			map[string]interface{}{"kind": "Pod", "metadata": map[string]interface{}{"name": "etcd-1"}},
+		},
+	}
+}
+
+func TestPrintYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{
+			name: "result has items",
+			data: yamlTestResult(),
+			want: `apiVersion: v1
+items:
+  - kind: Pod
+    metadata:
+      name: etcd-0
+  - kind: Pod
+    metadata:
+      name: etcd-1
+kind: List
+metadata:
+  resourceVersion: ""
+`,
+		},
+		{
+			name: "result is a single resource",
+			data: map[string]interface{}{"resource": map[string]interface{}{"kind": "Node", "metadata": map[string]interface{}{"name": "n1"}}},
+			want: `kind: Node
+metadata:
+  name: n1
+`,
+		},
+		{
+			name: "result has no items",
+			data: map[string]interface{}{"message": "ok"},
+			want: "message: ok\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintYAML(&buf, tt.data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintYAMLDocuments(t *testing.T) {
+	t.Run("result has items", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := PrintYAMLDocuments(&buf, yamlTestResult()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := `kind: Pod
+metadata:
+  name: etcd-0
+---
+kind: Pod
+metadata:
+  name: etcd-1
+`
+		if buf.String() != want {
+			t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("result has no items", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := PrintYAMLDocuments(&buf, map[string]interface{}{"items": []interface{}{}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got %q", buf.String())
+		}
+	})
+}